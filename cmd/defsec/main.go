@@ -2,28 +2,404 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/aquasecurity/defsec/pkg/baseline"
+	"github.com/aquasecurity/defsec/pkg/extrafs"
 	"github.com/aquasecurity/defsec/pkg/formatters"
-
+	"github.com/aquasecurity/defsec/pkg/ignore"
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/scanners/terraform/fixer"
 	"github.com/aquasecurity/defsec/pkg/scanners/universal"
+	"github.com/aquasecurity/defsec/pkg/severity"
+)
 
-	"github.com/aquasecurity/defsec/pkg/extrafs"
+// exit codes returned by the defsec command
+const (
+	exitCodeOK       = 0
+	exitCodeFailures = 1
+	exitCodeError    = 2
 )
 
+const usage = `Usage: defsec [options] <dir>
+
+Scans the given directory for misconfigurations in Terraform (including plan JSON
+from "terraform show -json" and .tfstate files), CloudFormation, Azure ARM
+templates, Bicep, Kubernetes (including Helm charts and Kustomize overlays),
+Dockerfile, JSON, YAML and TOML sources.
+
+Results for Helm charts name the template but not the line, as the lines of the
+rendered output do not match the template, so inline ignores in templates have no
+effect. Use a suppression file (see --ignore-file) to ignore them instead.
+
+Options:
+%s
+Exit codes:
+  0  no failures at or above --minimum-severity were found (or --soft-fail was set)
+  1  at least one failure at or above --minimum-severity was found (only failures
+     which are not in the --baseline report, if given), or a stale ignore was
+     reported with --report-stale-ignores
+  2  invalid arguments, or an error occurred while scanning
+
+With --fix, failures are not reported and the exit code is 0 unless an error occurs.
+`
+
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
+
+// repeatedList is a flag which can be given more than once. Values are not split on commas, as with stringList.
+type repeatedList []string
+
+func (l *repeatedList) String() string {
+	return strings.Join(*l, " ")
+}
+
+func (l *repeatedList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+type flags struct {
+	format           string
+	outputPath       string
+	maxLength        int
+	minimumSeverity  string
+	includeRules     stringList
+	excludeRules     stringList
+	policyDirs       stringList
+	dataDirs         stringList
+	policyNamespaces stringList
+	tfVarsPaths      stringList
+	workspace        string
+	helmValuesFiles  stringList
+	helmSetValues    repeatedList
+	ignoreFile       string
+	reportStale      bool
+	baselinePath     string
+	fix              string
+	includePassed    bool
+	includeIgnored   bool
+	noColour         bool
+	softFail         bool
+	debug            bool
+}
+
 func main() {
-	dir := os.Args[1]
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+
+	var f flags
+
+	flagSet := flag.NewFlagSet("defsec", flag.ContinueOnError)
+	flagSet.SetOutput(stderr)
+	flagSet.StringVar(&f.format, "format", "lovely", "output format: lovely (human-readable), json, csv, checkstyle, junit, sarif, html, markdown, gitlab-sast or sonarqube")
+	flagSet.StringVar(&f.outputPath, "out", "", "write output to the given file instead of stdout")
+	flagSet.IntVar(&f.maxLength, "max-length", 0, "maximum length of markdown output, e.g. 65536 for a GitHub comment - 0 for no limit")
+	flagSet.StringVar(&f.minimumSeverity, "minimum-severity", string(severity.Low), "ignore failures below this severity: LOW, MEDIUM, HIGH or CRITICAL")
+	flagSet.Var(&f.includeRules, "include-rules", "comma-separated list of rule IDs to run - all other rules are ignored")
+	flagSet.Var(&f.excludeRules, "exclude-rules", "comma-separated list of rule IDs to ignore")
+	flagSet.Var(&f.policyDirs, "policy-dirs", "comma-separated list of directories containing custom rego policies")
+	flagSet.Var(&f.dataDirs, "data-dirs", "comma-separated list of directories containing rego data")
+	flagSet.Var(&f.policyNamespaces, "policy-namespaces", "comma-separated list of namespaces containing custom rego policies")
+	flagSet.Var(&f.tfVarsPaths, "tfvars", "comma-separated list of Terraform variable files")
+	flagSet.StringVar(&f.workspace, "workspace", "", "Terraform workspace name")
+	flagSet.Var(&f.helmValuesFiles, "helm-values", "comma-separated list of values files used to render Helm charts")
+	flagSet.Var(&f.helmSetValues, "helm-set", "override a value used to render Helm charts, e.g. image.tag=latest (can be repeated)")
+	flagSet.StringVar(&f.ignoreFile, "ignore-file", "", "suppression file listing results to ignore (default: .defsecignore in the scanned directory, if present)")
+	flagSet.BoolVar(&f.reportStale, "report-stale-ignores", false, "report inline ignores which are expired, refer to an unknown rule or did not ignore anything")
+	flagSet.StringVar(&f.baselinePath, "baseline", "", "JSON report of a previous scan - failures which are already in it are ignored")
+	flagSet.StringVar(&f.fix, "fix", "", "fix failures in Terraform files where the rule declares a fix: diff (print a unified diff instead of the report) or write (rewrite the files)")
+	flagSet.BoolVar(&f.includePassed, "include-passed", false, "include passed checks in the output")
+	flagSet.BoolVar(&f.includeIgnored, "include-ignored", false, "include ignored checks in the output")
+	flagSet.BoolVar(&f.noColour, "no-colour", false, "disable coloured output")
+	flagSet.BoolVar(&f.softFail, "soft-fail", false, "always exit with code 0 unless an error occurs")
+	flagSet.BoolVar(&f.debug, "debug", false, "write debug logs to stderr")
+	flagSet.Usage = func() {
+		var defaults strings.Builder
+		flagSet.SetOutput(&defaults)
+		flagSet.PrintDefaults()
+		flagSet.SetOutput(stderr)
+		_, _ = fmt.Fprintf(stderr, usage, defaults.String())
+	}
+
+	if err := flagSet.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return exitCodeOK
+		}
+		return exitCodeError
+	}
+
+	if flagSet.NArg() != 1 {
+		flagSet.Usage()
+		return exitCodeError
+	}
+
+	if err := scanDir(flagSet.Arg(0), f, stdout, stderr); err != nil {
+		if err == errFailuresFound {
+			return exitCodeFailures
+		}
+		_, _ = fmt.Fprintf(stderr, "Error: %s\n", err)
+		return exitCodeError
+	}
+
+	return exitCodeOK
+}
+
+var errFailuresFound = fmt.Errorf("failures were found")
+
+func scanDir(dir string, f flags, stdout io.Writer, stderr io.Writer) error {
+
+	minimum := severity.StringToSeverity(f.minimumSeverity)
+	if !minimum.IsValid() {
+		return fmt.Errorf("invalid minimum severity '%s'", f.minimumSeverity)
+	}
+
+	if f.fix != "" && f.fix != "diff" && f.fix != "write" {
+		return fmt.Errorf("unsupported fix mode '%s'", f.fix)
+	}
+
+	format := strings.ToLower(f.format)
+	if !isSupportedFormat(format) {
+		return fmt.Errorf("unsupported format '%s'", f.format)
+	}
+
 	abs, err := filepath.Abs(dir)
 	if err != nil {
-		panic(err)
+		return err
+	}
+	if info, err := os.Stat(abs); err != nil {
+		return err
+	} else if !info.IsDir() {
+		return fmt.Errorf("'%s' is not a directory", dir)
+	}
+
+	var opts []universal.Option
+	if f.debug {
+		opts = append(opts, universal.OptionWithDebug(stderr))
+	}
+	if len(f.policyDirs) > 0 {
+		opts = append(opts, universal.OptionWithPolicyDirs(f.policyDirs))
+	}
+	if len(f.dataDirs) > 0 {
+		opts = append(opts, universal.OptionWithDataDirs(f.dataDirs))
+	}
+	if len(f.policyNamespaces) > 0 {
+		opts = append(opts, universal.OptionWithPolicyNamespaces(f.policyNamespaces...))
+	}
+	if len(f.tfVarsPaths) > 0 {
+		opts = append(opts, universal.OptionWithTerraformVarsPaths(f.tfVarsPaths))
 	}
-	fsys := extrafs.OSDir(abs)
-	results, err := universal.New(universal.OptionWithDebug(os.Stderr)).ScanFS(context.TODO(), fsys, ".")
+	if f.workspace != "" {
+		opts = append(opts, universal.OptionWithTerraformWorkspace(f.workspace))
+	}
+	if len(f.helmValuesFiles) > 0 {
+		opts = append(opts, universal.OptionWithHelmValuesFiles(f.helmValuesFiles))
+	}
+	if len(f.helmSetValues) > 0 {
+		opts = append(opts, universal.OptionWithHelmValues(f.helmSetValues))
+	}
+
+	if f.reportStale {
+		opts = append(opts, universal.OptionReportStaleIgnores(true))
+	}
+
+	target := extrafs.OSDir(abs)
+
+	suppressions, err := loadSuppressions(target, f.ignoreFile)
+	if err != nil {
+		return err
+	}
+	if len(suppressions) > 0 {
+		opts = append(opts, universal.OptionWithSuppressions(suppressions))
+	}
+
+	started := time.Now()
+	results, metrics, err := universal.New(opts...).ScanFSWithMetrics(context.TODO(), target, ".")
+	if err != nil {
+		return err
+	}
+
+	filterResults(results, f.includeRules, f.excludeRules, minimum)
+
+	if f.baselinePath != "" {
+		previous, err := baseline.LoadFile(f.baselinePath)
+		if err != nil {
+			return err
+		}
+		diff := previous.Apply(results)
+		_, _ = fmt.Fprintf(stderr, "Compared with baseline: %d new, %d unchanged, %d fixed\n", len(diff.New), len(diff.Unchanged), len(diff.Fixed))
+	}
+
+	if f.fix != "" {
+		return fixResults(target, abs, results, f.fix, stdout, stderr)
+	}
+
+	output := stdout
+	if f.outputPath != "" {
+		file, err := os.Create(f.outputPath)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = file.Close() }()
+		output = file
+	}
+
+	factory := formatters.New()
+	switch format {
+	case "lovely":
+		factory = factory.AsLovely()
+	case "json":
+		factory = factory.AsJSON()
+	case "csv":
+		factory = factory.AsCSV()
+	case "checkstyle":
+		factory = factory.AsCheckStyle()
+	case "junit":
+		factory = factory.AsJUnit()
+	case "sarif":
+		factory = factory.AsSARIF()
+	case "html":
+		factory = factory.AsHTML()
+	case "markdown":
+		factory = factory.AsMarkdown()
+	case "gitlab-sast":
+		factory = factory.AsGitLabSAST()
+	case "sonarqube":
+		factory = factory.AsSonarQube()
+	}
+
+	if err := factory.
+		WithWriter(output).
+		WithBaseDir(abs).
+		WithIncludePassed(f.includePassed).
+		WithIncludeIgnored(f.includeIgnored).
+		WithColoursEnabled(!f.noColour).
+		WithDebugEnabled(f.debug).
+		WithScanMetadata(scanMetadata(abs, started, metrics)).
+		WithMaxLength(f.maxLength).
+		Build().
+		Output(results); err != nil {
+		return err
+	}
+
+	if !f.softFail && (len(results.GetFailed()) > 0 || len(results.GetStaleIgnores()) > 0) {
+		return errFailuresFound
+	}
+
+	return nil
+}
+
+// fixResults prints the fixes for the failed results as unified diffs, or applies them to the files in the directory
+func fixResults(target fs.FS, dir string, results scan.Results, mode string, stdout io.Writer, stderr io.Writer) error {
+	patches, err := fixer.Fix(target, results)
 	if err != nil {
-		panic(err)
+		return err
+	}
+	if mode == "write" {
+		if err := fixer.Write(dir, patches); err != nil {
+			return err
+		}
+	}
+	var fixed int
+	for _, patch := range patches {
+		fixed += len(patch.Results)
+		if mode != "diff" {
+			continue
+		}
+		diff, err := patch.Diff()
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprint(stdout, diff)
+	}
+	verb := "Fixed"
+	if mode == "diff" {
+		verb = "Found fixes for"
 	}
-	if err := formatters.New().AsSARIF().Build().Output(results); err != nil {
-		panic(err)
+	_, _ = fmt.Fprintf(stderr, "%s %d of %d failures in %d files\n", verb, fixed, len(results.GetFailed()), len(patches))
+	return nil
+}
+
+func scanMetadata(dir string, started time.Time, metrics universal.Metrics) formatters.ScanMetadata {
+	metadata := formatters.ScanMetadata{
+		Paths:     []string{dir},
+		StartedAt: started.UTC(),
+		Duration:  metrics.Timings.Total,
+	}
+	for _, scanner := range metrics.Scanners {
+		metadata.Scanners = append(metadata.Scanners, formatters.ScannerMetadata{
+			Name:     scanner.Name,
+			Results:  scanner.Results,
+			Duration: scanner.Duration,
+		})
+	}
+	return metadata
+}
+
+// loadSuppressions reads the given suppression file, or the .defsecignore file in the scanned directory if none is given
+func loadSuppressions(target fs.FS, path string) (ignore.Suppressions, error) {
+	if path == "" {
+		return ignore.LoadSuppressions(target, "")
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ignore.ParseSuppressions(content, path)
+}
+
+var supportedFormats = []string{"lovely", "json", "csv", "checkstyle", "junit", "sarif", "html", "markdown", "gitlab-sast", "sonarqube"}
+
+func isSupportedFormat(format string) bool {
+	for _, supported := range supportedFormats {
+		if format == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// filterResults marks failures as ignored when they are excluded by rule ID or fall below the minimum severity
+func filterResults(results scan.Results, include []string, exclude []string, minimum severity.Severity) {
+	for i, result := range results {
+		if result.Status() != scan.StatusFailed {
+			continue
+		}
+		switch {
+		case len(include) > 0 && !matchesRule(result.Rule(), include),
+			matchesRule(result.Rule(), exclude),
+			result.Severity().Ordinal() < minimum.Ordinal():
+			results[i].OverrideStatus(scan.StatusIgnored)
+		}
+	}
+}
+
+func matchesRule(rule scan.Rule, ids []string) bool {
+	for _, id := range ids {
+		if strings.EqualFold(id, rule.AVDID) || strings.EqualFold(id, rule.LongID()) || (rule.LegacyID != "" && strings.EqualFold(id, rule.LegacyID)) {
+			return true
+		}
 	}
+	return false
 }
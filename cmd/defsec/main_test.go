@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	return dir
+}
+
+const insecureBucket = `
+resource "aws_s3_bucket" "logs" {
+  bucket = "logs"
+}
+`
+
+func Test_RunEveryFormat(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"main.tf": insecureBucket,
+	})
+
+	for _, format := range supportedFormats {
+		t.Run(format, func(t *testing.T) {
+			stdout := bytes.NewBuffer(nil)
+			stderr := bytes.NewBuffer(nil)
+			code := run([]string{"-format", format, "-no-colour", dir}, stdout, stderr)
+			assert.Equal(t, exitCodeFailures, code, stderr.String())
+			assert.NotContains(t, stderr.String(), "Error:")
+			assert.NotEmpty(t, stdout.String())
+		})
+	}
+}
+
+func Test_RunWithoutFailures(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"main.tf": `variable "name" {}`,
+	})
+	stderr := bytes.NewBuffer(nil)
+	assert.Equal(t, exitCodeOK, run([]string{"-format", "json", dir}, bytes.NewBuffer(nil), stderr), stderr.String())
+}
+
+func Test_RunInvalidArguments(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"main.tf": insecureBucket})
+	for _, args := range [][]string{
+		{"-format", "unknown", dir},
+		{"-minimum-severity", "extreme", dir},
+		{"-fix", "maybe", dir},
+		{},
+	} {
+		assert.Equal(t, exitCodeError, run(args, bytes.NewBuffer(nil), bytes.NewBuffer(nil)), args)
+	}
+}
+
+func Test_RunWithTFVarsAndPlanOrState(t *testing.T) {
+	plan, err := os.ReadFile("../../pkg/scanners/terraformplan/test/testdata/plan.json")
+	require.NoError(t, err)
+	state, err := os.ReadFile("../../pkg/scanners/terraformstate/test/testdata/terraform.tfstate")
+	require.NoError(t, err)
+
+	dir := writeFiles(t, map[string]string{
+		"main.tf":           "variable \"bucket\" {}\n" + insecureBucket,
+		"plan.json":         string(plan),
+		"terraform.tfstate": string(state),
+		"vars.tfvars":       `bucket = "logs"`,
+	})
+
+	stderr := bytes.NewBuffer(nil)
+	code := run([]string{"-format", "json", "-tfvars", "vars.tfvars", "-workspace", "dev", dir}, bytes.NewBuffer(nil), stderr)
+	assert.Equal(t, exitCodeFailures, code, stderr.String())
+	assert.NotContains(t, stderr.String(), "Error:")
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aquasecurity/defsec/internal/parity"
+)
+
+func main() {
+
+	root := flag.String("root", ".", "root directory of the defsec repository")
+	gaps := flag.Bool("gaps", false, "only list fields which are populated by some sources but not others")
+	flag.Parse()
+
+	fields, err := parity.Report(*root)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	header := []string{"FIELD"}
+	for _, source := range parity.Sources {
+		header = append(header, strings.ToUpper(source.Name))
+	}
+	_, _ = fmt.Fprintln(writer, strings.Join(header, "\t"))
+
+	var count int
+	for _, field := range fields {
+		if *gaps && !field.Gap() {
+			continue
+		}
+		row := []string{field.Path}
+		for _, source := range parity.Sources {
+			switch field.Sources[source.Name] {
+			case parity.Populated:
+				row = append(row, "yes")
+			case parity.Missing:
+				row = append(row, "no")
+			default:
+				row = append(row, "-")
+			}
+		}
+		_, _ = fmt.Fprintln(writer, strings.Join(row, "\t"))
+		count++
+	}
+	_ = writer.Flush()
+
+	fmt.Printf("\n%d fields listed\n", count)
+}
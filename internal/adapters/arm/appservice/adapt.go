@@ -0,0 +1,78 @@
+package appservice
+
+import (
+	"strings"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers/azure/appservice"
+	"github.com/aquasecurity/defsec/pkg/scanners/azure"
+)
+
+func Adapt(deployment azure.Deployment) appservice.AppService {
+	return appservice.AppService{
+		Services:     adaptServices(deployment),
+		FunctionApps: adaptFunctionApps(deployment),
+	}
+}
+
+func adaptServices(deployment azure.Deployment) []appservice.Service {
+	var services []appservice.Service
+	for _, resource := range deployment.GetResourcesByType("Microsoft.Web/sites") {
+		if isFunctionApp(resource) {
+			continue
+		}
+		services = append(services, adaptService(deployment, resource))
+	}
+	return services
+}
+
+func adaptFunctionApps(deployment azure.Deployment) []appservice.FunctionApp {
+	var functionApps []appservice.FunctionApp
+	for _, resource := range deployment.GetResourcesByType("Microsoft.Web/sites") {
+		if !isFunctionApp(resource) {
+			continue
+		}
+		functionApps = append(functionApps, adaptFunctionApp(resource))
+	}
+	return functionApps
+}
+
+func isFunctionApp(resource azure.Resource) bool {
+	return strings.Contains(strings.ToLower(resource.Kind.AsString()), "functionapp")
+}
+
+func adaptService(deployment azure.Deployment, resource azure.Resource) appservice.Service {
+	siteConfig := resource.Properties.GetMapValue("siteConfig")
+
+	authEnabled := types.BoolDefault(false, resource.Metadata)
+	for _, config := range deployment.GetChildResources(resource, "Microsoft.Web/sites/config") {
+		if strings.HasSuffix(strings.ToLower(config.Name.AsString()), "/authsettings") {
+			authEnabled = config.Properties.GetMapValue("enabled").AsBoolValue(false, config.Metadata)
+		}
+	}
+
+	return appservice.Service{
+		Metadata:         resource.Metadata,
+		EnableClientCert: resource.Properties.GetMapValue("clientCertEnabled").AsBoolValue(false, resource.Metadata),
+		Identity: struct{ Type types.StringValue }{
+			Type: resource.Identity.GetMapValue("type").AsStringValue("", resource.Metadata),
+		},
+		Authentication: struct{ Enabled types.BoolValue }{
+			Enabled: authEnabled,
+		},
+		Site: struct {
+			EnableHTTP2       types.BoolValue
+			MinimumTLSVersion types.StringValue
+		}{
+			EnableHTTP2:       siteConfig.GetMapValue("http20Enabled").AsBoolValue(false, resource.Metadata),
+			MinimumTLSVersion: siteConfig.GetMapValue("minTlsVersion").AsStringValue("1.2", resource.Metadata),
+		},
+	}
+}
+
+func adaptFunctionApp(resource azure.Resource) appservice.FunctionApp {
+	return appservice.FunctionApp{
+		Metadata:  resource.Metadata,
+		HTTPSOnly: resource.Properties.GetMapValue("httpsOnly").AsBoolValue(false, resource.Metadata),
+	}
+}
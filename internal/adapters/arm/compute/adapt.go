@@ -0,0 +1,84 @@
+package compute
+
+import (
+	"encoding/base64"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers/azure/compute"
+	"github.com/aquasecurity/defsec/pkg/scanners/azure"
+)
+
+func Adapt(deployment azure.Deployment) compute.Compute {
+	linuxVirtualMachines, windowsVirtualMachines := adaptVirtualMachines(deployment)
+	return compute.Compute{
+		LinuxVirtualMachines:   linuxVirtualMachines,
+		WindowsVirtualMachines: windowsVirtualMachines,
+		ManagedDisks:           adaptManagedDisks(deployment),
+	}
+}
+
+func adaptVirtualMachines(deployment azure.Deployment) ([]compute.LinuxVirtualMachine, []compute.WindowsVirtualMachine) {
+	var linuxVirtualMachines []compute.LinuxVirtualMachine
+	var windowsVirtualMachines []compute.WindowsVirtualMachine
+	for _, resource := range deployment.GetResourcesByType("Microsoft.Compute/virtualMachines") {
+		osProfile := resource.Properties.GetMapValue("osProfile")
+		if linuxConfig := osProfile.GetMapValue("linuxConfiguration"); !linuxConfig.IsNull() {
+			linuxVirtualMachines = append(linuxVirtualMachines, adaptLinuxVM(resource, osProfile, linuxConfig))
+		} else if !osProfile.GetMapValue("windowsConfiguration").IsNull() {
+			windowsVirtualMachines = append(windowsVirtualMachines, adaptWindowsVM(resource, osProfile))
+		}
+	}
+	return linuxVirtualMachines, windowsVirtualMachines
+}
+
+func adaptLinuxVM(resource azure.Resource, osProfile azure.Value, linuxConfig azure.Value) compute.LinuxVirtualMachine {
+	return compute.LinuxVirtualMachine{
+		Metadata: resource.Metadata,
+		VirtualMachine: compute.VirtualMachine{
+			Metadata:   resource.Metadata,
+			CustomData: adaptCustomData(resource, osProfile),
+		},
+		OSProfileLinuxConfig: compute.OSProfileLinuxConfig{
+			Metadata:                      linuxConfig.Metadata,
+			DisablePasswordAuthentication: linuxConfig.GetMapValue("disablePasswordAuthentication").AsBoolValue(false, linuxConfig.Metadata),
+		},
+	}
+}
+
+func adaptWindowsVM(resource azure.Resource, osProfile azure.Value) compute.WindowsVirtualMachine {
+	return compute.WindowsVirtualMachine{
+		Metadata: resource.Metadata,
+		VirtualMachine: compute.VirtualMachine{
+			Metadata:   resource.Metadata,
+			CustomData: adaptCustomData(resource, osProfile),
+		},
+	}
+}
+
+func adaptCustomData(resource azure.Resource, osProfile azure.Value) types.StringValue {
+	customData := osProfile.GetMapValue("customData")
+	if customData.Kind() != azure.KindString {
+		return customData.AsStringValue("", resource.Metadata)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(customData.AsString())
+	if err != nil {
+		decoded = []byte(customData.AsString())
+	}
+	return types.String(string(decoded), customData.Metadata)
+}
+
+func adaptManagedDisks(deployment azure.Deployment) []compute.ManagedDisk {
+	var managedDisks []compute.ManagedDisk
+	for _, resource := range deployment.GetResourcesByType("Microsoft.Compute/disks") {
+		encryption := resource.Properties.GetMapValue("encryptionSettingsCollection")
+		managedDisks = append(managedDisks, compute.ManagedDisk{
+			Metadata: resource.Metadata,
+			Encryption: compute.Encryption{
+				Metadata: encryption.Metadata,
+				// disks are encrypted at rest with platform-managed keys unless explicitly disabled
+				Enabled: encryption.GetMapValue("enabled").AsBoolValue(true, encryption.Metadata),
+			},
+		})
+	}
+	return managedDisks
+}
@@ -0,0 +1,49 @@
+package container
+
+import (
+	"github.com/aquasecurity/defsec/pkg/providers/azure/container"
+	"github.com/aquasecurity/defsec/pkg/scanners/azure"
+)
+
+func Adapt(deployment azure.Deployment) container.Container {
+	return container.Container{
+		KubernetesClusters: adaptClusters(deployment),
+	}
+}
+
+func adaptClusters(deployment azure.Deployment) []container.KubernetesCluster {
+	var clusters []container.KubernetesCluster
+	for _, resource := range deployment.GetResourcesByType("Microsoft.ContainerService/managedClusters") {
+		clusters = append(clusters, adaptCluster(resource))
+	}
+	return clusters
+}
+
+func adaptCluster(resource azure.Resource) container.KubernetesCluster {
+	properties := resource.Properties
+	networkProfile := properties.GetMapValue("networkProfile")
+	apiServerAccessProfile := properties.GetMapValue("apiServerAccessProfile")
+	addonProfiles := properties.GetMapValue("addonProfiles")
+	omsAgent := addonProfiles.GetMapValue("omsagent")
+
+	return container.KubernetesCluster{
+		Metadata: resource.Metadata,
+		NetworkProfile: container.NetworkProfile{
+			Metadata:      networkProfile.Metadata,
+			NetworkPolicy: networkProfile.GetMapValue("networkPolicy").AsStringValue("", networkProfile.Metadata),
+		},
+		EnablePrivateCluster:        apiServerAccessProfile.GetMapValue("enablePrivateCluster").AsBoolValue(false, apiServerAccessProfile.Metadata),
+		APIServerAuthorizedIPRanges: apiServerAccessProfile.GetMapValue("authorizedIPRanges").AsStringValuesList(""),
+		AddonProfile: container.AddonProfile{
+			Metadata: addonProfiles.Metadata,
+			OMSAgent: container.OMSAgent{
+				Metadata: omsAgent.Metadata,
+				Enabled:  omsAgent.GetMapValue("enabled").AsBoolValue(false, omsAgent.Metadata),
+			},
+		},
+		RoleBasedAccessControl: container.RoleBasedAccessControl{
+			Metadata: resource.Metadata,
+			Enabled:  properties.GetMapValue("enableRBAC").AsBoolValue(false, resource.Metadata),
+		},
+	}
+}
@@ -0,0 +1,151 @@
+package database
+
+import (
+	"strings"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers/azure/database"
+	"github.com/aquasecurity/defsec/pkg/scanners/azure"
+)
+
+func Adapt(deployment azure.Deployment) database.Database {
+	return database.Database{
+		MSSQLServers:      adaptMSSQLServers(deployment),
+		MariaDBServers:    adaptMariaDBServers(deployment),
+		MySQLServers:      adaptMySQLServers(deployment),
+		PostgreSQLServers: adaptPostgreSQLServers(deployment),
+	}
+}
+
+func adaptMSSQLServers(deployment azure.Deployment) []database.MSSQLServer {
+	var servers []database.MSSQLServer
+	for _, resource := range deployment.GetResourcesByType("Microsoft.Sql/servers") {
+		servers = append(servers, adaptMSSQLServer(deployment, resource))
+	}
+	return servers
+}
+
+func adaptMSSQLServer(deployment azure.Deployment, resource azure.Resource) database.MSSQLServer {
+	server := database.MSSQLServer{
+		Metadata: resource.Metadata,
+		Server: database.Server{
+			Metadata:                  resource.Metadata,
+			EnableSSLEnforcement:      types.BoolDefault(false, resource.Metadata),
+			MinimumTLSVersion:         resource.Properties.GetMapValue("minimalTlsVersion").AsStringValue("", resource.Metadata),
+			EnablePublicNetworkAccess: adaptPublicNetworkAccess(resource),
+			FirewallRules:             adaptFirewallRules(deployment, resource, "Microsoft.Sql/servers/firewallRules"),
+		},
+	}
+
+	for _, policy := range deployment.GetChildResources(resource, "Microsoft.Sql/servers/extendedAuditingSettings") {
+		server.ExtendedAuditingPolicies = append(server.ExtendedAuditingPolicies, database.ExtendedAuditingPolicy{
+			Metadata:        policy.Metadata,
+			RetentionInDays: policy.Properties.GetMapValue("retentionDays").AsIntValue(0, policy.Metadata),
+		})
+	}
+
+	for _, policy := range deployment.GetChildResources(resource, "Microsoft.Sql/servers/securityAlertPolicies") {
+		server.SecurityAlertPolicies = append(server.SecurityAlertPolicies, database.SecurityAlertPolicy{
+			Metadata:           policy.Metadata,
+			EmailAddresses:     policy.Properties.GetMapValue("emailAddresses").AsStringValuesList(""),
+			DisabledAlerts:     policy.Properties.GetMapValue("disabledAlerts").AsStringValuesList(""),
+			EmailAccountAdmins: policy.Properties.GetMapValue("emailAccountAdmins").AsBoolValue(false, policy.Metadata),
+		})
+	}
+
+	return server
+}
+
+func adaptMySQLServers(deployment azure.Deployment) []database.MySQLServer {
+	var servers []database.MySQLServer
+	for _, resource := range deployment.GetResourcesByType("Microsoft.DBforMySQL/servers") {
+		servers = append(servers, database.MySQLServer{
+			Metadata: resource.Metadata,
+			Server:   adaptServer(deployment, resource, "Microsoft.DBforMySQL/servers/firewallRules"),
+		})
+	}
+	return servers
+}
+
+func adaptMariaDBServers(deployment azure.Deployment) []database.MariaDBServer {
+	var servers []database.MariaDBServer
+	for _, resource := range deployment.GetResourcesByType("Microsoft.DBforMariaDB/servers") {
+		servers = append(servers, database.MariaDBServer{
+			Metadata: resource.Metadata,
+			Server:   adaptServer(deployment, resource, "Microsoft.DBforMariaDB/servers/firewallRules"),
+		})
+	}
+	return servers
+}
+
+func adaptPostgreSQLServers(deployment azure.Deployment) []database.PostgreSQLServer {
+	var servers []database.PostgreSQLServer
+	for _, resource := range deployment.GetResourcesByType("Microsoft.DBforPostgreSQL/servers") {
+		servers = append(servers, database.PostgreSQLServer{
+			Metadata: resource.Metadata,
+			Server:   adaptServer(deployment, resource, "Microsoft.DBforPostgreSQL/servers/firewallRules"),
+			Config:   adaptPostgreSQLConfig(deployment, resource),
+		})
+	}
+	return servers
+}
+
+func adaptServer(deployment azure.Deployment, resource azure.Resource, firewallRuleType string) database.Server {
+	sslEnforcement := types.BoolDefault(false, resource.Metadata)
+	if value := resource.Properties.GetMapValue("sslEnforcement"); value.Kind() == azure.KindString {
+		sslEnforcement = types.Bool(strings.EqualFold(value.AsString(), "Enabled"), value.Metadata)
+	}
+	return database.Server{
+		Metadata:                  resource.Metadata,
+		EnableSSLEnforcement:      sslEnforcement,
+		MinimumTLSVersion:         resource.Properties.GetMapValue("minimalTlsVersion").AsStringValue("TLSEnforcementDisabled", resource.Metadata),
+		EnablePublicNetworkAccess: adaptPublicNetworkAccess(resource),
+		FirewallRules:             adaptFirewallRules(deployment, resource, firewallRuleType),
+	}
+}
+
+// adaptPublicNetworkAccess converts the "Enabled"/"Disabled" publicNetworkAccess property - access is enabled by default
+func adaptPublicNetworkAccess(resource azure.Resource) types.BoolValue {
+	value := resource.Properties.GetMapValue("publicNetworkAccess")
+	if value.Kind() != azure.KindString {
+		return types.BoolDefault(true, resource.Metadata)
+	}
+	return types.Bool(!strings.EqualFold(value.AsString(), "Disabled"), value.Metadata)
+}
+
+func adaptFirewallRules(deployment azure.Deployment, server azure.Resource, firewallRuleType string) []database.FirewallRule {
+	var rules []database.FirewallRule
+	for _, rule := range deployment.GetChildResources(server, firewallRuleType) {
+		rules = append(rules, database.FirewallRule{
+			Metadata: rule.Metadata,
+			StartIP:  rule.Properties.GetMapValue("startIpAddress").AsStringValue("", rule.Metadata),
+			EndIP:    rule.Properties.GetMapValue("endIpAddress").AsStringValue("", rule.Metadata),
+		})
+	}
+	return rules
+}
+
+func adaptPostgreSQLConfig(deployment azure.Deployment, resource azure.Resource) database.PostgresSQLConfig {
+	config := database.PostgresSQLConfig{
+		Metadata:             resource.Metadata,
+		LogCheckpoints:       types.BoolDefault(false, resource.Metadata),
+		ConnectionThrottling: types.BoolDefault(false, resource.Metadata),
+		LogConnections:       types.BoolDefault(false, resource.Metadata),
+	}
+
+	for _, configuration := range deployment.GetChildResources(resource, "Microsoft.DBforPostgreSQL/servers/configurations") {
+		value := configuration.Properties.GetMapValue("value")
+		enabled := types.Bool(strings.EqualFold(value.AsString(), "on"), value.Metadata)
+		name := configuration.Name.AsString()
+		switch strings.ToLower(name[strings.LastIndex(name, "/")+1:]) {
+		case "log_checkpoints":
+			config.LogCheckpoints = enabled
+		case "connection_throttling":
+			config.ConnectionThrottling = enabled
+		case "log_connections":
+			config.LogConnections = enabled
+		}
+	}
+
+	return config
+}
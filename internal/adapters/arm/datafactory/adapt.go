@@ -0,0 +1,34 @@
+package datafactory
+
+import (
+	"strings"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers/azure/datafactory"
+	"github.com/aquasecurity/defsec/pkg/scanners/azure"
+)
+
+func Adapt(deployment azure.Deployment) datafactory.DataFactory {
+	return datafactory.DataFactory{
+		DataFactories: adaptFactories(deployment),
+	}
+}
+
+func adaptFactories(deployment azure.Deployment) []datafactory.Factory {
+	var factories []datafactory.Factory
+	for _, resource := range deployment.GetResourcesByType("Microsoft.DataFactory/factories") {
+		factories = append(factories, adaptFactory(resource))
+	}
+	return factories
+}
+
+func adaptFactory(resource azure.Resource) datafactory.Factory {
+	enablePublicNetwork := types.BoolDefault(true, resource.Metadata)
+	if value := resource.Properties.GetMapValue("publicNetworkAccess"); value.Kind() == azure.KindString {
+		enablePublicNetwork = types.Bool(!strings.EqualFold(value.AsString(), "Disabled"), value.Metadata)
+	}
+	return datafactory.Factory{
+		Metadata:            resource.Metadata,
+		EnablePublicNetwork: enablePublicNetwork,
+	}
+}
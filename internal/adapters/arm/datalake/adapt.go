@@ -0,0 +1,34 @@
+package datalake
+
+import (
+	"strings"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers/azure/datalake"
+	"github.com/aquasecurity/defsec/pkg/scanners/azure"
+)
+
+func Adapt(deployment azure.Deployment) datalake.DataLake {
+	return datalake.DataLake{
+		Stores: adaptStores(deployment),
+	}
+}
+
+func adaptStores(deployment azure.Deployment) []datalake.Store {
+	var stores []datalake.Store
+	for _, resource := range deployment.GetResourcesByType("Microsoft.DataLakeStore/accounts") {
+		stores = append(stores, adaptStore(resource))
+	}
+	return stores
+}
+
+func adaptStore(resource azure.Resource) datalake.Store {
+	store := datalake.Store{
+		Metadata:         resource.Metadata,
+		EnableEncryption: types.BoolDefault(true, resource.Metadata),
+	}
+	if value := resource.Properties.GetMapValue("encryptionState"); value.Kind() == azure.KindString {
+		store.EnableEncryption = types.Bool(!strings.EqualFold(value.AsString(), "Disabled"), value.Metadata)
+	}
+	return store
+}
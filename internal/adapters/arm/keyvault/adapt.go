@@ -0,0 +1,59 @@
+package keyvault
+
+import (
+	"github.com/aquasecurity/defsec/pkg/providers/azure/keyvault"
+	"github.com/aquasecurity/defsec/pkg/scanners/azure"
+)
+
+func Adapt(deployment azure.Deployment) keyvault.KeyVault {
+	return keyvault.KeyVault{
+		Vaults: adaptVaults(deployment),
+	}
+}
+
+func adaptVaults(deployment azure.Deployment) []keyvault.Vault {
+	var vaults []keyvault.Vault
+	for _, resource := range deployment.GetResourcesByType("Microsoft.KeyVault/vaults") {
+		vaults = append(vaults, adaptVault(deployment, resource))
+	}
+	return vaults
+}
+
+func adaptVault(deployment azure.Deployment, resource azure.Resource) keyvault.Vault {
+	networkACLs := resource.Properties.GetMapValue("networkAcls")
+
+	vault := keyvault.Vault{
+		Metadata:                resource.Metadata,
+		EnablePurgeProtection:   resource.Properties.GetMapValue("enablePurgeProtection").AsBoolValue(false, resource.Metadata),
+		SoftDeleteRetentionDays: resource.Properties.GetMapValue("softDeleteRetentionInDays").AsIntValue(0, resource.Metadata),
+		NetworkACLs: keyvault.NetworkACLs{
+			Metadata:      networkACLs.Metadata,
+			DefaultAction: networkACLs.GetMapValue("defaultAction").AsStringValue("", networkACLs.Metadata),
+		},
+	}
+
+	for _, secret := range deployment.GetChildResources(resource, "Microsoft.KeyVault/vaults/secrets") {
+		vault.Secrets = append(vault.Secrets, adaptSecret(secret))
+	}
+
+	for _, key := range deployment.GetChildResources(resource, "Microsoft.KeyVault/vaults/keys") {
+		vault.Keys = append(vault.Keys, adaptKey(key))
+	}
+
+	return vault
+}
+
+func adaptSecret(resource azure.Resource) keyvault.Secret {
+	return keyvault.Secret{
+		Metadata:    resource.Metadata,
+		ContentType: resource.Properties.GetMapValue("contentType").AsStringValue("", resource.Metadata),
+		ExpiryDate:  resource.Properties.GetNestedValue("attributes.exp").AsTimeValue(resource.Metadata),
+	}
+}
+
+func adaptKey(resource azure.Resource) keyvault.Key {
+	return keyvault.Key{
+		Metadata:   resource.Metadata,
+		ExpiryDate: resource.Properties.GetNestedValue("attributes.exp").AsTimeValue(resource.Metadata),
+	}
+}
@@ -0,0 +1,34 @@
+package monitor
+
+import (
+	"github.com/aquasecurity/defsec/pkg/providers/azure/monitor"
+	"github.com/aquasecurity/defsec/pkg/scanners/azure"
+)
+
+func Adapt(deployment azure.Deployment) monitor.Monitor {
+	return monitor.Monitor{
+		LogProfiles: adaptLogProfiles(deployment),
+	}
+}
+
+func adaptLogProfiles(deployment azure.Deployment) []monitor.LogProfile {
+	var logProfiles []monitor.LogProfile
+	for _, resource := range deployment.GetResourcesByType("Microsoft.Insights/logprofiles") {
+		logProfiles = append(logProfiles, adaptLogProfile(resource))
+	}
+	return logProfiles
+}
+
+func adaptLogProfile(resource azure.Resource) monitor.LogProfile {
+	retentionPolicy := resource.Properties.GetMapValue("retentionPolicy")
+	return monitor.LogProfile{
+		Metadata: resource.Metadata,
+		RetentionPolicy: monitor.RetentionPolicy{
+			Metadata: retentionPolicy.Metadata,
+			Enabled:  retentionPolicy.GetMapValue("enabled").AsBoolValue(false, retentionPolicy.Metadata),
+			Days:     retentionPolicy.GetMapValue("days").AsIntValue(0, retentionPolicy.Metadata),
+		},
+		Categories: resource.Properties.GetMapValue("categories").AsStringValuesList(""),
+		Locations:  resource.Properties.GetMapValue("locations").AsStringValuesList(""),
+	}
+}
@@ -0,0 +1,120 @@
+package network
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers/azure/network"
+	"github.com/aquasecurity/defsec/pkg/scanners/azure"
+)
+
+func Adapt(deployment azure.Deployment) network.Network {
+	return network.Network{
+		SecurityGroups:         adaptSecurityGroups(deployment),
+		NetworkWatcherFlowLogs: adaptWatcherLogs(deployment),
+	}
+}
+
+func adaptSecurityGroups(deployment azure.Deployment) []network.SecurityGroup {
+	var groups []network.SecurityGroup
+	for _, resource := range deployment.GetResourcesByType("Microsoft.Network/networkSecurityGroups") {
+		group := network.SecurityGroup{
+			Metadata: resource.Metadata,
+		}
+		for _, rule := range resource.Properties.GetMapValue("securityRules").AsList() {
+			group.Rules = append(group.Rules, adaptSecurityGroupRule(rule.Metadata, rule.GetMapValue("properties")))
+		}
+		for _, rule := range deployment.GetChildResources(resource, "Microsoft.Network/networkSecurityGroups/securityRules") {
+			group.Rules = append(group.Rules, adaptSecurityGroupRule(rule.Metadata, rule.Properties))
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+func adaptSecurityGroupRule(metadata types.Metadata, properties azure.Value) network.SecurityGroupRule {
+	rule := network.SecurityGroupRule{
+		Metadata: metadata,
+		Outbound: types.BoolDefault(false, metadata),
+		Allow:    types.BoolDefault(true, metadata),
+		Protocol: properties.GetMapValue("protocol").AsStringValue("", metadata),
+	}
+
+	if access := properties.GetMapValue("access"); access.Kind() == azure.KindString {
+		rule.Allow = types.Bool(strings.EqualFold(access.AsString(), "Allow"), access.Metadata)
+	}
+
+	if direction := properties.GetMapValue("direction"); direction.Kind() == azure.KindString {
+		rule.Outbound = types.Bool(strings.EqualFold(direction.AsString(), "Outbound"), direction.Metadata)
+	}
+
+	rule.SourceAddresses = adaptAddresses(properties, "sourceAddressPrefix", "sourceAddressPrefixes")
+	rule.SourcePorts = adaptPorts(properties, "sourcePortRange", "sourcePortRanges")
+	rule.DestinationAddresses = adaptAddresses(properties, "destinationAddressPrefix", "destinationAddressPrefixes")
+	rule.DestinationPorts = adaptPorts(properties, "destinationPortRange", "destinationPortRanges")
+
+	return rule
+}
+
+func adaptAddresses(properties azure.Value, singleKey string, multipleKey string) []types.StringValue {
+	if prefix := properties.GetMapValue(singleKey); !prefix.IsNull() {
+		return []types.StringValue{prefix.AsStringValue("", prefix.Metadata)}
+	}
+	return properties.GetMapValue(multipleKey).AsStringValuesList("")
+}
+
+func adaptPorts(properties azure.Value, singleKey string, multipleKey string) []network.PortRange {
+	var ports []network.PortRange
+	if portRange := properties.GetMapValue(singleKey); !portRange.IsNull() {
+		return append(ports, expandRange(portRange.AsString(), portRange.Metadata))
+	}
+	for _, portRange := range properties.GetMapValue(multipleKey).AsList() {
+		ports = append(ports, expandRange(portRange.AsString(), portRange.Metadata))
+	}
+	return ports
+}
+
+func expandRange(r string, m types.Metadata) network.PortRange {
+	start := 0
+	end := 65535
+	switch {
+	case r == "*":
+	case strings.Contains(r, "-"):
+		if parts := strings.Split(r, "-"); len(parts) == 2 {
+			if p1, err := strconv.ParseInt(parts[0], 10, 32); err == nil {
+				start = int(p1)
+			}
+			if p2, err := strconv.ParseInt(parts[1], 10, 32); err == nil {
+				end = int(p2)
+			}
+		}
+	default:
+		if val, err := strconv.ParseInt(r, 10, 32); err == nil {
+			start = int(val)
+			end = int(val)
+		}
+	}
+
+	return network.PortRange{
+		Metadata: m,
+		Start:    start,
+		End:      end,
+	}
+}
+
+func adaptWatcherLogs(deployment azure.Deployment) []network.NetworkWatcherFlowLog {
+	var watcherLogs []network.NetworkWatcherFlowLog
+	for _, resource := range deployment.GetResourcesByType("Microsoft.Network/networkWatchers/flowLogs") {
+		retentionPolicy := resource.Properties.GetMapValue("retentionPolicy")
+		watcherLogs = append(watcherLogs, network.NetworkWatcherFlowLog{
+			Metadata: resource.Metadata,
+			RetentionPolicy: network.RetentionPolicy{
+				Metadata: retentionPolicy.Metadata,
+				Enabled:  retentionPolicy.GetMapValue("enabled").AsBoolValue(false, retentionPolicy.Metadata),
+				Days:     retentionPolicy.GetMapValue("days").AsIntValue(0, retentionPolicy.Metadata),
+			},
+		})
+	}
+	return watcherLogs
+}
@@ -0,0 +1,46 @@
+package securitycenter
+
+import (
+	"strings"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers/azure/securitycenter"
+	"github.com/aquasecurity/defsec/pkg/scanners/azure"
+)
+
+func Adapt(deployment azure.Deployment) securitycenter.SecurityCenter {
+	return securitycenter.SecurityCenter{
+		Contacts:      adaptContacts(deployment),
+		Subscriptions: adaptSubscriptions(deployment),
+	}
+}
+
+func adaptContacts(deployment azure.Deployment) []securitycenter.Contact {
+	var contacts []securitycenter.Contact
+	for _, resource := range deployment.GetResourcesByType("Microsoft.Security/securityContacts") {
+		alertNotifications := types.BoolDefault(false, resource.Metadata)
+		if value := resource.Properties.GetMapValue("alertNotifications"); value.Kind() == azure.KindString {
+			alertNotifications = types.Bool(strings.EqualFold(value.AsString(), "On"), value.Metadata)
+		} else if value.Kind() == azure.KindObject {
+			state := value.GetMapValue("state")
+			alertNotifications = types.Bool(strings.EqualFold(state.AsString(), "On"), state.Metadata)
+		}
+		contacts = append(contacts, securitycenter.Contact{
+			Metadata:                 resource.Metadata,
+			EnableAlertNotifications: alertNotifications,
+			Phone:                    resource.Properties.GetMapValue("phone").AsStringValue("", resource.Metadata),
+		})
+	}
+	return contacts
+}
+
+func adaptSubscriptions(deployment azure.Deployment) []securitycenter.SubscriptionPricing {
+	var subscriptions []securitycenter.SubscriptionPricing
+	for _, resource := range deployment.GetResourcesByType("Microsoft.Security/pricings") {
+		subscriptions = append(subscriptions, securitycenter.SubscriptionPricing{
+			Metadata: resource.Metadata,
+			Tier:     resource.Properties.GetMapValue("pricingTier").AsStringValue(securitycenter.TierFree, resource.Metadata),
+		})
+	}
+	return subscriptions
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"strings"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers/azure/storage"
+	"github.com/aquasecurity/defsec/pkg/scanners/azure"
+)
+
+func Adapt(deployment azure.Deployment) storage.Storage {
+	return storage.Storage{
+		Accounts: adaptAccounts(deployment),
+	}
+}
+
+func adaptAccounts(deployment azure.Deployment) []storage.Account {
+	var accounts []storage.Account
+	for _, resource := range deployment.GetResourcesByType("Microsoft.Storage/storageAccounts") {
+		accounts = append(accounts, adaptAccount(deployment, resource))
+	}
+	return accounts
+}
+
+func adaptAccount(deployment azure.Deployment, resource azure.Resource) storage.Account {
+	account := storage.Account{
+		Metadata:     resource.Metadata,
+		EnforceHTTPS: resource.Properties.GetMapValue("supportsHttpsTrafficOnly").AsBoolValue(true, resource.Metadata),
+		QueueProperties: storage.QueueProperties{
+			Metadata:      resource.Metadata,
+			EnableLogging: types.BoolDefault(false, resource.Metadata),
+		},
+		MinimumTLSVersion: resource.Properties.GetMapValue("minimumTlsVersion").AsStringValue("TLS1_0", resource.Metadata),
+	}
+
+	if networkACLs := resource.Properties.GetMapValue("networkAcls"); !networkACLs.IsNull() {
+		account.NetworkRules = append(account.NetworkRules, adaptNetworkRule(networkACLs))
+	}
+
+	for _, container := range deployment.GetChildResources(resource, "Microsoft.Storage/storageAccounts/blobServices/containers") {
+		account.Containers = append(account.Containers, adaptContainer(container))
+	}
+
+	return account
+}
+
+func adaptNetworkRule(networkACLs azure.Value) storage.NetworkRule {
+	rule := storage.NetworkRule{
+		Metadata:       networkACLs.Metadata,
+		AllowByDefault: types.BoolDefault(true, networkACLs.Metadata),
+	}
+	if defaultAction := networkACLs.GetMapValue("defaultAction"); defaultAction.Kind() == azure.KindString {
+		rule.AllowByDefault = types.Bool(strings.EqualFold(defaultAction.AsString(), "Allow"), defaultAction.Metadata)
+	}
+	// bypass is a comma separated list, e.g. "Logging, Metrics, AzureServices"
+	if bypass := networkACLs.GetMapValue("bypass"); bypass.Kind() == azure.KindString {
+		for _, value := range strings.Split(bypass.AsString(), ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				rule.Bypass = append(rule.Bypass, types.String(value, bypass.Metadata))
+			}
+		}
+	}
+	return rule
+}
+
+func adaptContainer(resource azure.Resource) storage.Container {
+	publicAccess := types.StringDefault(storage.PublicAccessOff, resource.Metadata)
+	if value := resource.Properties.GetMapValue("publicAccess"); value.Kind() == azure.KindString {
+		switch strings.ToLower(value.AsString()) {
+		case storage.PublicAccessBlob:
+			publicAccess = types.String(storage.PublicAccessBlob, value.Metadata)
+		case storage.PublicAccessContainer:
+			publicAccess = types.String(storage.PublicAccessContainer, value.Metadata)
+		default:
+			publicAccess = types.String(storage.PublicAccessOff, value.Metadata)
+		}
+	}
+	return storage.Container{
+		Metadata:     resource.Metadata,
+		PublicAccess: publicAccess,
+	}
+}
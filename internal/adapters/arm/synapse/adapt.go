@@ -0,0 +1,32 @@
+package synapse
+
+import (
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers/azure/synapse"
+	"github.com/aquasecurity/defsec/pkg/scanners/azure"
+)
+
+func Adapt(deployment azure.Deployment) synapse.Synapse {
+	return synapse.Synapse{
+		Workspaces: adaptWorkspaces(deployment),
+	}
+}
+
+func adaptWorkspaces(deployment azure.Deployment) []synapse.Workspace {
+	var workspaces []synapse.Workspace
+	for _, resource := range deployment.GetResourcesByType("Microsoft.Synapse/workspaces") {
+		workspaces = append(workspaces, adaptWorkspace(resource))
+	}
+	return workspaces
+}
+
+func adaptWorkspace(resource azure.Resource) synapse.Workspace {
+	managedVirtualNetwork := types.BoolDefault(false, resource.Metadata)
+	if value := resource.Properties.GetMapValue("managedVirtualNetwork"); value.Kind() == azure.KindString {
+		managedVirtualNetwork = types.Bool(value.AsString() == "default", value.Metadata)
+	}
+	return synapse.Workspace{
+		Metadata:                    resource.Metadata,
+		EnableManagedVirtualNetwork: managedVirtualNetwork,
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/aquasecurity/defsec/internal/adapters/cloudformation/aws/elb"
 	"github.com/aquasecurity/defsec/internal/adapters/cloudformation/aws/iam"
 	"github.com/aquasecurity/defsec/internal/adapters/cloudformation/aws/kinesis"
+	"github.com/aquasecurity/defsec/internal/adapters/cloudformation/aws/kms"
 	"github.com/aquasecurity/defsec/internal/adapters/cloudformation/aws/lambda"
 	"github.com/aquasecurity/defsec/internal/adapters/cloudformation/aws/mq"
 	"github.com/aquasecurity/defsec/internal/adapters/cloudformation/aws/msk"
@@ -65,6 +66,7 @@ func Adapt(cfFile parser.FileContext) aws.AWS {
 		MSK:           msk.Adapt(cfFile),
 		MQ:            mq.Adapt(cfFile),
 		Kinesis:       kinesis.Adapt(cfFile),
+		KMS:           kms.Adapt(cfFile),
 		Lambda:        lambda.Adapt(cfFile),
 		Neptune:       neptune.Adapt(cfFile),
 		RDS:           rds.Adapt(cfFile),
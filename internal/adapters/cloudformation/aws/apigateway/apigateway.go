@@ -7,6 +7,6 @@ import (
 
 // Adapt ...
 func Adapt(cfFile parser.FileContext) (gateway apigateway.APIGateway) {
-	gateway.APIs = getApis(cfFile)
+	gateway.APIs = append(getApis(cfFile), getRestApis(cfFile)...)
 	return gateway
 }
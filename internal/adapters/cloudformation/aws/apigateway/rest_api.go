@@ -0,0 +1,107 @@
+package apigateway
+
+import (
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers/aws/apigateway"
+	"github.com/aquasecurity/defsec/pkg/scanners/cloudformation/parser"
+)
+
+func getRestApis(cfFile parser.FileContext) (apis []apigateway.API) {
+
+	apiResources := cfFile.GetResourcesByType("AWS::ApiGateway::RestApi")
+	for _, apiRes := range apiResources {
+		api := apigateway.API{
+			Metadata:     apiRes.Metadata(),
+			Name:         apiRes.GetStringProperty("Name"),
+			Version:      types.Int(1, apiRes.Metadata()),
+			ProtocolType: types.StringDefault(apigateway.ProtocolTypeREST, apiRes.Metadata()),
+			Stages:       getRestStages(apiRes.ID(), cfFile),
+			RESTMethods:  getRestMethods(apiRes.ID(), cfFile),
+		}
+		apis = append(apis, api)
+	}
+
+	return apis
+}
+
+func getRestStages(apiId string, cfFile parser.FileContext) []apigateway.Stage {
+	var apiStages []apigateway.Stage
+
+	stageResources := cfFile.GetResourcesByType("AWS::ApiGateway::Stage")
+	for _, r := range stageResources {
+		if r.GetStringProperty("RestApiId").Value() != apiId {
+			continue
+		}
+
+		s := apigateway.Stage{
+			Metadata:           r.Metadata(),
+			Name:               r.GetStringProperty("StageName"),
+			Version:            types.Int(1, r.Metadata()),
+			AccessLogging:      getRestAccessLogging(r),
+			RESTMethodSettings: getRestMethodSettings(r),
+			XRayTracingEnabled: r.GetBoolProperty("TracingEnabled"),
+		}
+		apiStages = append(apiStages, s)
+	}
+
+	return apiStages
+}
+
+func getRestAccessLogging(r *parser.Resource) apigateway.AccessLogging {
+
+	loggingProp := r.GetProperty("AccessLogSetting")
+	if loggingProp.IsNil() {
+		return apigateway.AccessLogging{
+			Metadata:              r.Metadata(),
+			CloudwatchLogGroupARN: types.StringDefault("", r.Metadata()),
+		}
+	}
+
+	return apigateway.AccessLogging{
+		Metadata:              loggingProp.Metadata(),
+		CloudwatchLogGroupARN: loggingProp.GetStringProperty("DestinationArn"),
+	}
+}
+
+func getRestMethodSettings(r *parser.Resource) apigateway.RESTMethodSettings {
+
+	settings := apigateway.RESTMethodSettings{
+		Metadata:           r.Metadata(),
+		CacheDataEncrypted: types.BoolDefault(false, r.Metadata()),
+		CacheEnabled:       types.BoolDefault(false, r.Metadata()),
+	}
+
+	// settings for all methods use the path /*/*
+	for _, methodSettings := range r.GetProperty("MethodSettings").AsList() {
+		if methodSettings.IsNotMap() {
+			continue
+		}
+		path := methodSettings.GetProperty("ResourcePath")
+		if path.IsNotNil() && !path.EqualTo("/*") {
+			continue
+		}
+		settings.Metadata = methodSettings.Metadata()
+		settings.CacheDataEncrypted = methodSettings.GetBoolProperty("CacheDataEncrypted")
+		settings.CacheEnabled = methodSettings.GetBoolProperty("CachingEnabled")
+	}
+
+	return settings
+}
+
+func getRestMethods(apiId string, cfFile parser.FileContext) []apigateway.RESTMethod {
+	var methods []apigateway.RESTMethod
+
+	for _, r := range cfFile.GetResourcesByType("AWS::ApiGateway::Method") {
+		if r.GetStringProperty("RestApiId").Value() != apiId {
+			continue
+		}
+		methods = append(methods, apigateway.RESTMethod{
+			Metadata:          r.Metadata(),
+			HTTPMethod:        r.GetStringProperty("HttpMethod"),
+			AuthorizationType: r.GetStringProperty("AuthorizationType", apigateway.AuthorizationNone),
+			APIKeyRequired:    r.GetBoolProperty("ApiKeyRequired"),
+		})
+	}
+
+	return methods
+}
@@ -0,0 +1,51 @@
+package kms
+
+import (
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers/aws/iam"
+	"github.com/aquasecurity/defsec/pkg/providers/aws/kms"
+	"github.com/aquasecurity/defsec/pkg/scanners/cloudformation/parser"
+	"github.com/liamg/iamgo"
+)
+
+func getKeys(ctx parser.FileContext) (keys []kms.Key) {
+	for _, r := range ctx.GetResourcesByType("AWS::KMS::Key") {
+		key := kms.Key{
+			Metadata:        r.Metadata(),
+			Usage:           r.GetStringProperty("KeyUsage", "ENCRYPT_DECRYPT"),
+			RotationEnabled: r.GetBoolProperty("EnableKeyRotation"),
+		}
+
+		if policyProp := r.GetProperty("KeyPolicy"); policyProp.IsNotNil() {
+			if parsed, err := iamgo.Parse(policyProp.GetJsonBytes()); err == nil {
+				key.Policies = append(key.Policies, iam.Policy{
+					Metadata: policyProp.Metadata(),
+					Name:     types.StringDefault("", policyProp.Metadata()),
+					Document: iam.Document{
+						Metadata: policyProp.Metadata(),
+						Parsed:   *parsed,
+					},
+				})
+			}
+		}
+
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func getAliases(ctx parser.FileContext) (aliases []kms.Alias) {
+	for _, r := range ctx.GetResourcesByType("AWS::KMS::Alias") {
+		alias := kms.Alias{
+			Metadata:    r.Metadata(),
+			Name:        r.GetStringProperty("AliasName"),
+			TargetKeyID: r.GetStringProperty("TargetKeyId"),
+		}
+		// as with Terraform, a key in the same template is identified by its resource name and metadata
+		if key := ctx.GetResourceByLogicalID(alias.TargetKeyID.Value()); key != nil && key.Type() == "AWS::KMS::Key" {
+			alias.TargetKeyID = types.String(key.ID(), key.Metadata())
+		}
+		aliases = append(aliases, alias)
+	}
+	return aliases
+}
@@ -0,0 +1,60 @@
+package kms
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/cloudformation/parser"
+	"github.com/aquasecurity/defsec/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Adapt(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"template.yaml": `---
+Resources:
+  Key:
+    Type: AWS::KMS::Key
+    Properties:
+      KeyPolicy:
+        Version: "2012-10-17"
+        Statement:
+          - Effect: Allow
+            Principal:
+              AWS: "*"
+            Action: "kms:*"
+            Resource: "*"
+  Alias:
+    Type: AWS::KMS::Alias
+    Properties:
+      AliasName: alias/example
+      TargetKeyId: !GetAtt Key.Arn
+  External:
+    Type: AWS::KMS::Alias
+    Properties:
+      AliasName: alias/external
+      TargetKeyId: 1234abcd-12ab-34cd-56ef-1234567890ab
+`,
+	})
+
+	ctx, err := parser.New().ParseFile(context.TODO(), fs, "template.yaml")
+	require.NoError(t, err)
+
+	adapted := Adapt(*ctx)
+
+	require.Len(t, adapted.Keys, 1)
+	require.Len(t, adapted.Keys[0].Policies, 1)
+	assert.Equal(t, "", adapted.Keys[0].Policies[0].Name.Value())
+	assert.Equal(t, 7, adapted.Keys[0].Policies[0].Range().GetStartLine())
+
+	targets := make(map[string]int)
+	for _, alias := range adapted.Aliases {
+		targets[alias.TargetKeyID.Value()] = alias.TargetKeyID.GetMetadata().Range().GetStartLine()
+	}
+	// the alias of the key in the template points at the key resource, as with Terraform
+	assert.Equal(t, map[string]int{
+		"Key":                                  3,
+		"1234abcd-12ab-34cd-56ef-1234567890ab": 23,
+	}, targets)
+}
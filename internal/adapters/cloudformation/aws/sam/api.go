@@ -8,7 +8,7 @@ import (
 
 func getApis(cfFile parser.FileContext) (apis []sam.API) {
 
-	apiResources := cfFile.GetResourcesByType("AWS::Serverless::Api")
+	apiResources := cfFile.GetSAMResourcesByType("AWS::Serverless::Api")
 	for _, r := range apiResources {
 		api := sam.API{
 			Metadata:            r.Metadata(),
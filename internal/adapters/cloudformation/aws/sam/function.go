@@ -10,7 +10,7 @@ import (
 
 func getFunctions(cfFile parser.FileContext) (functions []sam.Function) {
 
-	functionResources := cfFile.GetResourcesByType("AWS::Serverless::Function")
+	functionResources := cfFile.GetSAMResourcesByType("AWS::Serverless::Function")
 	for _, r := range functionResources {
 		function := sam.Function{
 			Metadata:        r.Metadata(),
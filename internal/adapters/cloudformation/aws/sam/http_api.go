@@ -8,7 +8,7 @@ import (
 
 func getHttpApis(cfFile parser.FileContext) (apis []sam.HttpAPI) {
 
-	apiResources := cfFile.GetResourcesByType("AWS::Serverless::HttpApi")
+	apiResources := cfFile.GetSAMResourcesByType("AWS::Serverless::HttpApi")
 	for _, r := range apiResources {
 		api := sam.HttpAPI{
 			Metadata:             r.Metadata(),
@@ -10,7 +10,7 @@ import (
 
 func getStateMachines(cfFile parser.FileContext) (stateMachines []sam.StateMachine) {
 
-	stateMachineResources := cfFile.GetResourcesByType("AWS::Serverless::StateMachine")
+	stateMachineResources := cfFile.GetSAMResourcesByType("AWS::Serverless::StateMachine")
 	for _, r := range stateMachineResources {
 		stateMachine := sam.StateMachine{
 			Metadata: r.Metadata(),
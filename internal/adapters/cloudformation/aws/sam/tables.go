@@ -8,7 +8,7 @@ import (
 
 func getSimpleTables(cfFile parser.FileContext) (tables []sam.SimpleTable) {
 
-	tableResources := cfFile.GetResourcesByType("AWS::Serverless::SimpleTable")
+	tableResources := cfFile.GetSAMResourcesByType("AWS::Serverless::SimpleTable")
 	for _, r := range tableResources {
 		table := sam.SimpleTable{
 			Metadata:         r.Metadata(),
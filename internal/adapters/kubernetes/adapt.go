@@ -0,0 +1,180 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers/kubernetes"
+	"github.com/aquasecurity/defsec/pkg/scanners/kubernetes/parser"
+	"github.com/aquasecurity/defsec/pkg/state"
+)
+
+// Adapt adapts Kubernetes objects (as parsed from YAML manifests, rendered Helm charts or built kustomizations) into state
+func Adapt(_ context.Context, manifests []parser.Manifest) *state.State {
+	var k8s kubernetes.Kubernetes
+	for _, manifest := range manifests {
+		obj := newObject(manifest)
+		switch kind := obj.kind(); kind {
+		case "NetworkPolicy":
+			k8s.NetworkPolicies = append(k8s.NetworkPolicies, adaptNetworkPolicy(obj))
+		case "Role":
+			k8s.Roles = append(k8s.Roles, adaptRole(obj))
+		case "ClusterRole":
+			k8s.ClusterRoles = append(k8s.ClusterRoles, adaptRole(obj))
+		case "RoleBinding":
+			k8s.RoleBindings = append(k8s.RoleBindings, adaptRoleBinding(obj))
+		case "ClusterRoleBinding":
+			k8s.ClusterRoleBindings = append(k8s.ClusterRoleBindings, adaptRoleBinding(obj))
+		case "Service":
+			k8s.Services = append(k8s.Services, adaptService(obj))
+		case "Ingress":
+			k8s.Ingresses = append(k8s.Ingresses, adaptIngress(obj))
+		default:
+			if template, ok := podTemplate(obj); ok {
+				k8s.Workloads = append(k8s.Workloads, adaptWorkload(obj, template))
+			}
+		}
+	}
+	return &state.State{
+		Kubernetes: k8s,
+	}
+}
+
+// object is a Kubernetes object, or part of one. Only the lines of each object as a whole are known, so all values
+// share the metadata of the object they belong to.
+type object struct {
+	metadata types.Metadata
+	content  map[string]interface{}
+}
+
+func newObject(manifest parser.Manifest) object {
+	kind, _ := manifest.Content["kind"].(string)
+	meta, _ := manifest.Content["metadata"].(map[string]interface{})
+	name, _ := meta["name"].(string)
+	var startLine, endLine int
+	if lines, ok := manifest.Content["__defsec_metadata"].(map[string]interface{}); ok {
+		startLine, _ = lines["startline"].(int)
+		endLine, _ = lines["endline"].(int)
+	}
+	rng := types.NewRange(manifest.Path, startLine, endLine, "", nil)
+	return object{
+		metadata: types.NewMetadata(rng, types.NewNamedReference(fmt.Sprintf("%s/%s", kind, name))),
+		content:  manifest.Content,
+	}
+}
+
+func (o object) kind() string {
+	kind, _ := o.content["kind"].(string)
+	return kind
+}
+
+func (o object) get(path ...string) (interface{}, bool) {
+	var current interface{} = o.content
+	for _, key := range path {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, current != nil
+}
+
+// child returns the map at the path, which is empty if it does not exist
+func (o object) child(path ...string) object {
+	value, _ := o.get(path...)
+	content, ok := value.(map[string]interface{})
+	if !ok {
+		content = make(map[string]interface{})
+	}
+	return object{metadata: o.metadata, content: content}
+}
+
+func (o object) has(path ...string) bool {
+	_, ok := o.get(path...)
+	return ok
+}
+
+func (o object) list(path ...string) []object {
+	value, _ := o.get(path...)
+	items, _ := value.([]interface{})
+	var objects []object
+	for _, item := range items {
+		if content, ok := item.(map[string]interface{}); ok {
+			objects = append(objects, object{metadata: o.metadata, content: content})
+		}
+	}
+	return objects
+}
+
+func (o object) str(defaultValue string, path ...string) types.StringValue {
+	if value, ok := o.get(path...); ok {
+		switch typed := value.(type) {
+		case string:
+			return types.String(typed, o.metadata)
+		case int, float64, bool:
+			// e.g. a port number where a name is also allowed
+			return types.String(fmt.Sprintf("%v", typed), o.metadata)
+		}
+	}
+	return types.StringDefault(defaultValue, o.metadata)
+}
+
+func (o object) boolean(defaultValue bool, path ...string) types.BoolValue {
+	if value, ok := o.get(path...); ok {
+		if typed, ok := value.(bool); ok {
+			return types.Bool(typed, o.metadata)
+		}
+	}
+	return types.BoolDefault(defaultValue, o.metadata)
+}
+
+func (o object) integer(defaultValue int, path ...string) types.IntValue {
+	if value, ok := o.get(path...); ok {
+		switch typed := value.(type) {
+		case int:
+			return types.Int(typed, o.metadata)
+		case float64:
+			return types.Int(int(typed), o.metadata)
+		}
+	}
+	return types.IntDefault(defaultValue, o.metadata)
+}
+
+func (o object) strings(path ...string) []types.StringValue {
+	value, _ := o.get(path...)
+	items, _ := value.([]interface{})
+	var values []types.StringValue
+	for _, item := range items {
+		if typed, ok := item.(string); ok {
+			values = append(values, types.String(typed, o.metadata))
+		}
+	}
+	return values
+}
+
+func (o object) stringMap(path ...string) types.MapValue {
+	value, ok := o.get(path...)
+	items, isMap := value.(map[string]interface{})
+	if !ok || !isMap {
+		return types.MapDefault(make(map[string]string), o.metadata)
+	}
+	values := make(map[string]string, len(items))
+	for key, item := range items {
+		values[key] = fmt.Sprintf("%v", item)
+	}
+	return types.Map(values, o.metadata)
+}
+
+func adaptObjectMeta(o object) kubernetes.ObjectMeta {
+	return kubernetes.ObjectMeta{
+		Metadata:    o.metadata,
+		Name:        o.str("", "metadata", "name"),
+		Namespace:   o.str("", "metadata", "namespace"),
+		Labels:      o.stringMap("metadata", "labels"),
+		Annotations: o.stringMap("metadata", "annotations"),
+	}
+}
@@ -0,0 +1,257 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers/kubernetes"
+	"github.com/aquasecurity/defsec/pkg/scanners/kubernetes/parser"
+	"github.com/aquasecurity/defsec/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func parseManifest(t *testing.T, source string) parser.Manifest {
+	var content map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(source), &content))
+	return parser.Manifest{Path: "test.yaml", Content: content}
+}
+
+func Test_AdaptWorkloads(t *testing.T) {
+	manifests := []parser.Manifest{
+		parseManifest(t, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: prod
+spec:
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      hostNetwork: true
+      automountServiceAccountToken: false
+      securityContext:
+        runAsNonRoot: true
+      containers:
+        - name: web
+          image: nginx:1.23
+          securityContext:
+            privileged: true
+            allowPrivilegeEscalation: false
+            capabilities:
+              drop: ["ALL"]
+          resources:
+            limits:
+              cpu: 500m
+              memory: 128Mi
+          ports:
+            - containerPort: 8080
+              hostPort: 80
+      volumes:
+        - name: docker
+          hostPath:
+            path: /var/run/docker.sock
+        - name: scratch
+          emptyDir: {}
+`),
+		parseManifest(t, `
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: cleanup
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          initContainers:
+            - name: init
+              image: busybox
+`),
+		parseManifest(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+`),
+	}
+
+	adapted := Adapt(context.TODO(), manifests).Kubernetes
+	require.Len(t, adapted.Workloads, 2)
+
+	deployment := adapted.Workloads[0]
+	assert.Equal(t, "Deployment", deployment.Kind.Value())
+	assert.Equal(t, "web", deployment.Meta.Name.Value())
+	assert.Equal(t, "prod", deployment.Meta.Namespace.Value())
+	assert.Equal(t, map[string]string{"app": "web"}, deployment.Template.Meta.Labels.Value())
+	assert.Equal(t, "test.yaml", deployment.GetMetadata().Range().GetFilename())
+	assert.Equal(t, "Deployment/web", deployment.GetMetadata().Reference().String())
+
+	spec := deployment.Template.Spec
+	assert.True(t, spec.HostNetwork.IsTrue())
+	assert.False(t, spec.HostPID.IsTrue())
+	assert.False(t, spec.AutomountServiceAccountToken.IsTrue())
+	assert.True(t, spec.SecurityContext.RunAsNonRoot.IsTrue())
+
+	require.Len(t, spec.Containers, 1)
+	testutil.AssertDefsecEqual(t, kubernetes.Container{
+		Metadata:        types.NewTestMetadata(),
+		Name:            types.String("web", types.NewTestMetadata()),
+		Image:           types.String("nginx:1.23", types.NewTestMetadata()),
+		ImagePullPolicy: types.String("", types.NewTestMetadata()),
+		SecurityContext: kubernetes.SecurityContext{
+			Metadata:                 types.NewTestMetadata(),
+			Privileged:               types.Bool(true, types.NewTestMetadata()),
+			AllowPrivilegeEscalation: types.Bool(false, types.NewTestMetadata()),
+			ReadOnlyRootFilesystem:   types.Bool(false, types.NewTestMetadata()),
+			RunAsNonRoot:             types.Bool(false, types.NewTestMetadata()),
+			RunAsUser:                types.Int(0, types.NewTestMetadata()),
+			RunAsGroup:               types.Int(0, types.NewTestMetadata()),
+			SeccompProfileType:       types.String("", types.NewTestMetadata()),
+			Capabilities: kubernetes.Capabilities{
+				Metadata: types.NewTestMetadata(),
+				Drop:     []types.StringValue{types.String("ALL", types.NewTestMetadata())},
+			},
+		},
+		Resources: kubernetes.Resources{
+			Metadata:       types.NewTestMetadata(),
+			LimitsCPU:      types.String("500m", types.NewTestMetadata()),
+			LimitsMemory:   types.String("128Mi", types.NewTestMetadata()),
+			RequestsCPU:    types.String("", types.NewTestMetadata()),
+			RequestsMemory: types.String("", types.NewTestMetadata()),
+		},
+		Ports: []kubernetes.ContainerPort{
+			{
+				Metadata:      types.NewTestMetadata(),
+				ContainerPort: types.Int(8080, types.NewTestMetadata()),
+				HostPort:      types.Int(80, types.NewTestMetadata()),
+				Protocol:      types.String("TCP", types.NewTestMetadata()),
+			},
+		},
+	}, spec.Containers[0])
+
+	require.Len(t, spec.Volumes, 2)
+	assert.Equal(t, kubernetes.VolumeTypeHostPath, spec.Volumes[0].Type.Value())
+	assert.Equal(t, "/var/run/docker.sock", spec.Volumes[0].HostPath.Value())
+	assert.Equal(t, kubernetes.VolumeTypeEmptyDir, spec.Volumes[1].Type.Value())
+
+	cronJob := adapted.Workloads[1]
+	assert.Equal(t, "CronJob", cronJob.Kind.Value())
+	require.Len(t, cronJob.Template.Spec.InitContainers, 1)
+	assert.Equal(t, "init", cronJob.Template.Spec.InitContainers[0].Name.Value())
+	assert.True(t, cronJob.Template.Spec.InitContainers[0].SecurityContext.AllowPrivilegeEscalation.IsTrue())
+}
+
+func Test_AdaptRBACAndNetworking(t *testing.T) {
+	manifests := []parser.Manifest{
+		parseManifest(t, `
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: secret-reader
+rules:
+  - apiGroups: [""]
+    resources: ["secrets"]
+    verbs: ["get", "watch", "list"]
+`),
+		parseManifest(t, `
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: read-secrets
+subjects:
+  - kind: Group
+    name: system:authenticated
+roleRef:
+  kind: ClusterRole
+  name: secret-reader
+`),
+		parseManifest(t, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  type: NodePort
+  ports:
+    - port: 80
+      targetPort: http
+      nodePort: 30080
+`),
+		parseManifest(t, `
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: web
+spec:
+  tls:
+    - hosts: ["example.com"]
+      secretName: tls
+  rules:
+    - host: example.com
+      http:
+        paths:
+          - path: /
+            pathType: Prefix
+            backend:
+              service:
+                name: web
+                port:
+                  number: 80
+`),
+		parseManifest(t, `
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: egress
+spec:
+  egress:
+    - to:
+        - ipBlock:
+            cidr: 0.0.0.0/0
+      ports:
+        - port: 443
+`),
+	}
+
+	adapted := Adapt(context.TODO(), manifests).Kubernetes
+
+	require.Len(t, adapted.ClusterRoles, 1)
+	require.Len(t, adapted.ClusterRoles[0].Rules, 1)
+	assert.Len(t, adapted.ClusterRoles[0].Rules[0].Verbs, 3)
+	assert.Equal(t, "secrets", adapted.ClusterRoles[0].Rules[0].Resources[0].Value())
+
+	require.Len(t, adapted.ClusterRoleBindings, 1)
+	binding := adapted.ClusterRoleBindings[0]
+	assert.Equal(t, "secret-reader", binding.RoleRef.Name.Value())
+	require.Len(t, binding.Subjects, 1)
+	assert.Equal(t, "system:authenticated", binding.Subjects[0].Name.Value())
+
+	require.Len(t, adapted.Services, 1)
+	service := adapted.Services[0]
+	assert.Equal(t, kubernetes.ServiceTypeNodePort, service.Type.Value())
+	require.Len(t, service.Ports, 1)
+	assert.Equal(t, "http", service.Ports[0].TargetPort.Value())
+	assert.Equal(t, 30080, service.Ports[0].NodePort.Value())
+
+	require.Len(t, adapted.Ingresses, 1)
+	ingress := adapted.Ingresses[0]
+	require.Len(t, ingress.TLS, 1)
+	assert.Equal(t, "tls", ingress.TLS[0].SecretName.Value())
+	require.Len(t, ingress.Rules, 1)
+	require.Len(t, ingress.Rules[0].Paths, 1)
+	assert.Equal(t, "web", ingress.Rules[0].Paths[0].ServiceName.Value())
+	assert.Equal(t, "80", ingress.Rules[0].Paths[0].ServicePort.Value())
+
+	require.Len(t, adapted.NetworkPolicies, 1)
+	egress := adapted.NetworkPolicies[0].Spec.Egress
+	require.Len(t, egress.DestinationCIDRs, 1)
+	assert.Equal(t, "0.0.0.0/0", egress.DestinationCIDRs[0].Value())
+	require.Len(t, egress.Ports, 1)
+	assert.Equal(t, "443", egress.Ports[0].Number.Value())
+}
@@ -0,0 +1,113 @@
+package kubernetes
+
+import (
+	"github.com/aquasecurity/defsec/pkg/providers/kubernetes"
+)
+
+func adaptService(o object) kubernetes.Service {
+	spec := o.child("spec")
+	service := kubernetes.Service{
+		Metadata:                 o.metadata,
+		Meta:                     adaptObjectMeta(o),
+		Type:                     spec.str(kubernetes.ServiceTypeClusterIP, "type"),
+		ExternalIPs:              spec.strings("externalIPs"),
+		LoadBalancerSourceRanges: spec.strings("loadBalancerSourceRanges"),
+	}
+	for _, port := range spec.list("ports") {
+		service.Ports = append(service.Ports, kubernetes.ServicePort{
+			Metadata:   port.metadata,
+			Port:       port.integer(0, "port"),
+			TargetPort: port.str("", "targetPort"),
+			NodePort:   port.integer(0, "nodePort"),
+			Protocol:   port.str("TCP", "protocol"),
+		})
+	}
+	return service
+}
+
+func adaptIngress(o object) kubernetes.IngressResource {
+	spec := o.child("spec")
+	ingress := kubernetes.IngressResource{
+		Metadata:         o.metadata,
+		Meta:             adaptObjectMeta(o),
+		IngressClassName: spec.str("", "ingressClassName"),
+	}
+	for _, tls := range spec.list("tls") {
+		ingress.TLS = append(ingress.TLS, kubernetes.IngressTLS{
+			Metadata:   tls.metadata,
+			Hosts:      tls.strings("hosts"),
+			SecretName: tls.str("", "secretName"),
+		})
+	}
+	for _, rule := range spec.list("rules") {
+		ingressRule := kubernetes.IngressRule{
+			Metadata: rule.metadata,
+			Host:     rule.str("", "host"),
+		}
+		for _, path := range rule.list("http", "paths") {
+			ingressPath := kubernetes.IngressPath{
+				Metadata:    path.metadata,
+				Path:        path.str("", "path"),
+				PathType:    path.str("", "pathType"),
+				ServiceName: path.str("", "backend", "serviceName"),
+				ServicePort: path.str("", "backend", "servicePort"),
+			}
+			if path.has("backend", "service") {
+				// networking.k8s.io/v1
+				ingressPath.ServiceName = path.str("", "backend", "service", "name")
+				ingressPath.ServicePort = path.str("", "backend", "service", "port", "name")
+				if path.has("backend", "service", "port", "number") {
+					ingressPath.ServicePort = path.str("", "backend", "service", "port", "number")
+				}
+			}
+			ingressRule.Paths = append(ingressRule.Paths, ingressPath)
+		}
+		ingress.Rules = append(ingress.Rules, ingressRule)
+	}
+	return ingress
+}
+
+// adaptNetworkPolicy flattens the ingress and egress rules of the policy into the ports and CIDRs they allow
+func adaptNetworkPolicy(o object) kubernetes.NetworkPolicy {
+	policy := kubernetes.NetworkPolicy{
+		Metadata: o.metadata,
+		Spec: kubernetes.Spec{
+			Metadata: o.metadata,
+			Egress: kubernetes.Egress{
+				Metadata: o.metadata,
+			},
+			Ingress: kubernetes.Ingress{
+				Metadata: o.metadata,
+			},
+		},
+	}
+	for _, rule := range o.list("spec", "egress") {
+		policy.Spec.Egress.Ports = append(policy.Spec.Egress.Ports, adaptPorts(rule)...)
+		for _, to := range rule.list("to") {
+			if to.has("ipBlock", "cidr") {
+				policy.Spec.Egress.DestinationCIDRs = append(policy.Spec.Egress.DestinationCIDRs, to.str("", "ipBlock", "cidr"))
+			}
+		}
+	}
+	for _, rule := range o.list("spec", "ingress") {
+		policy.Spec.Ingress.Ports = append(policy.Spec.Ingress.Ports, adaptPorts(rule)...)
+		for _, from := range rule.list("from") {
+			if from.has("ipBlock", "cidr") {
+				policy.Spec.Ingress.SourceCIDRs = append(policy.Spec.Ingress.SourceCIDRs, from.str("", "ipBlock", "cidr"))
+			}
+		}
+	}
+	return policy
+}
+
+func adaptPorts(rule object) []kubernetes.Port {
+	var ports []kubernetes.Port
+	for _, port := range rule.list("ports") {
+		ports = append(ports, kubernetes.Port{
+			Metadata: port.metadata,
+			Number:   port.str("", "port"),
+			Protocol: port.str("TCP", "protocol"),
+		})
+	}
+	return ports
+}
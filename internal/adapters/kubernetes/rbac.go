@@ -0,0 +1,43 @@
+package kubernetes
+
+import (
+	"github.com/aquasecurity/defsec/pkg/providers/kubernetes"
+)
+
+func adaptRole(o object) kubernetes.Role {
+	role := kubernetes.Role{
+		Metadata: o.metadata,
+		Meta:     adaptObjectMeta(o),
+	}
+	for _, rule := range o.list("rules") {
+		role.Rules = append(role.Rules, kubernetes.PolicyRule{
+			Metadata:      rule.metadata,
+			APIGroups:     rule.strings("apiGroups"),
+			Resources:     rule.strings("resources"),
+			ResourceNames: rule.strings("resourceNames"),
+			Verbs:         rule.strings("verbs"),
+		})
+	}
+	return role
+}
+
+func adaptRoleBinding(o object) kubernetes.RoleBinding {
+	binding := kubernetes.RoleBinding{
+		Metadata: o.metadata,
+		Meta:     adaptObjectMeta(o),
+		RoleRef: kubernetes.RoleRef{
+			Metadata: o.metadata,
+			Kind:     o.str("", "roleRef", "kind"),
+			Name:     o.str("", "roleRef", "name"),
+		},
+	}
+	for _, subject := range o.list("subjects") {
+		binding.Subjects = append(binding.Subjects, kubernetes.Subject{
+			Metadata:  subject.metadata,
+			Kind:      subject.str("", "kind"),
+			Name:      subject.str("", "name"),
+			Namespace: subject.str("", "namespace"),
+		})
+	}
+	return binding
+}
@@ -0,0 +1,138 @@
+package kubernetes
+
+import (
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers/kubernetes"
+)
+
+// podTemplatePaths are the paths of the pod templates in each kind of workload
+var podTemplatePaths = map[string][]string{
+	"Deployment":            {"spec", "template"},
+	"ReplicaSet":            {"spec", "template"},
+	"StatefulSet":           {"spec", "template"},
+	"DaemonSet":             {"spec", "template"},
+	"ReplicationController": {"spec", "template"},
+	"Job":                   {"spec", "template"},
+	"CronJob":               {"spec", "jobTemplate", "spec", "template"},
+	"PodTemplate":           {"template"},
+}
+
+// podTemplate returns the pod template of the workload. A pod is its own template.
+func podTemplate(o object) (object, bool) {
+	if o.kind() == "Pod" {
+		return o, true
+	}
+	path, ok := podTemplatePaths[o.kind()]
+	if !ok {
+		return object{}, false
+	}
+	return o.child(path...), true
+}
+
+func adaptWorkload(o object, template object) kubernetes.Workload {
+	return kubernetes.Workload{
+		Metadata: o.metadata,
+		Kind:     o.str("", "kind"),
+		Meta:     adaptObjectMeta(o),
+		Template: kubernetes.PodTemplate{
+			Metadata: o.metadata,
+			Meta:     adaptObjectMeta(template),
+			Spec:     adaptPodSpec(template.child("spec")),
+		},
+	}
+}
+
+func adaptPodSpec(spec object) kubernetes.PodSpec {
+	podSpec := kubernetes.PodSpec{
+		Metadata:                     spec.metadata,
+		ServiceAccountName:           spec.str("", "serviceAccountName"),
+		AutomountServiceAccountToken: spec.boolean(true, "automountServiceAccountToken"),
+		HostNetwork:                  spec.boolean(false, "hostNetwork"),
+		HostPID:                      spec.boolean(false, "hostPID"),
+		HostIPC:                      spec.boolean(false, "hostIPC"),
+		SecurityContext: kubernetes.PodSecurityContext{
+			Metadata:           spec.metadata,
+			RunAsUser:          spec.integer(0, "securityContext", "runAsUser"),
+			RunAsGroup:         spec.integer(0, "securityContext", "runAsGroup"),
+			RunAsNonRoot:       spec.boolean(false, "securityContext", "runAsNonRoot"),
+			FSGroup:            spec.integer(0, "securityContext", "fsGroup"),
+			SeccompProfileType: spec.str("", "securityContext", "seccompProfile", "type"),
+		},
+	}
+	for _, container := range spec.list("containers") {
+		podSpec.Containers = append(podSpec.Containers, adaptContainer(container))
+	}
+	for _, container := range spec.list("initContainers") {
+		podSpec.InitContainers = append(podSpec.InitContainers, adaptContainer(container))
+	}
+	for _, volume := range spec.list("volumes") {
+		podSpec.Volumes = append(podSpec.Volumes, adaptVolume(volume))
+	}
+	return podSpec
+}
+
+func adaptContainer(container object) kubernetes.Container {
+	securityContext := container.child("securityContext")
+	adapted := kubernetes.Container{
+		Metadata:        container.metadata,
+		Name:            container.str("", "name"),
+		Image:           container.str("", "image"),
+		ImagePullPolicy: container.str("", "imagePullPolicy"),
+		SecurityContext: kubernetes.SecurityContext{
+			Metadata:                 container.metadata,
+			Privileged:               securityContext.boolean(false, "privileged"),
+			AllowPrivilegeEscalation: securityContext.boolean(true, "allowPrivilegeEscalation"),
+			ReadOnlyRootFilesystem:   securityContext.boolean(false, "readOnlyRootFilesystem"),
+			RunAsNonRoot:             securityContext.boolean(false, "runAsNonRoot"),
+			RunAsUser:                securityContext.integer(0, "runAsUser"),
+			RunAsGroup:               securityContext.integer(0, "runAsGroup"),
+			SeccompProfileType:       securityContext.str("", "seccompProfile", "type"),
+			Capabilities: kubernetes.Capabilities{
+				Metadata: container.metadata,
+				Add:      securityContext.strings("capabilities", "add"),
+				Drop:     securityContext.strings("capabilities", "drop"),
+			},
+		},
+		Resources: kubernetes.Resources{
+			Metadata:       container.metadata,
+			LimitsCPU:      container.str("", "resources", "limits", "cpu"),
+			LimitsMemory:   container.str("", "resources", "limits", "memory"),
+			RequestsCPU:    container.str("", "resources", "requests", "cpu"),
+			RequestsMemory: container.str("", "resources", "requests", "memory"),
+		},
+	}
+	for _, port := range container.list("ports") {
+		adapted.Ports = append(adapted.Ports, kubernetes.ContainerPort{
+			Metadata:      port.metadata,
+			ContainerPort: port.integer(0, "containerPort"),
+			HostPort:      port.integer(0, "hostPort"),
+			Protocol:      port.str("TCP", "protocol"),
+		})
+	}
+	return adapted
+}
+
+var volumeTypes = []string{
+	kubernetes.VolumeTypeHostPath,
+	kubernetes.VolumeTypeEmptyDir,
+	kubernetes.VolumeTypeSecret,
+	kubernetes.VolumeTypeConfigMap,
+	kubernetes.VolumeTypePersistentVolumeClaim,
+	kubernetes.VolumeTypeProjected,
+}
+
+func adaptVolume(volume object) kubernetes.Volume {
+	adapted := kubernetes.Volume{
+		Metadata: volume.metadata,
+		Name:     volume.str("", "name"),
+		Type:     types.StringDefault("", volume.metadata),
+		HostPath: volume.str("", "hostPath", "path"),
+	}
+	for _, volumeType := range volumeTypes {
+		if volume.has(volumeType) {
+			adapted.Type = types.String(volumeType, volume.metadata)
+			break
+		}
+	}
+	return adapted
+}
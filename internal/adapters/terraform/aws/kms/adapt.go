@@ -1,13 +1,18 @@
 package kms
 
 import (
+	"github.com/aquasecurity/defsec/internal/adapters/terraform/aws/iam"
+	"github.com/aquasecurity/defsec/internal/types"
+	iamp "github.com/aquasecurity/defsec/pkg/providers/aws/iam"
 	"github.com/aquasecurity/defsec/pkg/providers/aws/kms"
 	"github.com/aquasecurity/defsec/pkg/terraform"
+	"github.com/liamg/iamgo"
 )
 
 func Adapt(modules terraform.Modules) kms.KMS {
 	return kms.KMS{
-		Keys: adaptKeys(modules),
+		Keys:    adaptKeys(modules),
+		Aliases: adaptAliases(modules),
 	}
 }
 
@@ -15,13 +20,13 @@ func adaptKeys(modules terraform.Modules) []kms.Key {
 	var keys []kms.Key
 	for _, module := range modules {
 		for _, resource := range module.GetResourcesByType("aws_kms_key") {
-			keys = append(keys, adaptKey(resource))
+			keys = append(keys, adaptKey(resource, modules))
 		}
 	}
 	return keys
 }
 
-func adaptKey(resource *terraform.Block) kms.Key {
+func adaptKey(resource *terraform.Block, modules terraform.Modules) kms.Key {
 	usageAttr := resource.GetAttribute("key_usage")
 	usageVal := usageAttr.AsStringValueOrDefault("ENCRYPT_DECRYPT", resource)
 
@@ -32,5 +37,60 @@ func adaptKey(resource *terraform.Block) kms.Key {
 		Metadata:        resource.GetMetadata(),
 		Usage:           usageVal,
 		RotationEnabled: enableKeyRotationVal,
+		Policies:        adaptKeyPolicies(resource, modules),
 	}
 }
+
+func adaptKeyPolicies(resource *terraform.Block, modules terraform.Modules) []iamp.Policy {
+	var policies []iamp.Policy
+	attr := resource.GetAttribute("policy")
+	if attr.IsString() {
+		parsed, err := iamgo.ParseString(attr.Value().AsString())
+		if err != nil {
+			return nil
+		}
+		policies = append(policies, iamp.Policy{
+			Metadata: attr.GetMetadata(),
+			Name:     types.StringDefault("", attr.GetMetadata()),
+			Document: iamp.Document{
+				Metadata: attr.GetMetadata(),
+				Parsed:   *parsed,
+			},
+		})
+	} else if refBlock, err := modules.GetReferencedBlock(attr, resource); err == nil {
+		if refBlock.Type() == "data" && refBlock.TypeLabel() == "aws_iam_policy_document" {
+			if doc, err := iam.ConvertTerraformDocument(modules, refBlock); err == nil {
+				policies = append(policies, iamp.Policy{
+					Metadata: doc.Source.GetMetadata(),
+					Name:     types.StringDefault("", doc.Source.GetMetadata()),
+					Document: iamp.Document{
+						Metadata: doc.Source.GetMetadata(),
+						Parsed:   doc.Document,
+					},
+				})
+			}
+		}
+	}
+	return policies
+}
+
+func adaptAliases(modules terraform.Modules) []kms.Alias {
+	var aliases []kms.Alias
+	for _, module := range modules {
+		for _, resource := range module.GetResourcesByType("aws_kms_alias") {
+			alias := kms.Alias{
+				Metadata:    resource.GetMetadata(),
+				Name:        resource.GetAttribute("name").AsStringValueOrDefault("", resource),
+				TargetKeyID: resource.GetAttribute("target_key_id").AsStringValueOrDefault("", resource),
+			}
+			targetAttr := resource.GetAttribute("target_key_id")
+			if targetAttr.IsResourceBlockReference("aws_kms_key") {
+				if keyBlock, err := module.GetReferencedBlock(targetAttr, resource); err == nil {
+					alias.TargetKeyID = types.String(keyBlock.FullName(), keyBlock.GetMetadata())
+				}
+			}
+			aliases = append(aliases, alias)
+		}
+	}
+	return aliases
+}
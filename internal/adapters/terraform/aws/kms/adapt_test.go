@@ -49,12 +49,48 @@ func Test_adaptKey(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			modules := tftestutil.CreateModulesFromSource(t, test.terraform, ".tf")
-			adapted := adaptKey(modules.GetBlocks()[0])
+			adapted := adaptKey(modules.GetBlocks()[0], modules)
 			testutil.AssertDefsecEqual(t, test.expected, adapted)
 		})
 	}
 }
 
+func Test_adaptKeyPolicy(t *testing.T) {
+	src := `
+	resource "aws_kms_key" "example" {
+		policy = jsonencode({
+			Version = "2012-10-17"
+			Statement = [
+				{
+					Effect = "Allow"
+					Action = "kms:*"
+					Principal = { AWS = "*" }
+					Resource = "*"
+				}
+			]
+		})
+	}
+
+	resource "aws_kms_alias" "example" {
+		name          = "alias/example"
+		target_key_id = aws_kms_key.example.key_id
+	}`
+
+	modules := tftestutil.CreateModulesFromSource(t, src, ".tf")
+	adapted := Adapt(modules)
+
+	require.Len(t, adapted.Keys, 1)
+	require.Len(t, adapted.Keys[0].Policies, 1)
+	statements, _ := adapted.Keys[0].Policies[0].Document.Parsed.Statements()
+	require.Len(t, statements, 1)
+	actions, _ := statements[0].Actions()
+	assert.Equal(t, []string{"kms:*"}, actions)
+
+	require.Len(t, adapted.Aliases, 1)
+	assert.Equal(t, "alias/example", adapted.Aliases[0].Name.Value())
+	assert.Equal(t, "aws_kms_key.example", adapted.Aliases[0].TargetKeyID.Value())
+}
+
 func TestLines(t *testing.T) {
 	src := `
 	resource "aws_kms_key" "example" {
@@ -7,7 +7,14 @@ import (
 
 func Adapt(modules terraform.Modules) kubernetes.Kubernetes {
 	return kubernetes.Kubernetes{
-		NetworkPolicies: adaptNetworkPolicies(modules),
+		NetworkPolicies:     adaptNetworkPolicies(modules),
+		Workloads:           adaptWorkloads(modules),
+		Roles:               adaptRoles(modules, "kubernetes_role", "kubernetes_role_v1"),
+		ClusterRoles:        adaptRoles(modules, "kubernetes_cluster_role", "kubernetes_cluster_role_v1"),
+		RoleBindings:        adaptRoleBindings(modules, "kubernetes_role_binding", "kubernetes_role_binding_v1"),
+		ClusterRoleBindings: adaptRoleBindings(modules, "kubernetes_cluster_role_binding", "kubernetes_cluster_role_binding_v1"),
+		Services:            adaptServices(modules),
+		Ingresses:           adaptIngresses(modules),
 	}
 }
 
@@ -0,0 +1,358 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/defsec/internal/adapters/terraform/tftestutil"
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers/kubernetes"
+	"github.com/aquasecurity/defsec/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_adaptWorkloads(t *testing.T) {
+	src := `
+resource "kubernetes_deployment" "example" {
+	metadata {
+		name      = "web"
+		namespace = "prod"
+		labels = {
+			app = "web"
+		}
+	}
+	spec {
+		template {
+			metadata {
+				labels = {
+					app = "web"
+				}
+			}
+			spec {
+				service_account_name = "web"
+				host_network         = true
+				security_context {
+					run_as_non_root = true
+					run_as_user     = 1000
+				}
+				container {
+					name  = "web"
+					image = "nginx:1.23"
+					security_context {
+						privileged                = true
+						read_only_root_filesystem = true
+						capabilities {
+							add  = ["NET_ADMIN"]
+							drop = ["ALL"]
+						}
+					}
+					resources {
+						limits = {
+							cpu    = "500m"
+							memory = "128Mi"
+						}
+					}
+					port {
+						container_port = 8080
+						host_port      = 80
+					}
+				}
+				volume {
+					name = "docker"
+					host_path {
+						path = "/var/run/docker.sock"
+					}
+				}
+			}
+		}
+	}
+}
+
+resource "kubernetes_cron_job_v1" "example" {
+	metadata {
+		name = "cleanup"
+	}
+	spec {
+		schedule = "0 * * * *"
+		job_template {
+			metadata {}
+			spec {
+				template {
+					metadata {}
+					spec {
+						container {
+							name  = "cleanup"
+							image = "busybox"
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+resource "kubernetes_pod" "example" {
+	metadata {
+		name = "debug"
+	}
+	spec {
+		host_pid = true
+		container {
+			name  = "debug"
+			image = "busybox"
+		}
+	}
+}
+`
+	modules := tftestutil.CreateModulesFromSource(t, src, ".tf")
+	adapted := Adapt(modules)
+	require.Len(t, adapted.Workloads, 3)
+
+	// resources are adapted in order of type
+	cronJob := adapted.Workloads[0]
+	assert.Equal(t, "CronJob", cronJob.Kind.Value())
+	require.Len(t, cronJob.Template.Spec.Containers, 1)
+	assert.Equal(t, "cleanup", cronJob.Template.Spec.Containers[0].Name.Value())
+
+	deployment := adapted.Workloads[1]
+	assert.Equal(t, "Deployment", deployment.Kind.Value())
+	assert.Equal(t, "web", deployment.Meta.Name.Value())
+	assert.Equal(t, "prod", deployment.Meta.Namespace.Value())
+	assert.Equal(t, map[string]string{"app": "web"}, deployment.Template.Meta.Labels.Value())
+
+	spec := deployment.Template.Spec
+	assert.Equal(t, "web", spec.ServiceAccountName.Value())
+	assert.True(t, spec.HostNetwork.IsTrue())
+	assert.True(t, spec.AutomountServiceAccountToken.IsTrue())
+	assert.True(t, spec.SecurityContext.RunAsNonRoot.IsTrue())
+	assert.Equal(t, 1000, spec.SecurityContext.RunAsUser.Value())
+
+	require.Len(t, spec.Containers, 1)
+	container := spec.Containers[0]
+	testutil.AssertDefsecEqual(t, kubernetes.Container{
+		Metadata:        types.NewTestMetadata(),
+		Name:            types.String("web", types.NewTestMetadata()),
+		Image:           types.String("nginx:1.23", types.NewTestMetadata()),
+		ImagePullPolicy: types.String("", types.NewTestMetadata()),
+		SecurityContext: kubernetes.SecurityContext{
+			Metadata:                 types.NewTestMetadata(),
+			Privileged:               types.Bool(true, types.NewTestMetadata()),
+			AllowPrivilegeEscalation: types.Bool(true, types.NewTestMetadata()),
+			ReadOnlyRootFilesystem:   types.Bool(true, types.NewTestMetadata()),
+			RunAsNonRoot:             types.Bool(false, types.NewTestMetadata()),
+			RunAsUser:                types.Int(0, types.NewTestMetadata()),
+			RunAsGroup:               types.Int(0, types.NewTestMetadata()),
+			SeccompProfileType:       types.String("", types.NewTestMetadata()),
+			Capabilities: kubernetes.Capabilities{
+				Metadata: types.NewTestMetadata(),
+				Add:      []types.StringValue{types.String("NET_ADMIN", types.NewTestMetadata())},
+				Drop:     []types.StringValue{types.String("ALL", types.NewTestMetadata())},
+			},
+		},
+		Resources: kubernetes.Resources{
+			Metadata:       types.NewTestMetadata(),
+			LimitsCPU:      types.String("500m", types.NewTestMetadata()),
+			LimitsMemory:   types.String("128Mi", types.NewTestMetadata()),
+			RequestsCPU:    types.String("", types.NewTestMetadata()),
+			RequestsMemory: types.String("", types.NewTestMetadata()),
+		},
+		Ports: []kubernetes.ContainerPort{
+			{
+				Metadata:      types.NewTestMetadata(),
+				ContainerPort: types.Int(8080, types.NewTestMetadata()),
+				HostPort:      types.Int(80, types.NewTestMetadata()),
+				Protocol:      types.String("TCP", types.NewTestMetadata()),
+			},
+		},
+	}, container)
+
+	require.Len(t, spec.Volumes, 1)
+	assert.Equal(t, kubernetes.VolumeTypeHostPath, spec.Volumes[0].Type.Value())
+	assert.Equal(t, "/var/run/docker.sock", spec.Volumes[0].HostPath.Value())
+
+	pod := adapted.Workloads[2]
+	assert.Equal(t, "Pod", pod.Kind.Value())
+	assert.Equal(t, "debug", pod.Template.Meta.Name.Value())
+	assert.True(t, pod.Template.Spec.HostPID.IsTrue())
+	assert.True(t, pod.Template.Spec.Containers[0].SecurityContext.AllowPrivilegeEscalation.IsTrue())
+}
+
+func Test_adaptRBAC(t *testing.T) {
+	src := `
+resource "kubernetes_cluster_role" "example" {
+	metadata {
+		name = "admin"
+	}
+	rule {
+		api_groups = [""]
+		resources  = ["secrets"]
+		verbs      = ["get", "list"]
+	}
+}
+
+resource "kubernetes_role_binding" "example" {
+	metadata {
+		name      = "admin"
+		namespace = "default"
+	}
+	role_ref {
+		api_group = "rbac.authorization.k8s.io"
+		kind      = "ClusterRole"
+		name      = "admin"
+	}
+	subject {
+		kind      = "ServiceAccount"
+		name      = "default"
+		namespace = "default"
+	}
+}
+`
+	modules := tftestutil.CreateModulesFromSource(t, src, ".tf")
+	adapted := Adapt(modules)
+	assert.Empty(t, adapted.Roles)
+	assert.Empty(t, adapted.ClusterRoleBindings)
+
+	require.Len(t, adapted.ClusterRoles, 1)
+	testutil.AssertDefsecEqual(t, []kubernetes.PolicyRule{
+		{
+			Metadata:  types.NewTestMetadata(),
+			APIGroups: []types.StringValue{types.String("", types.NewTestMetadata())},
+			Resources: []types.StringValue{types.String("secrets", types.NewTestMetadata())},
+			Verbs: []types.StringValue{
+				types.String("get", types.NewTestMetadata()),
+				types.String("list", types.NewTestMetadata()),
+			},
+		},
+	}, adapted.ClusterRoles[0].Rules)
+
+	require.Len(t, adapted.RoleBindings, 1)
+	binding := adapted.RoleBindings[0]
+	assert.Equal(t, "default", binding.Meta.Namespace.Value())
+	assert.Equal(t, "ClusterRole", binding.RoleRef.Kind.Value())
+	assert.Equal(t, "admin", binding.RoleRef.Name.Value())
+	require.Len(t, binding.Subjects, 1)
+	assert.Equal(t, "ServiceAccount", binding.Subjects[0].Kind.Value())
+}
+
+func Test_adaptServicesAndIngresses(t *testing.T) {
+	src := `
+resource "kubernetes_service" "example" {
+	metadata {
+		name = "web"
+	}
+	spec {
+		type                        = "LoadBalancer"
+		load_balancer_source_ranges = ["0.0.0.0/0"]
+		port {
+			port        = 80
+			target_port = 8080
+		}
+	}
+}
+
+resource "kubernetes_ingress_v1" "example" {
+	metadata {
+		name = "web"
+	}
+	spec {
+		ingress_class_name = "nginx"
+		rule {
+			host = "example.com"
+			http {
+				path {
+					path      = "/"
+					path_type = "Prefix"
+					backend {
+						service {
+							name = "web"
+							port {
+								number = 80
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+resource "kubernetes_ingress" "legacy" {
+	spec {
+		rule {
+			http {
+				path {
+					backend {
+						service_name = "legacy"
+						service_port = "http"
+					}
+				}
+			}
+		}
+		tls {
+			hosts       = ["example.com"]
+			secret_name = "tls"
+		}
+	}
+}
+`
+	modules := tftestutil.CreateModulesFromSource(t, src, ".tf")
+	adapted := Adapt(modules)
+
+	require.Len(t, adapted.Services, 1)
+	service := adapted.Services[0]
+	assert.Equal(t, kubernetes.ServiceTypeLoadBalancer, service.Type.Value())
+	require.Len(t, service.LoadBalancerSourceRanges, 1)
+	assert.Equal(t, "0.0.0.0/0", service.LoadBalancerSourceRanges[0].Value())
+	require.Len(t, service.Ports, 1)
+	assert.Equal(t, 80, service.Ports[0].Port.Value())
+	assert.Equal(t, "8080", service.Ports[0].TargetPort.Value())
+	assert.Equal(t, "TCP", service.Ports[0].Protocol.Value())
+
+	require.Len(t, adapted.Ingresses, 2)
+	legacy := adapted.Ingresses[0]
+	assert.Equal(t, "legacy", legacy.Rules[0].Paths[0].ServiceName.Value())
+	assert.Equal(t, "http", legacy.Rules[0].Paths[0].ServicePort.Value())
+	require.Len(t, legacy.TLS, 1)
+	assert.Equal(t, "tls", legacy.TLS[0].SecretName.Value())
+
+	ingress := adapted.Ingresses[1]
+	assert.Equal(t, "nginx", ingress.IngressClassName.Value())
+	require.Len(t, ingress.Rules, 1)
+	assert.Equal(t, "example.com", ingress.Rules[0].Host.Value())
+	require.Len(t, ingress.Rules[0].Paths, 1)
+	assert.Equal(t, "Prefix", ingress.Rules[0].Paths[0].PathType.Value())
+	assert.Equal(t, "web", ingress.Rules[0].Paths[0].ServiceName.Value())
+	assert.Equal(t, "80", ingress.Rules[0].Paths[0].ServicePort.Value())
+}
+
+func TestLines(t *testing.T) {
+	src := `
+resource "kubernetes_pod" "example" {
+	metadata {
+		name = "debug"
+	}
+	spec {
+		container {
+			name  = "debug"
+			image = "busybox"
+		}
+	}
+}`
+
+	modules := tftestutil.CreateModulesFromSource(t, src, ".tf")
+	adapted := Adapt(modules)
+
+	require.Len(t, adapted.Workloads, 1)
+	pod := adapted.Workloads[0]
+
+	assert.Equal(t, 2, pod.GetMetadata().Range().GetStartLine())
+	assert.Equal(t, 12, pod.GetMetadata().Range().GetEndLine())
+
+	assert.Equal(t, 3, pod.Meta.GetMetadata().Range().GetStartLine())
+	assert.Equal(t, 5, pod.Meta.GetMetadata().Range().GetEndLine())
+
+	assert.Equal(t, 7, pod.Template.Spec.Containers[0].GetMetadata().Range().GetStartLine())
+	assert.Equal(t, 10, pod.Template.Spec.Containers[0].GetMetadata().Range().GetEndLine())
+
+	assert.Equal(t, 9, pod.Template.Spec.Containers[0].Image.GetMetadata().Range().GetStartLine())
+}
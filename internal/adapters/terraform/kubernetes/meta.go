@@ -0,0 +1,50 @@
+package kubernetes
+
+import (
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers/kubernetes"
+	"github.com/aquasecurity/defsec/pkg/terraform"
+)
+
+// adaptObjectMeta adapts the metadata block of the parent block, e.g. a resource or pod template
+func adaptObjectMeta(parent *terraform.Block) kubernetes.ObjectMeta {
+	meta := kubernetes.ObjectMeta{
+		Metadata:    parent.GetMetadata(),
+		Name:        types.StringDefault("", parent.GetMetadata()),
+		Namespace:   types.StringDefault("", parent.GetMetadata()),
+		Labels:      types.MapDefault(make(map[string]string), parent.GetMetadata()),
+		Annotations: types.MapDefault(make(map[string]string), parent.GetMetadata()),
+	}
+	if metadataBlock := parent.GetBlock("metadata"); metadataBlock.IsNotNil() {
+		meta.Metadata = metadataBlock.GetMetadata()
+		meta.Name = metadataBlock.GetAttribute("name").AsStringValueOrDefault("", metadataBlock)
+		meta.Namespace = metadataBlock.GetAttribute("namespace").AsStringValueOrDefault("", metadataBlock)
+		if labels := metadataBlock.GetAttribute("labels"); labels.IsNotNil() {
+			meta.Labels = types.Map(stringMap(labels), labels.GetMetadata())
+		}
+		if annotations := metadataBlock.GetAttribute("annotations"); annotations.IsNotNil() {
+			meta.Annotations = types.Map(stringMap(annotations), annotations.GetMetadata())
+		}
+	}
+	return meta
+}
+
+func stringMap(attr *terraform.Attribute) map[string]string {
+	values := make(map[string]string)
+	_ = attr.Each(func(key, val cty.Value) {
+		if key.Type() == cty.String && val.Type() == cty.String {
+			values[key.AsString()] = val.AsString()
+		}
+	})
+	return values
+}
+
+func stringValues(attr *terraform.Attribute) []types.StringValue {
+	var values []types.StringValue
+	for _, value := range attr.ValueAsStrings() {
+		values = append(values, types.String(value, attr.GetMetadata()))
+	}
+	return values
+}
@@ -0,0 +1,60 @@
+package kubernetes
+
+import (
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers/kubernetes"
+	"github.com/aquasecurity/defsec/pkg/terraform"
+)
+
+func adaptRoles(modules terraform.Modules, resourceTypes ...string) []kubernetes.Role {
+	var roles []kubernetes.Role
+	for _, resource := range modules.GetResourcesByType(resourceTypes...) {
+		role := kubernetes.Role{
+			Metadata: resource.GetMetadata(),
+			Meta:     adaptObjectMeta(resource),
+		}
+		for _, rule := range resource.GetBlocks("rule") {
+			role.Rules = append(role.Rules, kubernetes.PolicyRule{
+				Metadata:      rule.GetMetadata(),
+				APIGroups:     stringValues(rule.GetAttribute("api_groups")),
+				Resources:     stringValues(rule.GetAttribute("resources")),
+				ResourceNames: stringValues(rule.GetAttribute("resource_names")),
+				Verbs:         stringValues(rule.GetAttribute("verbs")),
+			})
+		}
+		roles = append(roles, role)
+	}
+	return roles
+}
+
+func adaptRoleBindings(modules terraform.Modules, resourceTypes ...string) []kubernetes.RoleBinding {
+	var bindings []kubernetes.RoleBinding
+	for _, resource := range modules.GetResourcesByType(resourceTypes...) {
+		binding := kubernetes.RoleBinding{
+			Metadata: resource.GetMetadata(),
+			Meta:     adaptObjectMeta(resource),
+			RoleRef: kubernetes.RoleRef{
+				Metadata: resource.GetMetadata(),
+				Kind:     types.StringDefault("", resource.GetMetadata()),
+				Name:     types.StringDefault("", resource.GetMetadata()),
+			},
+		}
+		if roleRef := resource.GetBlock("role_ref"); roleRef.IsNotNil() {
+			binding.RoleRef = kubernetes.RoleRef{
+				Metadata: roleRef.GetMetadata(),
+				Kind:     roleRef.GetAttribute("kind").AsStringValueOrDefault("", roleRef),
+				Name:     roleRef.GetAttribute("name").AsStringValueOrDefault("", roleRef),
+			}
+		}
+		for _, subject := range resource.GetBlocks("subject") {
+			binding.Subjects = append(binding.Subjects, kubernetes.Subject{
+				Metadata:  subject.GetMetadata(),
+				Kind:      subject.GetAttribute("kind").AsStringValueOrDefault("", subject),
+				Name:      subject.GetAttribute("name").AsStringValueOrDefault("", subject),
+				Namespace: subject.GetAttribute("namespace").AsStringValueOrDefault("", subject),
+			})
+		}
+		bindings = append(bindings, binding)
+	}
+	return bindings
+}
@@ -0,0 +1,106 @@
+package kubernetes
+
+import (
+	"strconv"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers/kubernetes"
+	"github.com/aquasecurity/defsec/pkg/terraform"
+)
+
+func adaptServices(modules terraform.Modules) []kubernetes.Service {
+	var services []kubernetes.Service
+	for _, resource := range modules.GetResourcesByType("kubernetes_service", "kubernetes_service_v1") {
+		service := kubernetes.Service{
+			Metadata: resource.GetMetadata(),
+			Meta:     adaptObjectMeta(resource),
+			Type:     types.StringDefault(kubernetes.ServiceTypeClusterIP, resource.GetMetadata()),
+		}
+		if spec := resource.GetBlock("spec"); spec.IsNotNil() {
+			service.Type = spec.GetAttribute("type").AsStringValueOrDefault(kubernetes.ServiceTypeClusterIP, spec)
+			service.ExternalIPs = stringValues(spec.GetAttribute("external_ips"))
+			service.LoadBalancerSourceRanges = stringValues(spec.GetAttribute("load_balancer_source_ranges"))
+			for _, port := range spec.GetBlocks("port") {
+				service.Ports = append(service.Ports, kubernetes.ServicePort{
+					Metadata:   port.GetMetadata(),
+					Port:       port.GetAttribute("port").AsIntValueOrDefault(0, port),
+					TargetPort: adaptPortString(port, "target_port"),
+					NodePort:   port.GetAttribute("node_port").AsIntValueOrDefault(0, port),
+					Protocol:   port.GetAttribute("protocol").AsStringValueOrDefault("TCP", port),
+				})
+			}
+		}
+		services = append(services, service)
+	}
+	return services
+}
+
+// adaptPortString reads a port which may be given as a number or a name, e.g. 8080 or "http"
+func adaptPortString(block *terraform.Block, name string) types.StringValue {
+	attr := block.GetAttribute(name)
+	if attr.IsNumber() {
+		port := attr.AsIntValueOrDefault(0, block)
+		return types.String(strconv.Itoa(port.Value()), attr.GetMetadata())
+	}
+	return attr.AsStringValueOrDefault("", block)
+}
+
+func adaptIngresses(modules terraform.Modules) []kubernetes.IngressResource {
+	var ingresses []kubernetes.IngressResource
+	for _, resource := range modules.GetResourcesByType("kubernetes_ingress", "kubernetes_ingress_v1") {
+		ingress := kubernetes.IngressResource{
+			Metadata:         resource.GetMetadata(),
+			Meta:             adaptObjectMeta(resource),
+			IngressClassName: types.StringDefault("", resource.GetMetadata()),
+		}
+		if spec := resource.GetBlock("spec"); spec.IsNotNil() {
+			ingress.IngressClassName = spec.GetAttribute("ingress_class_name").AsStringValueOrDefault("", spec)
+			for _, tls := range spec.GetBlocks("tls") {
+				ingress.TLS = append(ingress.TLS, kubernetes.IngressTLS{
+					Metadata:   tls.GetMetadata(),
+					Hosts:      stringValues(tls.GetAttribute("hosts")),
+					SecretName: tls.GetAttribute("secret_name").AsStringValueOrDefault("", tls),
+				})
+			}
+			for _, rule := range spec.GetBlocks("rule") {
+				ingress.Rules = append(ingress.Rules, adaptIngressRule(rule))
+			}
+		}
+		ingresses = append(ingresses, ingress)
+	}
+	return ingresses
+}
+
+func adaptIngressRule(rule *terraform.Block) kubernetes.IngressRule {
+	ingressRule := kubernetes.IngressRule{
+		Metadata: rule.GetMetadata(),
+		Host:     rule.GetAttribute("host").AsStringValueOrDefault("", rule),
+	}
+	for _, path := range rule.GetBlock("http").GetBlocks("path") {
+		ingressPath := kubernetes.IngressPath{
+			Metadata:    path.GetMetadata(),
+			Path:        path.GetAttribute("path").AsStringValueOrDefault("", path),
+			PathType:    path.GetAttribute("path_type").AsStringValueOrDefault("", path),
+			ServiceName: types.StringDefault("", path.GetMetadata()),
+			ServicePort: types.StringDefault("", path.GetMetadata()),
+		}
+		if backend := path.GetBlock("backend"); backend.IsNotNil() {
+			if service := backend.GetBlock("service"); service.IsNotNil() {
+				// networking.k8s.io/v1
+				ingressPath.ServiceName = service.GetAttribute("name").AsStringValueOrDefault("", service)
+				if port := service.GetBlock("port"); port.IsNotNil() {
+					if number := port.GetAttribute("number"); number.IsNotNil() {
+						ingressPath.ServicePort = adaptPortString(port, "number")
+					} else {
+						ingressPath.ServicePort = port.GetAttribute("name").AsStringValueOrDefault("", port)
+					}
+				}
+			} else {
+				ingressPath.ServiceName = backend.GetAttribute("service_name").AsStringValueOrDefault("", backend)
+				ingressPath.ServicePort = adaptPortString(backend, "service_port")
+			}
+		}
+		ingressRule.Paths = append(ingressRule.Paths, ingressPath)
+	}
+	return ingressRule
+}
@@ -0,0 +1,272 @@
+package kubernetes
+
+import (
+	"sort"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers/kubernetes"
+	"github.com/aquasecurity/defsec/pkg/terraform"
+)
+
+// workloadKinds maps the resource types which create pods to the kind of the Kubernetes object
+var workloadKinds = map[string]string{
+	"kubernetes_pod":                       "Pod",
+	"kubernetes_pod_v1":                    "Pod",
+	"kubernetes_deployment":                "Deployment",
+	"kubernetes_deployment_v1":             "Deployment",
+	"kubernetes_stateful_set":              "StatefulSet",
+	"kubernetes_stateful_set_v1":           "StatefulSet",
+	"kubernetes_daemonset":                 "DaemonSet",
+	"kubernetes_daemon_set_v1":             "DaemonSet",
+	"kubernetes_replication_controller":    "ReplicationController",
+	"kubernetes_replication_controller_v1": "ReplicationController",
+	"kubernetes_job":                       "Job",
+	"kubernetes_job_v1":                    "Job",
+	"kubernetes_cron_job":                  "CronJob",
+	"kubernetes_cron_job_v1":               "CronJob",
+}
+
+func adaptWorkloads(modules terraform.Modules) []kubernetes.Workload {
+	var workloads []kubernetes.Workload
+	for _, module := range modules {
+		for _, resource := range module.GetResourcesByType(sortedKeys(workloadKinds)...) {
+			workloads = append(workloads, adaptWorkload(resource, workloadKinds[resource.TypeLabel()]))
+		}
+	}
+	return workloads
+}
+
+func adaptWorkload(resource *terraform.Block, kind string) kubernetes.Workload {
+	workload := kubernetes.Workload{
+		Metadata: resource.GetMetadata(),
+		Kind:     types.String(kind, resource.GetMetadata()),
+		Meta:     adaptObjectMeta(resource),
+	}
+
+	if kind == "Pod" {
+		// a pod is its own template
+		workload.Template = kubernetes.PodTemplate{
+			Metadata: resource.GetMetadata(),
+			Meta:     workload.Meta,
+			Spec:     adaptPodSpec(resource, resource.GetBlock("spec")),
+		}
+		return workload
+	}
+
+	spec := resource.GetBlock("spec")
+	if kind == "CronJob" {
+		spec = spec.GetBlock("job_template").GetBlock("spec")
+	}
+	template := spec.GetBlock("template")
+	if template.IsNil() {
+		workload.Template = kubernetes.PodTemplate{
+			Metadata: resource.GetMetadata(),
+			Meta:     adaptObjectMeta(resource),
+			Spec:     adaptPodSpec(resource, nil),
+		}
+		return workload
+	}
+	workload.Template = kubernetes.PodTemplate{
+		Metadata: template.GetMetadata(),
+		Meta:     adaptObjectMeta(template),
+		Spec:     adaptPodSpec(template, template.GetBlock("spec")),
+	}
+	return workload
+}
+
+func adaptPodSpec(parent *terraform.Block, spec *terraform.Block) kubernetes.PodSpec {
+	if spec.IsNil() {
+		return kubernetes.PodSpec{
+			Metadata:                     parent.GetMetadata(),
+			ServiceAccountName:           types.StringDefault("", parent.GetMetadata()),
+			AutomountServiceAccountToken: types.BoolDefault(true, parent.GetMetadata()),
+			HostNetwork:                  types.BoolDefault(false, parent.GetMetadata()),
+			HostPID:                      types.BoolDefault(false, parent.GetMetadata()),
+			HostIPC:                      types.BoolDefault(false, parent.GetMetadata()),
+			SecurityContext:              adaptPodSecurityContext(parent, nil),
+		}
+	}
+
+	podSpec := kubernetes.PodSpec{
+		Metadata:                     spec.GetMetadata(),
+		ServiceAccountName:           spec.GetAttribute("service_account_name").AsStringValueOrDefault("", spec),
+		AutomountServiceAccountToken: spec.GetAttribute("automount_service_account_token").AsBoolValueOrDefault(true, spec),
+		HostNetwork:                  spec.GetAttribute("host_network").AsBoolValueOrDefault(false, spec),
+		HostPID:                      spec.GetAttribute("host_pid").AsBoolValueOrDefault(false, spec),
+		HostIPC:                      spec.GetAttribute("host_ipc").AsBoolValueOrDefault(false, spec),
+		SecurityContext:              adaptPodSecurityContext(spec, spec.GetBlock("security_context")),
+	}
+	for _, container := range spec.GetBlocks("container") {
+		podSpec.Containers = append(podSpec.Containers, adaptContainer(container))
+	}
+	for _, container := range spec.GetBlocks("init_container") {
+		podSpec.InitContainers = append(podSpec.InitContainers, adaptContainer(container))
+	}
+	for _, volume := range spec.GetBlocks("volume") {
+		podSpec.Volumes = append(podSpec.Volumes, adaptVolume(volume))
+	}
+	return podSpec
+}
+
+func adaptPodSecurityContext(parent *terraform.Block, context *terraform.Block) kubernetes.PodSecurityContext {
+	if context.IsNil() {
+		return kubernetes.PodSecurityContext{
+			Metadata:           parent.GetMetadata(),
+			RunAsUser:          types.IntDefault(0, parent.GetMetadata()),
+			RunAsGroup:         types.IntDefault(0, parent.GetMetadata()),
+			RunAsNonRoot:       types.BoolDefault(false, parent.GetMetadata()),
+			FSGroup:            types.IntDefault(0, parent.GetMetadata()),
+			SeccompProfileType: types.StringDefault("", parent.GetMetadata()),
+		}
+	}
+	return kubernetes.PodSecurityContext{
+		Metadata:           context.GetMetadata(),
+		RunAsUser:          context.GetAttribute("run_as_user").AsIntValueOrDefault(0, context),
+		RunAsGroup:         context.GetAttribute("run_as_group").AsIntValueOrDefault(0, context),
+		RunAsNonRoot:       context.GetAttribute("run_as_non_root").AsBoolValueOrDefault(false, context),
+		FSGroup:            context.GetAttribute("fs_group").AsIntValueOrDefault(0, context),
+		SeccompProfileType: adaptSeccompProfileType(context),
+	}
+}
+
+func adaptSeccompProfileType(context *terraform.Block) types.StringValue {
+	if profile := context.GetBlock("seccomp_profile"); profile.IsNotNil() {
+		return profile.GetAttribute("type").AsStringValueOrDefault("", profile)
+	}
+	return types.StringDefault("", context.GetMetadata())
+}
+
+func adaptContainer(block *terraform.Block) kubernetes.Container {
+	container := kubernetes.Container{
+		Metadata:        block.GetMetadata(),
+		Name:            block.GetAttribute("name").AsStringValueOrDefault("", block),
+		Image:           block.GetAttribute("image").AsStringValueOrDefault("", block),
+		ImagePullPolicy: block.GetAttribute("image_pull_policy").AsStringValueOrDefault("", block),
+		SecurityContext: adaptSecurityContext(block),
+		Resources:       adaptResources(block),
+	}
+	for _, port := range block.GetBlocks("port") {
+		container.Ports = append(container.Ports, kubernetes.ContainerPort{
+			Metadata:      port.GetMetadata(),
+			ContainerPort: port.GetAttribute("container_port").AsIntValueOrDefault(0, port),
+			HostPort:      port.GetAttribute("host_port").AsIntValueOrDefault(0, port),
+			Protocol:      port.GetAttribute("protocol").AsStringValueOrDefault("TCP", port),
+		})
+	}
+	return container
+}
+
+func adaptSecurityContext(container *terraform.Block) kubernetes.SecurityContext {
+	context := container.GetBlock("security_context")
+	if context.IsNil() {
+		return kubernetes.SecurityContext{
+			Metadata:                 container.GetMetadata(),
+			Privileged:               types.BoolDefault(false, container.GetMetadata()),
+			AllowPrivilegeEscalation: types.BoolDefault(true, container.GetMetadata()),
+			ReadOnlyRootFilesystem:   types.BoolDefault(false, container.GetMetadata()),
+			RunAsNonRoot:             types.BoolDefault(false, container.GetMetadata()),
+			RunAsUser:                types.IntDefault(0, container.GetMetadata()),
+			RunAsGroup:               types.IntDefault(0, container.GetMetadata()),
+			SeccompProfileType:       types.StringDefault("", container.GetMetadata()),
+			Capabilities: kubernetes.Capabilities{
+				Metadata: container.GetMetadata(),
+			},
+		}
+	}
+	securityContext := kubernetes.SecurityContext{
+		Metadata:                 context.GetMetadata(),
+		Privileged:               context.GetAttribute("privileged").AsBoolValueOrDefault(false, context),
+		AllowPrivilegeEscalation: context.GetAttribute("allow_privilege_escalation").AsBoolValueOrDefault(true, context),
+		ReadOnlyRootFilesystem:   context.GetAttribute("read_only_root_filesystem").AsBoolValueOrDefault(false, context),
+		RunAsNonRoot:             context.GetAttribute("run_as_non_root").AsBoolValueOrDefault(false, context),
+		RunAsUser:                context.GetAttribute("run_as_user").AsIntValueOrDefault(0, context),
+		RunAsGroup:               context.GetAttribute("run_as_group").AsIntValueOrDefault(0, context),
+		SeccompProfileType:       adaptSeccompProfileType(context),
+		Capabilities: kubernetes.Capabilities{
+			Metadata: context.GetMetadata(),
+		},
+	}
+	if capabilities := context.GetBlock("capabilities"); capabilities.IsNotNil() {
+		securityContext.Capabilities = kubernetes.Capabilities{
+			Metadata: capabilities.GetMetadata(),
+			Add:      stringValues(capabilities.GetAttribute("add")),
+			Drop:     stringValues(capabilities.GetAttribute("drop")),
+		}
+	}
+	return securityContext
+}
+
+func adaptResources(container *terraform.Block) kubernetes.Resources {
+	resources := container.GetBlock("resources")
+	if resources.IsNil() {
+		return kubernetes.Resources{
+			Metadata:       container.GetMetadata(),
+			LimitsCPU:      types.StringDefault("", container.GetMetadata()),
+			LimitsMemory:   types.StringDefault("", container.GetMetadata()),
+			RequestsCPU:    types.StringDefault("", container.GetMetadata()),
+			RequestsMemory: types.StringDefault("", container.GetMetadata()),
+		}
+	}
+	return kubernetes.Resources{
+		Metadata:       resources.GetMetadata(),
+		LimitsCPU:      adaptQuantity(resources, "limits", "cpu"),
+		LimitsMemory:   adaptQuantity(resources, "limits", "memory"),
+		RequestsCPU:    adaptQuantity(resources, "requests", "cpu"),
+		RequestsMemory: adaptQuantity(resources, "requests", "memory"),
+	}
+}
+
+// adaptQuantity reads a resource quantity, which is set with a map attribute in newer versions of the provider
+// and a nested block in older ones
+func adaptQuantity(resources *terraform.Block, name string, key string) types.StringValue {
+	if attr := resources.GetAttribute(name); attr.IsNotNil() {
+		if value := attr.MapValue(key); !value.IsNull() && value.IsKnown() && value.Type() == cty.String {
+			return types.String(value.AsString(), attr.GetMetadata())
+		}
+		return types.StringDefault("", attr.GetMetadata())
+	}
+	if block := resources.GetBlock(name); block.IsNotNil() {
+		return block.GetAttribute(key).AsStringValueOrDefault("", block)
+	}
+	return types.StringDefault("", resources.GetMetadata())
+}
+
+// volumeTypes maps the volume source blocks to the name of the source in the Kubernetes API
+var volumeTypes = map[string]string{
+	"host_path":               kubernetes.VolumeTypeHostPath,
+	"empty_dir":               kubernetes.VolumeTypeEmptyDir,
+	"secret":                  kubernetes.VolumeTypeSecret,
+	"config_map":              kubernetes.VolumeTypeConfigMap,
+	"persistent_volume_claim": kubernetes.VolumeTypePersistentVolumeClaim,
+	"projected":               kubernetes.VolumeTypeProjected,
+}
+
+func adaptVolume(block *terraform.Block) kubernetes.Volume {
+	volume := kubernetes.Volume{
+		Metadata: block.GetMetadata(),
+		Name:     block.GetAttribute("name").AsStringValueOrDefault("", block),
+		Type:     types.StringDefault("", block.GetMetadata()),
+		HostPath: types.StringDefault("", block.GetMetadata()),
+	}
+	for _, name := range sortedKeys(volumeTypes) {
+		if source := block.GetBlock(name); source.IsNotNil() {
+			volume.Type = types.String(volumeTypes[name], source.GetMetadata())
+			if name == "host_path" {
+				volume.HostPath = source.GetAttribute("path").AsStringValueOrDefault("", source)
+			}
+			break
+		}
+	}
+	return volume
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
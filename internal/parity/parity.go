@@ -0,0 +1,240 @@
+package parity
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/aquasecurity/defsec/pkg/state"
+)
+
+const providersPackage = "github.com/aquasecurity/defsec/pkg/providers/"
+
+// Source is a type of input which is adapted into the state, e.g. Terraform
+type Source struct {
+	Name string
+	// Dir is the directory containing the adapters for the source, relative to the root of the repository
+	Dir string
+	// Provider limits the source to a single provider, whose directory is omitted from the adapter paths
+	Provider string
+}
+
+var Sources = []Source{
+	{Name: "Terraform", Dir: "internal/adapters/terraform"},
+	{Name: "CloudFormation", Dir: "internal/adapters/cloudformation"},
+	{Name: "ARM", Dir: "internal/adapters/arm", Provider: "azure"},
+	{Name: "Kubernetes", Dir: "internal/adapters/kubernetes", Provider: "kubernetes"},
+}
+
+type Support int
+
+const (
+	// Unsupported sources have no adapters for the provider of the field
+	Unsupported Support = iota
+	Missing
+	Populated
+)
+
+// Field is a field of the state which holds the resources of a service, e.g. aws.kms.keys
+type Field struct {
+	Path    string
+	Sources map[string]Support
+}
+
+// Gap returns true if the field is populated by at least one source, but not by another source for the same provider
+func (f Field) Gap() bool {
+	var populated, missing bool
+	for _, support := range f.Sources {
+		switch support {
+		case Populated:
+			populated = true
+		case Missing:
+			missing = true
+		}
+	}
+	return populated && missing
+}
+
+// Report lists the fields of the state, and which sources populate them, by reading the adapters under the given root
+// directory of the repository. A field is populated by a source if an adapter for the service sets the field, either
+// in a composite literal of the type containing it, or by assigning to a field of the same name.
+func Report(root string) ([]Field, error) {
+	var fields []Field
+	cache := make(map[string]*adapterPackage)
+	for _, owner := range serviceFields(reflect.TypeOf(state.State{}), "") {
+		field := Field{
+			Path:    owner.path,
+			Sources: make(map[string]Support),
+		}
+		for _, source := range Sources {
+			dir, ok := source.adapterDir(root, owner.pkg)
+			if !ok {
+				field.Sources[source.Name] = Unsupported
+				continue
+			}
+			adapters, ok := cache[dir]
+			if !ok {
+				var err error
+				if adapters, err = parseAdapterPackage(dir); err != nil {
+					return nil, err
+				}
+				cache[dir] = adapters
+			}
+			if adapters.sets(owner.pkg, owner.typeName, owner.fieldName) {
+				field.Sources[source.Name] = Populated
+			} else {
+				field.Sources[source.Name] = Missing
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// adapterDir returns the directory of the adapters for a provider package, e.g. pkg/providers/aws/kms, if the source
+// has adapters for the provider
+func (s Source) adapterDir(root string, pkg string) (string, bool) {
+	parts := strings.Split(strings.TrimPrefix(pkg, providersPackage), "/")
+	if s.Provider != "" {
+		if parts[0] != s.Provider {
+			return "", false
+		}
+		parts = parts[1:]
+	}
+	providerDir := filepath.Join(root, s.Dir)
+	if s.Provider == "" {
+		providerDir = filepath.Join(providerDir, parts[0])
+	}
+	if info, err := os.Stat(providerDir); err != nil || !info.IsDir() {
+		return "", false
+	}
+	return filepath.Join(append([]string{root, s.Dir}, parts...)...), true
+}
+
+type serviceField struct {
+	path      string
+	pkg       string
+	typeName  string
+	fieldName string
+}
+
+// serviceFields walks the state, descending into the structs of other provider packages, i.e. providers and services,
+// and returns the remaining fields
+func serviceFields(t reflect.Type, prefix string) []serviceField {
+	var fields []serviceField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		path := strings.ToLower(f.Name)
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+		if f.Type.Kind() == reflect.Struct && f.Type.PkgPath() != t.PkgPath() && strings.HasPrefix(f.Type.PkgPath(), providersPackage) {
+			fields = append(fields, serviceFields(f.Type, path)...)
+			continue
+		}
+		fields = append(fields, serviceField{
+			path:      path,
+			pkg:       t.PkgPath(),
+			typeName:  t.Name(),
+			fieldName: f.Name,
+		})
+	}
+	return fields
+}
+
+type adapterPackage struct {
+	// literals are the fields set in composite literals, by qualified type, e.g. .../aws/kms.KMS
+	literals map[string]map[string]bool
+	// assigned are the names of fields which are assigned to
+	assigned map[string]bool
+}
+
+func (a *adapterPackage) sets(pkg string, typeName string, fieldName string) bool {
+	return a.literals[pkg+"."+typeName][fieldName] || a.assigned[fieldName]
+}
+
+func parseAdapterPackage(dir string) (*adapterPackage, error) {
+	adapters := &adapterPackage{
+		literals: make(map[string]map[string]bool),
+		assigned: make(map[string]bool),
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return adapters, nil
+	} else if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, entry.Name()), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		adapters.add(file)
+	}
+	return adapters, nil
+}
+
+func (a *adapterPackage) add(file *ast.File) {
+	imports := make(map[string]string)
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := filepath.Base(path)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = path
+	}
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.CompositeLit:
+			selector, ok := n.Type.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			pkg, ok := selector.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			qualified := imports[pkg.Name] + "." + selector.Sel.Name
+			for _, element := range n.Elts {
+				kv, ok := element.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				key, ok := kv.Key.(*ast.Ident)
+				if !ok || isNil(kv.Value) {
+					continue
+				}
+				if a.literals[qualified] == nil {
+					a.literals[qualified] = make(map[string]bool)
+				}
+				a.literals[qualified][key.Name] = true
+			}
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				if selector, ok := lhs.(*ast.SelectorExpr); ok && !(len(n.Rhs) == len(n.Lhs) && isNil(n.Rhs[i])) {
+					a.assigned[selector.Sel.Name] = true
+				}
+			}
+		}
+		return true
+	})
+}
+
+func isNil(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "nil"
+}
@@ -0,0 +1,75 @@
+package parity
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Report(t *testing.T) {
+	fields, err := Report("../..")
+	require.NoError(t, err)
+
+	byPath := make(map[string]Field)
+	for _, field := range fields {
+		byPath[field.Path] = field
+	}
+
+	keys, ok := byPath["aws.kms.keys"]
+	require.True(t, ok)
+	assert.Equal(t, map[string]Support{
+		"Terraform":      Populated,
+		"CloudFormation": Populated,
+		"ARM":            Unsupported,
+		"Kubernetes":     Unsupported,
+	}, keys.Sources)
+	assert.False(t, keys.Gap())
+
+	functions, ok := byPath["aws.sam.functions"]
+	require.True(t, ok)
+	assert.Equal(t, Missing, functions.Sources["Terraform"])
+	assert.Equal(t, Populated, functions.Sources["CloudFormation"])
+	assert.True(t, functions.Gap())
+
+	workloads, ok := byPath["kubernetes.workloads"]
+	require.True(t, ok)
+	assert.Equal(t, Populated, workloads.Sources["Kubernetes"])
+	assert.Equal(t, Unsupported, workloads.Sources["CloudFormation"])
+}
+
+func Test_adapterPackage(t *testing.T) {
+	src := `package kms
+
+import (
+	awskms "github.com/aquasecurity/defsec/pkg/providers/aws/kms"
+)
+
+func adapt() awskms.KMS {
+	result := awskms.KMS{
+		Keys:    getKeys(),
+		Aliases: nil,
+	}
+	result.Other = nil
+	result.Assigned = getAssigned()
+	return result
+}
+`
+	file, err := parser.ParseFile(token.NewFileSet(), "adapt.go", src, 0)
+	require.NoError(t, err)
+
+	adapters := &adapterPackage{
+		literals: make(map[string]map[string]bool),
+		assigned: make(map[string]bool),
+	}
+	adapters.add(file)
+
+	pkg := "github.com/aquasecurity/defsec/pkg/providers/aws/kms"
+	assert.True(t, adapters.sets(pkg, "KMS", "Keys"))
+	assert.False(t, adapters.sets(pkg, "KMS", "Aliases"))
+	assert.False(t, adapters.sets(pkg, "KMS", "Other"))
+	assert.True(t, adapters.sets(pkg, "KMS", "Assigned"))
+	assert.False(t, adapters.sets(pkg, "Key", "Keys"))
+}
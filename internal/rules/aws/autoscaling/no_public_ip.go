@@ -26,6 +26,7 @@ var CheckNoPublicIp = rules.Register(
 			BadExamples:         terraformNoPublicIpBadExamples,
 			Links:               terraformNoPublicIpLinks,
 			RemediationMarkdown: terraformNoPublicIpRemediationMarkdown,
+			Fixes:               terraformNoPublicIpFixes,
 		},
 		CloudFormation: &scan.EngineMetadata{
 			GoodExamples:        cloudFormationNoPublicIpGoodExamples,
@@ -1,5 +1,7 @@
 package autoscaling
 
+import "github.com/aquasecurity/defsec/pkg/scan"
+
 var terraformNoPublicIpGoodExamples = []string{
 	`
  resource "aws_launch_configuration" "good_example" {
@@ -21,3 +23,12 @@ var terraformNoPublicIpLinks = []string{
 }
 
 var terraformNoPublicIpRemediationMarkdown = ``
+
+var terraformNoPublicIpFixes = []scan.Fix{
+	{
+		ResourceType: "aws_launch_configuration",
+		Operations: []scan.FixOperation{
+			{Action: scan.FixRemoveAttribute, Path: "associate_public_ip_address"},
+		},
+	},
+}
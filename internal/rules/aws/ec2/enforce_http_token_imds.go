@@ -32,6 +32,7 @@ To fully protect IMDS you need to enable session tokens by using <code>metadata_
 			BadExamples:         terraformEnforceHttpTokenImdsBadExamples,
 			Links:               terraformEnforceHttpTokenImdsLinks,
 			RemediationMarkdown: terraformEnforceHttpTokenImdsRemediationMarkdown,
+			Fixes:               terraformEnforceHttpTokenImdsFixes,
 		},
 		Severity: severity.High,
 	},
@@ -1,5 +1,7 @@
 package ec2
 
+import "github.com/aquasecurity/defsec/pkg/scan"
+
 var terraformEnforceHttpTokenImdsGoodExamples = []string{
 	`
  resource "aws_instance" "good_example" {
@@ -26,3 +28,12 @@ var terraformEnforceHttpTokenImdsLinks = []string{
 }
 
 var terraformEnforceHttpTokenImdsRemediationMarkdown = ``
+
+var terraformEnforceHttpTokenImdsFixes = []scan.Fix{
+	{
+		ResourceType: "aws_instance",
+		Operations: []scan.FixOperation{
+			{Action: scan.FixSetAttribute, Path: "metadata_options.http_tokens", Value: `"required"`},
+		},
+	},
+}
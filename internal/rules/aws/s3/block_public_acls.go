@@ -28,6 +28,7 @@ S3 buckets should block public ACLs on buckets and any objects they contain. By
 			BadExamples:         terraformBlockPublicAclsBadExamples,
 			Links:               terraformBlockPublicAclsLinks,
 			RemediationMarkdown: terraformBlockPublicAclsRemediationMarkdown,
+			Fixes:               terraformBlockPublicAclsFixes,
 		},
 		CloudFormation: &scan.EngineMetadata{
 			GoodExamples:        cloudFormationBlockPublicAclsGoodExamples,
@@ -1,5 +1,7 @@
 package s3
 
+import "github.com/aquasecurity/defsec/pkg/scan"
+
 var terraformBlockPublicAclsGoodExamples = []string{
 	`
 resource "aws_s3_bucket" "good_example" {
@@ -39,3 +41,12 @@ var terraformBlockPublicAclsLinks = []string{
 }
 
 var terraformBlockPublicAclsRemediationMarkdown = ``
+
+var terraformBlockPublicAclsFixes = []scan.Fix{
+	{
+		ResourceType: "aws_s3_bucket_public_access_block",
+		Operations: []scan.FixOperation{
+			{Action: scan.FixSetAttribute, Path: "block_public_acls", Value: `true`},
+		},
+	},
+}
@@ -29,6 +29,7 @@ S3 bucket policy should have block public policy to prevent users from putting a
 			BadExamples:         terraformBlockPublicPolicyBadExamples,
 			Links:               terraformBlockPublicPolicyLinks,
 			RemediationMarkdown: terraformBlockPublicPolicyRemediationMarkdown,
+			Fixes:               terraformBlockPublicPolicyFixes,
 		},
 		CloudFormation: &scan.EngineMetadata{
 			GoodExamples:        cloudFormationBlockPublicPolicyGoodExamples,
@@ -1,5 +1,7 @@
 package s3
 
+import "github.com/aquasecurity/defsec/pkg/scan"
+
 var terraformBlockPublicPolicyGoodExamples = []string{
 	`
 resource "aws_s3_bucket" "example" {
@@ -35,3 +37,12 @@ var terraformBlockPublicPolicyLinks = []string{
 }
 
 var terraformBlockPublicPolicyRemediationMarkdown = ``
+
+var terraformBlockPublicPolicyFixes = []scan.Fix{
+	{
+		ResourceType: "aws_s3_bucket_public_access_block",
+		Operations: []scan.FixOperation{
+			{Action: scan.FixSetAttribute, Path: "block_public_policy", Value: `true`},
+		},
+	},
+}
@@ -27,6 +27,7 @@ var CheckEncryptionIsEnabled = rules.Register(
 			BadExamples:         terraformEnableBucketEncryptionBadExamples,
 			Links:               terraformEnableBucketEncryptionLinks,
 			RemediationMarkdown: terraformEnableBucketEncryptionRemediationMarkdown,
+			Fixes:               terraformEnableBucketEncryptionFixes,
 		},
 		CloudFormation: &scan.EngineMetadata{
 			GoodExamples:        cloudFormationEnableBucketEncryptionGoodExamples,
@@ -1,5 +1,7 @@
 package s3
 
+import "github.com/aquasecurity/defsec/pkg/scan"
+
 var terraformEnableBucketEncryptionGoodExamples = []string{
 	`
  resource "aws_s3_bucket" "good_example" {
@@ -54,3 +56,26 @@ var terraformEnableBucketEncryptionLinks = []string{
 }
 
 var terraformEnableBucketEncryptionRemediationMarkdown = ``
+
+var terraformEnableBucketEncryptionFixes = []scan.Fix{
+	{
+		ResourceType: "aws_s3_bucket",
+		Operations: []scan.FixOperation{
+			{
+				Action: scan.FixSetAttribute,
+				Path:   "server_side_encryption_configuration.rule.apply_server_side_encryption_by_default.sse_algorithm",
+				Value:  `"AES256"`,
+			},
+		},
+	},
+	{
+		ResourceType: "aws_s3_bucket_server_side_encryption_configuration",
+		Operations: []scan.FixOperation{
+			{
+				Action: scan.FixSetAttribute,
+				Path:   "rule.apply_server_side_encryption_by_default.sse_algorithm",
+				Value:  `"AES256"`,
+			},
+		},
+	},
+}
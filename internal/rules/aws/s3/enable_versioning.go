@@ -30,6 +30,7 @@ With versioning you can recover more easily from both unintended user actions an
 			BadExamples:         terraformEnableVersioningBadExamples,
 			Links:               terraformEnableVersioningLinks,
 			RemediationMarkdown: terraformEnableVersioningRemediationMarkdown,
+			Fixes:               terraformEnableVersioningFixes,
 		},
 		CloudFormation: &scan.EngineMetadata{
 			GoodExamples:        cloudFormationEnableVersioningGoodExamples,
@@ -1,5 +1,7 @@
 package s3
 
+import "github.com/aquasecurity/defsec/pkg/scan"
+
 var terraformEnableVersioningGoodExamples = []string{
 	`
 resource "aws_s3_bucket" "good_example" {
@@ -36,3 +38,18 @@ var terraformEnableVersioningLinks = []string{
 }
 
 var terraformEnableVersioningRemediationMarkdown = ``
+
+var terraformEnableVersioningFixes = []scan.Fix{
+	{
+		ResourceType: "aws_s3_bucket",
+		Operations: []scan.FixOperation{
+			{Action: scan.FixSetAttribute, Path: "versioning.enabled", Value: `true`},
+		},
+	},
+	{
+		ResourceType: "aws_s3_bucket_versioning",
+		Operations: []scan.FixOperation{
+			{Action: scan.FixSetAttribute, Path: "versioning_configuration.status", Value: `"Enabled"`},
+		},
+	},
+}
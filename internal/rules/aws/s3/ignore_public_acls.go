@@ -28,6 +28,7 @@ S3 buckets should ignore public ACLs on buckets and any objects they contain. By
 			BadExamples:         terraformIgnorePublicAclsBadExamples,
 			Links:               terraformIgnorePublicAclsLinks,
 			RemediationMarkdown: terraformIgnorePublicAclsRemediationMarkdown,
+			Fixes:               terraformIgnorePublicAclsFixes,
 		},
 		CloudFormation: &scan.EngineMetadata{
 			GoodExamples:        cloudFormationIgnorePublicAclsGoodExamples,
@@ -1,5 +1,7 @@
 package s3
 
+import "github.com/aquasecurity/defsec/pkg/scan"
+
 var terraformIgnorePublicAclsGoodExamples = []string{
 	`
 resource "aws_s3_bucket" "example" {
@@ -38,3 +40,12 @@ var terraformIgnorePublicAclsLinks = []string{
 }
 
 var terraformIgnorePublicAclsRemediationMarkdown = ``
+
+var terraformIgnorePublicAclsFixes = []scan.Fix{
+	{
+		ResourceType: "aws_s3_bucket_public_access_block",
+		Operations: []scan.FixOperation{
+			{Action: scan.FixSetAttribute, Path: "ignore_public_acls", Value: `true`},
+		},
+	},
+}
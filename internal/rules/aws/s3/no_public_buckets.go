@@ -26,6 +26,7 @@ var CheckPublicBucketsAreRestricted = rules.Register(
 			BadExamples:         terraformNoPublicBucketsBadExamples,
 			Links:               terraformNoPublicBucketsLinks,
 			RemediationMarkdown: terraformNoPublicBucketsRemediationMarkdown,
+			Fixes:               terraformNoPublicBucketsFixes,
 		},
 		CloudFormation: &scan.EngineMetadata{
 			GoodExamples:        cloudFormationNoPublicBucketsGoodExamples,
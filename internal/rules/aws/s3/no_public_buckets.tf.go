@@ -1,5 +1,7 @@
 package s3
 
+import "github.com/aquasecurity/defsec/pkg/scan"
+
 var terraformNoPublicBucketsGoodExamples = []string{
 	`
 resource "aws_s3_bucket" "example" {
@@ -37,3 +39,12 @@ var terraformNoPublicBucketsLinks = []string{
 }
 
 var terraformNoPublicBucketsRemediationMarkdown = ``
+
+var terraformNoPublicBucketsFixes = []scan.Fix{
+	{
+		ResourceType: "aws_s3_bucket_public_access_block",
+		Operations: []scan.FixOperation{
+			{Action: scan.FixSetAttribute, Path: "restrict_public_buckets", Value: `true`},
+		},
+	},
+}
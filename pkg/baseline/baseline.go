@@ -0,0 +1,171 @@
+// Package baseline compares scan results with those of a previous scan, so that only newly introduced findings need to
+// fail a build while the existing backlog is tracked separately.
+package baseline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+)
+
+// Baseline holds the findings of a previous scan, as written by the JSON formatter
+type Baseline struct {
+	Results []scan.FlatResult `json:"results"`
+}
+
+// Diff is the outcome of comparing results with a baseline
+type Diff struct {
+	// New holds failures which are not in the baseline
+	New scan.Results
+	// Unchanged holds failures which are also in the baseline
+	Unchanged scan.Results
+	// Fixed holds failures from the baseline which are no longer reported
+	Fixed []scan.FlatResult
+}
+
+// Load reads a report written by the JSON formatter
+func Load(r io.Reader) (*Baseline, error) {
+	var baseline Baseline
+	if err := json.NewDecoder(r).Decode(&baseline); err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+	return &baseline, nil
+}
+
+// LoadFile reads a report written by the JSON formatter from the given path
+func LoadFile(path string) (*Baseline, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return Load(f)
+}
+
+// Compare matches the failed results against the baseline without modifying them
+func (b *Baseline) Compare(results scan.Results) Diff {
+	copied := make(scan.Results, len(results))
+	copy(copied, results)
+	return b.Apply(copied)
+}
+
+// Apply matches the failed results against the baseline, marks the failures which are already in the baseline as
+// ignored, and returns the diff. Findings are matched by the fingerprint recorded in the baseline, or by Fingerprint for
+// reports written before fingerprints were recorded. Findings sharing a fingerprint are paired up in order of their
+// location.
+func (b *Baseline) Apply(results scan.Results) Diff {
+	fingerprintOf := b.fingerprintFunc()
+	previous := make(map[string][]scan.FlatResult)
+	for _, flat := range b.Results {
+		if flat.Status != scan.StatusFailed {
+			continue
+		}
+		fingerprint := fingerprintOf(flat)
+		previous[fingerprint] = append(previous[fingerprint], flat)
+	}
+
+	current := make(map[string][]int)
+	var fingerprints []string
+	for i, result := range results {
+		if result.Status() != scan.StatusFailed {
+			continue
+		}
+		fingerprint := fingerprintOf(result.Flatten())
+		if _, ok := current[fingerprint]; !ok {
+			fingerprints = append(fingerprints, fingerprint)
+		}
+		current[fingerprint] = append(current[fingerprint], i)
+	}
+
+	var diff Diff
+	for _, fingerprint := range fingerprints {
+		indexes := current[fingerprint]
+		sort.SliceStable(indexes, func(i, j int) bool {
+			return results[indexes[i]].Range().GetStartLine() < results[indexes[j]].Range().GetStartLine()
+		})
+		matches := previous[fingerprint]
+		sortByLocation(matches)
+		for n, index := range indexes {
+			if n < len(matches) {
+				diff.Unchanged = append(diff.Unchanged, results[index])
+				results[index].OverrideStatus(scan.StatusIgnored)
+				continue
+			}
+			diff.New = append(diff.New, results[index])
+		}
+		if len(matches) > len(indexes) {
+			diff.Fixed = append(diff.Fixed, matches[len(indexes):]...)
+		}
+		delete(previous, fingerprint)
+	}
+
+	for _, matches := range previous {
+		diff.Fixed = append(diff.Fixed, matches...)
+	}
+	sortByLocation(diff.Fixed)
+	return diff
+}
+
+func (b *Baseline) fingerprintFunc() func(scan.FlatResult) string {
+	for _, flat := range b.Results {
+		if flat.Fingerprint == "" {
+			return Fingerprint
+		}
+	}
+	return func(flat scan.FlatResult) string {
+		return flat.Fingerprint
+	}
+}
+
+func sortByLocation(results []scan.FlatResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Location.Filename != results[j].Location.Filename {
+			return results[i].Location.Filename < results[j].Location.Filename
+		}
+		return results[i].Location.StartLine < results[j].Location.StartLine
+	})
+}
+
+// Fingerprint identifies a finding by its rule, file and resource rather than its line numbers, so it is not affected by
+// changes elsewhere in the file. Where a finding has no resource, its description is used instead.
+func Fingerprint(result scan.FlatResult) string {
+	ruleID := result.RuleID
+	if ruleID == "" {
+		ruleID = result.LongID
+	}
+	filename := normaliseFilename(result.Location.Filename)
+	resource := normaliseResource(result.Resource, result.Location.Filename)
+	if resource == "" {
+		resource = result.Description
+	}
+	hash := sha256.Sum256([]byte(strings.Join([]string{ruleID, filename, resource}, "\x00")))
+	return hex.EncodeToString(hash[:])
+}
+
+func normaliseFilename(filename string) string {
+	return strings.TrimPrefix(path.Clean(filepath.ToSlash(filename)), "./")
+}
+
+var locationPattern = regexp.MustCompile(`:\d+(-\d+)?$`)
+
+// normaliseResource drops resources which are only a location, e.g. "deployment.yaml:1-20", as used by results with
+// no named resource
+func normaliseResource(resource string, filename string) string {
+	if !locationPattern.MatchString(resource) {
+		return resource
+	}
+	if location := locationPattern.ReplaceAllString(resource, ""); location == filename || location == normaliseFilename(filename) {
+		return ""
+	}
+	return resource
+}
@@ -0,0 +1,116 @@
+package baseline
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers"
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newResult(avdID string, filename string, startLine int, resource string) scan.Result {
+	var results scan.Results
+	rng := types.NewRange(filename, startLine, startLine+2, "", nil)
+	results.Add("bucket is not encrypted", types.Bool(false, types.NewMetadata(rng, types.NewNamedReference(resource))))
+	results.SetRule(scan.Rule{AVDID: avdID, Provider: providers.AWSProvider, Service: "s3", ShortCode: "test"})
+	return results[0]
+}
+
+func baselineOf(t *testing.T, results ...scan.Result) *Baseline {
+	var buffer bytes.Buffer
+	require.NoError(t, json.NewEncoder(&buffer).Encode(Baseline{Results: scan.Results(results).Flatten()}))
+	baseline, err := Load(&buffer)
+	require.NoError(t, err)
+	return baseline
+}
+
+func Test_Apply(t *testing.T) {
+	previous := baselineOf(t,
+		newResult("AVD-AWS-0088", "main.tf", 10, "aws_s3_bucket.logs"),
+		newResult("AVD-AWS-0088", "main.tf", 30, "aws_s3_bucket.data"),
+		newResult("AVD-AWS-0086", "main.tf", 10, "aws_s3_bucket.logs"),
+	)
+
+	// lines have shifted, one finding was fixed and one was introduced
+	results := scan.Results{
+		newResult("AVD-AWS-0088", "./main.tf", 14, "aws_s3_bucket.logs"),
+		newResult("AVD-AWS-0088", "main.tf", 34, "aws_s3_bucket.data"),
+		newResult("AVD-AWS-0088", "main.tf", 50, "aws_s3_bucket.new"),
+	}
+
+	diff := previous.Apply(results)
+	require.Len(t, diff.New, 1)
+	assert.Equal(t, 50, diff.New[0].Range().GetStartLine())
+	require.Len(t, diff.Unchanged, 2)
+	require.Len(t, diff.Fixed, 1)
+	assert.Equal(t, "AVD-AWS-0086", diff.Fixed[0].RuleID)
+
+	assert.Len(t, results.GetFailed(), 1)
+	assert.Len(t, results.GetIgnored(), 2)
+}
+
+func Test_CompareDoesNotModifyResults(t *testing.T) {
+	previous := baselineOf(t, newResult("AVD-AWS-0088", "main.tf", 10, "aws_s3_bucket.logs"))
+	results := scan.Results{newResult("AVD-AWS-0088", "main.tf", 10, "aws_s3_bucket.logs")}
+
+	diff := previous.Compare(results)
+	assert.Len(t, diff.Unchanged, 1)
+	assert.Len(t, results.GetFailed(), 1)
+}
+
+func Test_ApplyWithoutNamedResources(t *testing.T) {
+	// results without a named resource use their location as the resource, e.g. Kubernetes manifests
+	previous := baselineOf(t,
+		newResult("AVD-KSV-0001", "pod.yaml", 1, "pod.yaml:1-3"),
+		newResult("AVD-KSV-0001", "pod.yaml", 5, "pod.yaml:5-7"),
+	)
+	results := scan.Results{
+		newResult("AVD-KSV-0001", "pod.yaml", 2, "pod.yaml:2-4"),
+		newResult("AVD-KSV-0001", "pod.yaml", 6, "pod.yaml:6-8"),
+		newResult("AVD-KSV-0001", "pod.yaml", 10, "pod.yaml:10-12"),
+	}
+
+	diff := previous.Apply(results)
+	require.Len(t, diff.New, 1)
+	assert.Equal(t, 10, diff.New[0].Range().GetStartLine())
+	assert.Len(t, diff.Unchanged, 2)
+	assert.Empty(t, diff.Fixed)
+}
+
+func Test_Fingerprint(t *testing.T) {
+	flattened := scan.Results{
+		newResult("AVD-AWS-0088", "modules/s3/main.tf", 10, "aws_s3_bucket.logs"),
+		newResult("AVD-AWS-0088", "modules/s3/main.tf", 40, "aws_s3_bucket.logs"),
+		newResult("AVD-AWS-0088", "modules/s3/main.tf", 10, "aws_s3_bucket.data"),
+	}.Flatten()
+
+	assert.Equal(t, Fingerprint(flattened[0]), Fingerprint(flattened[1]))
+	assert.NotEqual(t, Fingerprint(flattened[0]), Fingerprint(flattened[2]))
+}
+
+func Test_LoadInvalid(t *testing.T) {
+	_, err := Load(strings.NewReader("not json"))
+	assert.Error(t, err)
+}
+
+func Test_ApplyWithoutRecordedFingerprints(t *testing.T) {
+	previous := baselineOf(t,
+		newResult("AVD-AWS-0088", "main.tf", 10, "aws_s3_bucket.logs"),
+		newResult("AVD-AWS-0086", "main.tf", 10, "aws_s3_bucket.logs"),
+	)
+	// reports written before fingerprints were recorded
+	for i := range previous.Results {
+		previous.Results[i].Fingerprint = ""
+	}
+
+	diff := previous.Compare(scan.Results{newResult("AVD-AWS-0088", "main.tf", 12, "aws_s3_bucket.logs")})
+	assert.Empty(t, diff.New)
+	assert.Len(t, diff.Unchanged, 1)
+	require.Len(t, diff.Fixed, 1)
+	assert.Equal(t, "AVD-AWS-0086", diff.Fixed[0].RuleID)
+}
@@ -11,7 +11,7 @@ import (
 func outputCSV(b ConfigurableFormatter, results scan.Results) error {
 
 	records := [][]string{
-		{"file", "start_line", "end_line", "rule_id", "severity", "description", "link", "passed"},
+		{"file", "start_line", "end_line", "rule_id", "severity", "description", "link", "passed", "fingerprint"},
 	}
 
 	for _, res := range results {
@@ -42,6 +42,7 @@ func outputCSV(b ConfigurableFormatter, results scan.Results) error {
 			res.Description(),
 			link,
 			strconv.FormatBool(res.Status() == scan.StatusPassed),
+			res.Fingerprint(),
 		})
 	}
 
@@ -19,8 +19,8 @@ import (
 )
 
 func Test_CSV(t *testing.T) {
-	want := `file,start_line,end_line,rule_id,severity,description,link,passed
-test.test,123,123,aws-dynamodb-enable-at-rest-encryption,HIGH,Cluster encryption is not enabled.,,false
+	want := `file,start_line,end_line,rule_id,severity,description,link,passed,fingerprint
+test.test,123,123,aws-dynamodb-enable-at-rest-encryption,HIGH,Cluster encryption is not enabled.,,false,22f6ad49bb7fcea6cd9c27c123ba2f274de8a2565887f8bfcbbc78e62a393c72
 `
 	buffer := bytes.NewBuffer([]byte{})
 	formatter := New().AsCSV().WithWriter(buffer).Build()
@@ -36,8 +36,8 @@ test.test,123,123,aws-dynamodb-enable-at-rest-encryption,HIGH,Cluster encryption
 }
 
 func Test_CSV_WithoutPassed(t *testing.T) {
-	want := `file,start_line,end_line,rule_id,severity,description,link,passed
-test.test,123,123,aws-dynamodb-enable-at-rest-encryption,HIGH,Cluster encryption is not enabled.,,false
+	want := `file,start_line,end_line,rule_id,severity,description,link,passed,fingerprint
+test.test,123,123,aws-dynamodb-enable-at-rest-encryption,HIGH,Cluster encryption is not enabled.,,false,22f6ad49bb7fcea6cd9c27c123ba2f274de8a2565887f8bfcbbc78e62a393c72
 `
 	buffer := bytes.NewBuffer([]byte{})
 	formatter := New().AsCSV().WithWriter(buffer).Build()
@@ -54,9 +54,9 @@ test.test,123,123,aws-dynamodb-enable-at-rest-encryption,HIGH,Cluster encryption
 }
 
 func Test_CSV_WithPassed(t *testing.T) {
-	want := `file,start_line,end_line,rule_id,severity,description,link,passed
-test.test,123,123,aws-dynamodb-enable-at-rest-encryption,HIGH,Cluster encryption is not enabled.,,false
-test.test,123,123,aws-dynamodb-enable-at-rest-encryption,HIGH,Everything is fine.,,true
+	want := `file,start_line,end_line,rule_id,severity,description,link,passed,fingerprint
+test.test,123,123,aws-dynamodb-enable-at-rest-encryption,HIGH,Cluster encryption is not enabled.,,false,22f6ad49bb7fcea6cd9c27c123ba2f274de8a2565887f8bfcbbc78e62a393c72
+test.test,123,123,aws-dynamodb-enable-at-rest-encryption,HIGH,Everything is fine.,,true,22f6ad49bb7fcea6cd9c27c123ba2f274de8a2565887f8bfcbbc78e62a393c72
 `
 	buffer := bytes.NewBuffer([]byte{})
 	formatter := New().AsCSV().WithWriter(buffer).WithIncludePassed(true).Build()
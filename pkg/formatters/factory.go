@@ -55,6 +55,16 @@ func (f *factory) WithBaseDir(dir string) *factory {
 	return f
 }
 
+func (f *factory) WithScanMetadata(metadata ScanMetadata) *factory {
+	f.base.scanMetadata = &metadata
+	return f
+}
+
+func (f *factory) WithMaxLength(length int) *factory {
+	f.base.maxLength = length
+	return f
+}
+
 func (f *factory) WithCustomFormatterFunc(fn func(ConfigurableFormatter, scan.Results) error) *factory {
 	f.base.outputOverride = fn
 	return f
@@ -94,3 +104,28 @@ func (f *factory) AsSARIF() *factory {
 	f.base.outputOverride = outputSARIF
 	return f
 }
+
+func (f *factory) AsLovely() *factory {
+	f.base.outputOverride = outputLovely
+	return f
+}
+
+func (f *factory) AsHTML() *factory {
+	f.base.outputOverride = outputHTML
+	return f
+}
+
+func (f *factory) AsMarkdown() *factory {
+	f.base.outputOverride = outputMarkdown
+	return f
+}
+
+func (f *factory) AsGitLabSAST() *factory {
+	f.base.outputOverride = outputGitLabSAST
+	return f
+}
+
+func (f *factory) AsSonarQube() *factory {
+	f.base.outputOverride = outputSonarQube
+	return f
+}
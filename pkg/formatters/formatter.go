@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"sort"
+	"time"
 
 	"github.com/aquasecurity/defsec/pkg/severity"
 
@@ -22,9 +23,27 @@ type ConfigurableFormatter interface {
 	GetLinks(scan.Result) []string
 	BaseDir() string
 	DebugEnabled() bool
+	MetricsEnabled() bool
 	GroupResults([]scan.Result) ([]GroupedResult, error)
 	IncludePassed() bool
 	IncludeIgnored() bool
+	ScanMetadata() *ScanMetadata
+	MaxLength() int
+}
+
+// ScanMetadata describes the scan which produced the results, for formats which record it
+type ScanMetadata struct {
+	// Paths are the scanned paths
+	Paths     []string
+	Scanners  []ScannerMetadata
+	StartedAt time.Time
+	Duration  time.Duration
+}
+
+type ScannerMetadata struct {
+	Name     string
+	Results  int
+	Duration time.Duration
 }
 
 type Base struct {
@@ -35,6 +54,8 @@ type Base struct {
 	includePassed  bool
 	includeIgnored bool
 	baseDir        string
+	scanMetadata   *ScanMetadata
+	maxLength      int
 	writer         io.Writer
 	outputOverride func(ConfigurableFormatter, scan.Results) error
 	linksOverride  func(result scan.Result) []string
@@ -50,7 +71,7 @@ func NewBase() *Base {
 		includeIgnored: false,
 		baseDir:        ".",
 		writer:         os.Stdout,
-		outputOverride: outputSARIF,
+		outputOverride: outputLovely,
 		linksOverride: func(result scan.Result) []string {
 			return result.Rule().Links
 		},
@@ -73,6 +94,10 @@ func (b *Base) DebugEnabled() bool {
 	return b.enableDebug
 }
 
+func (b *Base) MetricsEnabled() bool {
+	return b.enableMetrics
+}
+
 func (b *Base) GetLinks(result scan.Result) []string {
 	return b.linksOverride(result)
 }
@@ -81,8 +106,19 @@ func (b *Base) BaseDir() string {
 	return b.baseDir
 }
 
+func (b *Base) ScanMetadata() *ScanMetadata {
+	return b.scanMetadata
+}
+
+// MaxLength is the maximum length of the output of formats written to size limited destinations, or 0 for no limit
+func (b *Base) MaxLength() int {
+	return b.maxLength
+}
+
 func (b *Base) Output(results scan.Results) error {
-	if !b.enableColours {
+	if b.enableColours {
+		tml.EnableFormatting()
+	} else {
 		tml.DisableFormatting()
 	}
 	return b.outputOverride(b, results)
@@ -0,0 +1,189 @@
+package formatters
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"runtime/debug"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
+)
+
+// gitlabSASTVersion is the version of the GitLab security report schema the report conforms to
+const gitlabSASTVersion = "15.0.4"
+
+const gitlabTimeFormat = "2006-01-02T15:04:05"
+
+// gitlabNamespace is used to derive stable vulnerability IDs from result fingerprints and locations
+var gitlabNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://github.com/aquasecurity/defsec"))
+
+type gitlabReport struct {
+	Version         string                `json:"version"`
+	Vulnerabilities []gitlabVulnerability `json:"vulnerabilities"`
+	Scan            gitlabScan            `json:"scan"`
+}
+
+type gitlabVulnerability struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Severity    string             `json:"severity"`
+	Solution    string             `json:"solution,omitempty"`
+	Identifiers []gitlabIdentifier `json:"identifiers"`
+	Links       []gitlabLink       `json:"links,omitempty"`
+	Location    gitlabLocation     `json:"location"`
+}
+
+type gitlabIdentifier struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	URL   string `json:"url,omitempty"`
+}
+
+type gitlabLink struct {
+	URL string `json:"url"`
+}
+
+type gitlabLocation struct {
+	File      string `json:"file"`
+	StartLine int    `json:"start_line,omitempty"`
+	EndLine   int    `json:"end_line,omitempty"`
+}
+
+type gitlabScan struct {
+	Analyzer  gitlabTool `json:"analyzer"`
+	Scanner   gitlabTool `json:"scanner"`
+	Type      string     `json:"type"`
+	StartTime string     `json:"start_time"`
+	EndTime   string     `json:"end_time"`
+	Status    string     `json:"status"`
+}
+
+type gitlabTool struct {
+	ID      string       `json:"id"`
+	Name    string       `json:"name"`
+	Version string       `json:"version"`
+	Vendor  gitlabVendor `json:"vendor"`
+}
+
+type gitlabVendor struct {
+	Name string `json:"name"`
+}
+
+// outputGitLabSAST writes failures as a GitLab SAST report (gl-sast-report.json)
+func outputGitLabSAST(b ConfigurableFormatter, results scan.Results) error {
+
+	tool := gitlabTool{
+		ID:      "defsec",
+		Name:    "defsec",
+		Version: defsecVersion(),
+		Vendor:  gitlabVendor{Name: "Aqua Security"},
+	}
+
+	start := time.Now()
+	end := start
+	if metadata := b.ScanMetadata(); metadata != nil && !metadata.StartedAt.IsZero() {
+		start = metadata.StartedAt
+		end = start.Add(metadata.Duration)
+	}
+
+	report := gitlabReport{
+		Version:         gitlabSASTVersion,
+		Vulnerabilities: []gitlabVulnerability{},
+		Scan: gitlabScan{
+			Analyzer:  tool,
+			Scanner:   tool,
+			Type:      "sast",
+			StartTime: start.UTC().Format(gitlabTimeFormat),
+			EndTime:   end.UTC().Format(gitlabTimeFormat),
+			Status:    "success",
+		},
+	}
+
+	for _, res := range results {
+		if res.Status() != scan.StatusFailed && res.Status() != scan.StatusStaleIgnore {
+			continue
+		}
+		rule := res.Rule()
+		links := b.GetLinks(res)
+
+		vulnerability := gitlabVulnerability{
+			ID:          gitlabID(b.BaseDir(), res),
+			Name:        rule.Summary,
+			Description: res.Description(),
+			Severity:    gitlabSeverity(res.Severity()),
+			Solution:    rule.Resolution,
+			Location: gitlabLocation{
+				File:      filepath.ToSlash(relativeFilename(b.BaseDir(), res.Range().GetFilename())),
+				StartLine: res.Range().GetStartLine(),
+				EndLine:   res.Range().GetEndLine(),
+			},
+		}
+		if vulnerability.Name == "" {
+			vulnerability.Name = rule.LongID()
+		}
+		if rule.AVDID != "" {
+			identifier := gitlabIdentifier{Type: "avd", Name: rule.AVDID, Value: rule.AVDID}
+			if len(links) > 0 {
+				identifier.URL = links[0]
+			}
+			vulnerability.Identifiers = append(vulnerability.Identifiers, identifier)
+		}
+		vulnerability.Identifiers = append(vulnerability.Identifiers, gitlabIdentifier{
+			Type:  "defsec_rule_id",
+			Name:  rule.LongID(),
+			Value: rule.LongID(),
+		})
+		for _, link := range links {
+			vulnerability.Links = append(vulnerability.Links, gitlabLink{URL: link})
+		}
+		report.Vulnerabilities = append(report.Vulnerabilities, vulnerability)
+	}
+
+	jsonWriter := json.NewEncoder(b.Writer())
+	jsonWriter.SetIndent("", "\t")
+	return jsonWriter.Encode(report)
+}
+
+// gitlabID returns the ID of the vulnerability, which must be unique within the report. The location is included as
+// well as the fingerprint, so that results which share a fingerprint are still told apart.
+func gitlabID(baseDir string, res scan.Result) string {
+	return uuid.NewSHA1(gitlabNamespace, []byte(res.Fingerprint()+":"+resultLocation(baseDir, res))).String()
+}
+
+func gitlabSeverity(sev severity.Severity) string {
+	switch sev {
+	case severity.Critical:
+		return "Critical"
+	case severity.High:
+		return "High"
+	case severity.Medium:
+		return "Medium"
+	case severity.Low:
+		return "Low"
+	case severity.None:
+		return "Info"
+	default:
+		return "Unknown"
+	}
+}
+
+// defsecVersion returns the version of the defsec module in the running binary, where known
+func defsecVersion() string {
+	const module = "github.com/aquasecurity/defsec"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if info.Main.Path == module && info.Main.Version != "" && info.Main.Version != "(devel)" {
+			return info.Main.Version
+		}
+		for _, dep := range info.Deps {
+			if dep.Path == module {
+				return dep.Version
+			}
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,145 @@
+package formatters
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers"
+	"github.com/aquasecurity/defsec/pkg/providers/aws/dynamodb"
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
+	"github.com/aquasecurity/defsec/test/testutil"
+)
+
+func Test_GitLabSAST(t *testing.T) {
+	want := `{
+	"version": "15.0.4",
+	"vulnerabilities": [
+		{
+			"id": "0aceb6d0-1827-5b10-b857-1c3fbdaf2076",
+			"name": "summary",
+			"description": "Cluster encryption is not enabled.",
+			"severity": "High",
+			"solution": "resolution",
+			"identifiers": [
+				{
+					"type": "avd",
+					"name": "AVD-AA-9999",
+					"value": "AVD-AA-9999",
+					"url": "https://google.com"
+				},
+				{
+					"type": "defsec_rule_id",
+					"name": "aws-dynamodb-enable-at-rest-encryption",
+					"value": "aws-dynamodb-enable-at-rest-encryption"
+				}
+			],
+			"links": [
+				{
+					"url": "https://google.com"
+				}
+			],
+			"location": {
+				"file": "test.test",
+				"start_line": 123,
+				"end_line": 123
+			}
+		}
+	],
+	"scan": {
+		"analyzer": {
+			"id": "defsec",
+			"name": "defsec",
+			"version": "unknown",
+			"vendor": {
+				"name": "Aqua Security"
+			}
+		},
+		"scanner": {
+			"id": "defsec",
+			"name": "defsec",
+			"version": "unknown",
+			"vendor": {
+				"name": "Aqua Security"
+			}
+		},
+		"type": "sast",
+		"start_time": "2022-01-01T10:00:00",
+		"end_time": "2022-01-01T10:00:05",
+		"status": "success"
+	}
+}
+`
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsGitLabSAST().WithWriter(buffer).WithScanMetadata(ScanMetadata{
+		StartedAt: time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC),
+		Duration:  5 * time.Second,
+	}).Build()
+	require.NoError(t, formatter.Output(serviceResults()))
+	assert.Equal(t, want, buffer.String())
+}
+
+func Test_GitLabSAST_NoFailures(t *testing.T) {
+	var results scan.Results
+	results.AddPassed(types.NewTestMetadata())
+
+	buffer := bytes.NewBuffer([]byte{})
+	require.NoError(t, New().AsGitLabSAST().WithWriter(buffer).Build().Output(results))
+	assert.Contains(t, buffer.String(), "\"vulnerabilities\": [],")
+}
+
+func Test_GitLabSAST_UniqueIDs(t *testing.T) {
+	source := `resource "aws_dynamodb_table" "example" {
+  server_side_encryption {
+    enabled = false
+  }
+}
+`
+	fs := testutil.CreateFS(t, map[string]string{
+		"a/main.tf": source,
+		"b/main.tf": source,
+	})
+
+	var results scan.Results
+	for _, filename := range []string{"a/main.tf", "b/main.tf"} {
+		metadata := types.NewMetadata(types.NewRange(filename, 3, 3, "", fs), types.NewNamedReference("aws_dynamodb_table.example"))
+		results.Add("Table encryption is not enabled.", types.Bool(false, metadata))
+	}
+	results.SetRule(scan.Rule{Severity: severity.High, Provider: providers.AWSProvider, Service: "dynamodb", ShortCode: "enable-at-rest-encryption"})
+
+	buffer := bytes.NewBuffer([]byte{})
+	require.NoError(t, New().AsGitLabSAST().WithWriter(buffer).Build().Output(results))
+
+	var report gitlabReport
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &report))
+	require.Len(t, report.Vulnerabilities, 2)
+	assert.NotEqual(t, report.Vulnerabilities[0].ID, report.Vulnerabilities[1].ID)
+}
+
+// serviceResults returns a failure and a pass of a rule with full metadata
+func serviceResults() scan.Results {
+	var results scan.Results
+	results.Add("Cluster encryption is not enabled.",
+		dynamodb.ServerSideEncryption{
+			Metadata: types.NewTestMetadata(),
+			Enabled:  types.Bool(false, types.NewTestMetadata()),
+		})
+	results.AddPassed(types.NewTestMetadata(), "Everything is fine.")
+	results.SetRule(scan.Rule{
+		AVDID:      "AVD-AA-9999",
+		ShortCode:  "enable-at-rest-encryption",
+		Summary:    "summary",
+		Resolution: "resolution",
+		Provider:   providers.AWSProvider,
+		Service:    "dynamodb",
+		Links:      []string{"https://google.com"},
+		Severity:   severity.High,
+	})
+	return results
+}
@@ -0,0 +1,252 @@
+package formatters
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
+)
+
+const (
+	// htmlContextLines is the number of lines printed either side of the range of a result
+	htmlContextLines = 3
+	// htmlMaxLines is the maximum number of lines of a range printed for a result
+	htmlMaxLines = 40
+)
+
+//go:embed html.tmpl
+var htmlTemplateSource string
+
+var htmlTemplate = template.Must(template.New("report").Parse(htmlTemplateSource))
+
+type htmlReport struct {
+	Scan       *ScanMetadata
+	Passed     int
+	Ignored    int
+	Failed     int
+	Severities []htmlCount
+	Providers  []htmlCount
+	Services   []htmlCount
+	Results    []htmlResult
+	Rules      []htmlRule
+}
+
+type htmlCount struct {
+	Name  string
+	Count int
+}
+
+type htmlResult struct {
+	Number      int
+	Status      string
+	Severity    string
+	RuleID      string
+	Provider    string
+	Service     string
+	Description string
+	Resource    string
+	Location    string
+	Annotation  string
+	Snippet     []htmlLine
+}
+
+type htmlLine struct {
+	Number    int
+	Content   string
+	Highlight bool
+}
+
+type htmlRule struct {
+	ID          string
+	AVDID       string
+	Severity    string
+	Summary     string
+	Explanation string
+	Impact      string
+	Resolution  string
+	Links       []string
+	Engines     []htmlEngine
+}
+
+type htmlEngine struct {
+	Name                string
+	RemediationMarkdown string
+	GoodExamples        []string
+	BadExamples         []string
+}
+
+// outputHTML writes a self-contained HTML report, including a summary, a filterable table of results with their source
+// and the documentation of each rule
+func outputHTML(b ConfigurableFormatter, results scan.Results) error {
+
+	report := htmlReport{
+		Scan: b.ScanMetadata(),
+	}
+
+	severities := make(map[string]int)
+	providers := make(map[string]int)
+	services := make(map[string]int)
+	rules := make(map[string]htmlRule)
+	sources := make(sourceLines)
+
+	for _, res := range results {
+		switch res.Status() {
+		case scan.StatusPassed:
+			report.Passed++
+			if !b.IncludePassed() {
+				continue
+			}
+		case scan.StatusIgnored:
+			report.Ignored++
+			if !b.IncludeIgnored() {
+				continue
+			}
+		default:
+			report.Failed++
+			severities[string(res.Severity())]++
+			providers[res.Rule().Provider.DisplayName()]++
+			services[fmt.Sprintf("%s / %s", res.Rule().Provider.DisplayName(), res.Rule().ServiceDisplayName())]++
+		}
+
+		rule := res.Rule()
+		if _, ok := rules[rule.LongID()]; !ok {
+			rules[rule.LongID()] = newHTMLRule(rule, b.GetLinks(res))
+		}
+
+		report.Results = append(report.Results, htmlResult{
+			Number:      len(report.Results) + 1,
+			Status:      htmlStatus(res.Status()),
+			Severity:    string(res.Severity()),
+			RuleID:      rule.LongID(),
+			Provider:    rule.Provider.DisplayName(),
+			Service:     rule.ServiceDisplayName(),
+			Description: res.Description(),
+			Resource:    res.Flatten().Resource,
+			Location:    resultLocation(b.BaseDir(), res),
+			Annotation:  res.Annotation(),
+			Snippet:     htmlSnippet(res, sources),
+		})
+	}
+
+	for _, sev := range []severity.Severity{severity.Critical, severity.High, severity.Medium, severity.Low} {
+		report.Severities = append(report.Severities, htmlCount{Name: string(sev), Count: severities[string(sev)]})
+	}
+	report.Providers = sortedCounts(providers)
+	report.Services = sortedCounts(services)
+
+	for _, rule := range rules {
+		report.Rules = append(report.Rules, rule)
+	}
+	sort.Slice(report.Rules, func(i, j int) bool {
+		return report.Rules[i].ID < report.Rules[j].ID
+	})
+
+	return htmlTemplate.Execute(b.Writer(), report)
+}
+
+func newHTMLRule(rule scan.Rule, links []string) htmlRule {
+	output := htmlRule{
+		ID:          rule.LongID(),
+		AVDID:       rule.AVDID,
+		Severity:    string(rule.Severity),
+		Summary:     rule.Summary,
+		Explanation: rule.Explanation,
+		Impact:      rule.Impact,
+		Resolution:  rule.Resolution,
+		Links:       links,
+	}
+	for _, engine := range []struct {
+		name     string
+		metadata *scan.EngineMetadata
+	}{
+		{name: "Terraform", metadata: rule.Terraform},
+		{name: "CloudFormation", metadata: rule.CloudFormation},
+	} {
+		if engine.metadata == nil {
+			continue
+		}
+		output.Engines = append(output.Engines, htmlEngine{
+			Name:                engine.name,
+			RemediationMarkdown: strings.TrimSpace(engine.metadata.RemediationMarkdown),
+			GoodExamples:        trimExamples(engine.metadata.GoodExamples),
+			BadExamples:         trimExamples(engine.metadata.BadExamples),
+		})
+	}
+	return output
+}
+
+func trimExamples(examples []string) []string {
+	var output []string
+	for _, example := range examples {
+		output = append(output, strings.Trim(example, "\n"))
+	}
+	return output
+}
+
+func htmlStatus(status scan.Status) string {
+	switch status {
+	case scan.StatusPassed:
+		return "passed"
+	case scan.StatusIgnored:
+		return "ignored"
+	case scan.StatusStaleIgnore:
+		return "stale-ignore"
+	default:
+		return "failed"
+	}
+}
+
+// htmlSnippet returns the lines of the range of the result, with a few lines of context either side
+func htmlSnippet(res scan.Result, sources sourceLines) []htmlLine {
+	rng := res.Range()
+	if rng == nil || rng.GetStartLine() <= 0 {
+		return nil
+	}
+	lines := sources.get(rng)
+	start, end := rng.GetStartLine(), rng.GetEndLine()
+	if end < start {
+		end = start
+	}
+	if start > len(lines) {
+		return nil
+	}
+	if end-start+1 > htmlMaxLines {
+		end = start + htmlMaxLines - 1
+	}
+
+	first, last := start-htmlContextLines, end+htmlContextLines
+	if first < 1 {
+		first = 1
+	}
+	if last > len(lines) {
+		last = len(lines)
+	}
+
+	var snippet []htmlLine
+	for number := first; number <= last; number++ {
+		snippet = append(snippet, htmlLine{
+			Number:    number,
+			Content:   lines[number-1],
+			Highlight: number >= start && number <= end,
+		})
+	}
+	return snippet
+}
+
+func sortedCounts(counts map[string]int) []htmlCount {
+	var output []htmlCount
+	for name, count := range counts {
+		output = append(output, htmlCount{Name: name, Count: count})
+	}
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].Count != output[j].Count {
+			return output[i].Count > output[j].Count
+		}
+		return output[i].Name < output[j].Name
+	})
+	return output
+}
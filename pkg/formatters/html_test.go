@@ -0,0 +1,70 @@
+package formatters
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers"
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
+)
+
+func Test_HTML(t *testing.T) {
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsHTML().WithWriter(buffer).Build()
+	require.NoError(t, formatter.Output(lovelyResults(t)))
+	output := buffer.String()
+
+	assert.Contains(t, output, "<p>1 failed, 1 passed, 0 ignored.</p>")
+	assert.Contains(t, output, `<tr><td class="severity severity-HIGH">HIGH</td><td>1</td></tr>`)
+	assert.Contains(t, output, `<tr><td>AWS / Dynamodb</td><td>1</td></tr>`)
+	assert.Contains(t, output, `<tbody class="result" data-severity="HIGH" data-status="failed"`)
+	assert.Contains(t, output, `<span class="highlight"><span class="number">   3 </span>	enabled = false</span>`)
+	assert.Contains(t, output, `<span><span class="number">   5 </span>}</span></pre>`)
+	assert.Contains(t, output, `<li><a href="https://example.com/dynamodb">https://example.com/dynamodb</a></li>`)
+	assert.NotContains(t, output, "Everything is fine.")
+}
+
+func Test_HTML_WithPassed(t *testing.T) {
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsHTML().WithWriter(buffer).WithIncludePassed(true).Build()
+	require.NoError(t, formatter.Output(lovelyResults(t)))
+	assert.Contains(t, buffer.String(), `data-status="passed"`)
+	assert.Contains(t, buffer.String(), "Everything is fine.")
+}
+
+func Test_HTML_RuleDocumentation(t *testing.T) {
+	var results scan.Results
+	results.Add("Bucket is <b>public</b>.", types.NewTestMetadata())
+	results.SetRule(scan.Rule{
+		AVDID:       "AVD-AWS-0001",
+		Provider:    providers.AWSProvider,
+		Service:     "s3",
+		ShortCode:   "no-public-buckets",
+		Summary:     "Buckets should not be public",
+		Explanation: "Public buckets can be read by anyone.",
+		Severity:    severity.Critical,
+		Terraform: &scan.EngineMetadata{
+			GoodExamples:        []string{"\nresource \"aws_s3_bucket\" \"good\" {}\n"},
+			BadExamples:         []string{"\nresource \"aws_s3_bucket\" \"bad\" {}\n"},
+			RemediationMarkdown: "Set `acl` to `private`.",
+		},
+	})
+
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsHTML().WithWriter(buffer).Build()
+	require.NoError(t, formatter.Output(results))
+	output := buffer.String()
+
+	assert.Contains(t, output, "Bucket is &lt;b&gt;public&lt;/b&gt;.")
+	assert.Contains(t, output, `<div class="rule" id="rule-aws-s3-no-public-buckets">`)
+	assert.Contains(t, output, "<p>Public buckets can be read by anyone.</p>")
+	assert.Contains(t, output, "<summary>Terraform</summary>")
+	assert.Contains(t, output, "<pre>Set `acl` to `private`.</pre>")
+	assert.Contains(t, output, "<pre>resource &#34;aws_s3_bucket&#34; &#34;good&#34; {}</pre>")
+	assert.Contains(t, output, "<pre>resource &#34;aws_s3_bucket&#34; &#34;bad&#34; {}</pre>")
+}
@@ -2,15 +2,52 @@ package formatters
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/aquasecurity/defsec/pkg/scan"
 )
 
+// JSONSchemaVersion is incremented whenever a field of the JSON report is changed or removed
+const JSONSchemaVersion = 2
+
+// JSONReport is the document written by the JSON formatter
+type JSONReport struct {
+	SchemaVersion int       `json:"schema_version"`
+	Scan          *JSONScan `json:"scan,omitempty"`
+	// Rules holds the rules of the reported results by long ID
+	Rules   map[string]scan.Rule `json:"rules"`
+	Results []JSONResult         `json:"results"`
+}
+
+type JSONScan struct {
+	Paths      []string      `json:"paths"`
+	Scanners   []JSONScanner `json:"scanners"`
+	StartedAt  time.Time     `json:"started_at"`
+	DurationMS int64         `json:"duration_ms"`
+}
+
+type JSONScanner struct {
+	Name       string `json:"name"`
+	Results    int    `json:"results"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+type JSONResult struct {
+	scan.FlatResult
+	Annotation    string   `json:"annotation,omitempty"`
+	RegoNamespace string   `json:"rego_namespace,omitempty"`
+	RegoRule      string   `json:"rego_rule,omitempty"`
+	Traces        []string `json:"traces,omitempty"`
+}
+
 func outputJSON(b ConfigurableFormatter, results scan.Results) error {
-	jsonWriter := json.NewEncoder(b.Writer())
-	jsonWriter.SetIndent("", "\t")
-	var flatResults []scan.FlatResult
-	for _, result := range results.GetFailed() {
+	report := JSONReport{
+		SchemaVersion: JSONSchemaVersion,
+		Scan:          jsonScan(b.ScanMetadata()),
+		Rules:         make(map[string]scan.Rule),
+		Results:       []JSONResult{},
+	}
+	for _, result := range results {
 		switch result.Status() {
 		case scan.StatusIgnored:
 			if !b.IncludeIgnored() {
@@ -23,9 +60,37 @@ func outputJSON(b ConfigurableFormatter, results scan.Results) error {
 		}
 		flat := result.Flatten()
 		flat.Links = b.GetLinks(result)
-		flatResults = append(flatResults, flat)
+		report.Results = append(report.Results, JSONResult{
+			FlatResult:    flat,
+			Annotation:    result.Annotation(),
+			RegoNamespace: result.RegoNamespace(),
+			RegoRule:      result.RegoRule(),
+			Traces:        result.Traces(),
+		})
+		report.Rules[flat.LongID] = result.Rule()
+	}
+
+	jsonWriter := json.NewEncoder(b.Writer())
+	jsonWriter.SetIndent("", "\t")
+	return jsonWriter.Encode(report)
+}
+
+func jsonScan(metadata *ScanMetadata) *JSONScan {
+	if metadata == nil {
+		return nil
+	}
+	output := JSONScan{
+		Paths:      metadata.Paths,
+		Scanners:   []JSONScanner{},
+		StartedAt:  metadata.StartedAt,
+		DurationMS: metadata.Duration.Milliseconds(),
+	}
+	for _, scanner := range metadata.Scanners {
+		output.Scanners = append(output.Scanners, JSONScanner{
+			Name:       scanner.Name,
+			Results:    scanner.Results,
+			DurationMS: scanner.Duration.Milliseconds(),
+		})
 	}
-	return jsonWriter.Encode(struct {
-		Results []scan.FlatResult `json:"results"`
-	}{flatResults})
+	return &output
 }
@@ -2,7 +2,9 @@ package formatters
 
 import (
 	"bytes"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/aquasecurity/defsec/internal/types"
 
@@ -19,6 +21,24 @@ import (
 
 func Test_JSON(t *testing.T) {
 	want := `{
+	"schema_version": 2,
+	"rules": {
+		"aws-dynamodb-enable-at-rest-encryption": {
+			"avd_id": "AVD-AA-9999",
+			"id": "AAA999",
+			"short_code": "enable-at-rest-encryption",
+			"summary": "summary",
+			"explanation": "explanation",
+			"impact": "impact",
+			"resolution": "resolution",
+			"provider": "aws",
+			"service": "dynamodb",
+			"links": [
+				"https://google.com"
+			],
+			"severity": "HIGH"
+		}
+	},
 	"results": [
 		{
 			"rule_id": "AVD-AA-9999",
@@ -39,7 +59,8 @@ func Test_JSON(t *testing.T) {
 				"filename": "test.test",
 				"start_line": 123,
 				"end_line": 123
-			}
+			},
+			"fingerprint": "209d7729c305d3479993158d7fd6580a3328b40776b852c2f7874fe44a388564"
 		}
 	]
 }
@@ -70,3 +91,80 @@ func Test_JSON(t *testing.T) {
 	require.NoError(t, formatter.Output(results))
 	assert.Equal(t, want, buffer.String())
 }
+
+func Test_JSON_WithPassedAndIgnored(t *testing.T) {
+	var results scan.Results
+	results.Add("Cluster encryption is not enabled.", types.NewTestMetadata())
+	results.AddPassed(types.NewTestMetadata(), "Everything is fine.")
+	results.AddIgnored(types.NewTestMetadata(), "Ignored.")
+	results.SetRule(scan.Rule{Provider: providers.AWSProvider, Service: "dynamodb", ShortCode: "enable-at-rest-encryption"})
+
+	tests := []struct {
+		name           string
+		includePassed  bool
+		includeIgnored bool
+		expected       []scan.Status
+	}{
+		{name: "failed only", expected: []scan.Status{scan.StatusFailed}},
+		{name: "with passed", includePassed: true, expected: []scan.Status{scan.StatusFailed, scan.StatusPassed}},
+		{name: "with ignored", includeIgnored: true, expected: []scan.Status{scan.StatusFailed, scan.StatusIgnored}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buffer := bytes.NewBuffer([]byte{})
+			formatter := New().AsJSON().WithWriter(buffer).
+				WithIncludePassed(test.includePassed).
+				WithIncludeIgnored(test.includeIgnored).
+				Build()
+			require.NoError(t, formatter.Output(results))
+
+			var report JSONReport
+			require.NoError(t, json.Unmarshal(buffer.Bytes(), &report))
+			var statuses []scan.Status
+			for _, result := range report.Results {
+				statuses = append(statuses, result.Status)
+			}
+			assert.Equal(t, test.expected, statuses)
+		})
+	}
+}
+
+func Test_JSON_WithScanMetadataAndTraces(t *testing.T) {
+	var results scan.Results
+	results.AddRego("Bucket is public.", "defsec.test", "deny", []string{"Enter data.defsec.test.deny"}, types.NewTestMetadata())
+	results.SetRule(scan.Rule{
+		Provider:  providers.AWSProvider,
+		Service:   "s3",
+		ShortCode: "no-public-buckets",
+		Terraform: &scan.EngineMetadata{GoodExamples: []string{"good"}},
+	})
+
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsJSON().WithWriter(buffer).WithScanMetadata(ScanMetadata{
+		Paths:     []string{"/code"},
+		Scanners:  []ScannerMetadata{{Name: "terraform", Results: 1, Duration: 2 * time.Second}},
+		StartedAt: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		Duration:  3 * time.Second,
+	}).Build()
+	require.NoError(t, formatter.Output(results))
+
+	var report JSONReport
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &report))
+	assert.Equal(t, JSONSchemaVersion, report.SchemaVersion)
+
+	require.NotNil(t, report.Scan)
+	assert.Equal(t, []string{"/code"}, report.Scan.Paths)
+	assert.Equal(t, []JSONScanner{{Name: "terraform", Results: 1, DurationMS: 2000}}, report.Scan.Scanners)
+	assert.Equal(t, int64(3000), report.Scan.DurationMS)
+
+	require.Len(t, report.Results, 1)
+	assert.Equal(t, "defsec.test", report.Results[0].RegoNamespace)
+	assert.Equal(t, "deny", report.Results[0].RegoRule)
+	assert.Equal(t, []string{"Enter data.defsec.test.deny"}, report.Results[0].Traces)
+
+	rule, ok := report.Rules["aws-s3-no-public-buckets"]
+	require.True(t, ok)
+	require.NotNil(t, rule.Terraform)
+	assert.Equal(t, []string{"good"}, rule.Terraform.GoodExamples)
+}
@@ -0,0 +1,201 @@
+package formatters
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/liamg/tml"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
+)
+
+const (
+	lovelyWidth = 80
+	// lovelyMaxLines is the maximum number of source lines printed for a single result
+	lovelyMaxLines = 12
+)
+
+// outputLovely writes human-readable results to a terminal, including the offending source lines
+func outputLovely(b ConfigurableFormatter, results scan.Results) error {
+
+	var filtered []scan.Result
+	for _, res := range results {
+		switch res.Status() {
+		case scan.StatusIgnored:
+			if !b.IncludeIgnored() {
+				continue
+			}
+		case scan.StatusPassed:
+			if !b.IncludePassed() {
+				continue
+			}
+		}
+		filtered = append(filtered, res)
+	}
+
+	groups, err := b.GroupResults(filtered)
+	if err != nil {
+		return err
+	}
+
+	w := b.Writer()
+	sources := make(sourceLines)
+	_, _ = fmt.Fprintln(w)
+	for _, group := range groups {
+		printLovelyResult(b, w, group, sources)
+	}
+
+	if b.MetricsEnabled() {
+		printLovelySummary(w, results)
+	}
+	return nil
+}
+
+func printLovelyResult(b ConfigurableFormatter, w io.Writer, group GroupedResult, sources sourceLines) {
+	res := group.Results()[0]
+
+	_ = tml.Fprintf(w, "<bold>Result %s</bold> %s <bold>%s</bold>\n", group.String(), lovelyStatus(res), res.Description())
+	if group.Len() > 1 {
+		_ = tml.Fprintf(w, "<darkgrey>(%d similar results)</darkgrey>\n", group.Len())
+	}
+	printLovelySeparator(w)
+
+	if location := resultLocation(b.BaseDir(), res); location != "" {
+		_ = tml.Fprintf(w, "  <lightblue>%s</lightblue>\n", location)
+		if printLovelySnippet(w, res, sources) {
+			printLovelySeparator(w)
+		}
+	}
+
+	rule := res.Rule()
+	if id := rule.LongID(); id != "" {
+		_ = tml.Fprintf(w, "  <darkgrey>%10s</darkgrey> %s\n", "ID", id)
+	}
+	if rule.Impact != "" {
+		_ = tml.Fprintf(w, "  <darkgrey>%10s</darkgrey> %s\n", "Impact", rule.Impact)
+	}
+	if rule.Resolution != "" {
+		_ = tml.Fprintf(w, "  <darkgrey>%10s</darkgrey> %s\n", "Resolution", rule.Resolution)
+	}
+	if directive := res.IgnoredBy(); directive != nil {
+		_ = tml.Fprintf(w, "  <darkgrey>%10s</darkgrey> %s:%d\n", "Ignored by", relativeFilename(b.BaseDir(), directive.Range.GetFilename()), directive.Range.GetStartLine())
+		if directive.Justification != "" {
+			_ = tml.Fprintf(w, "  <darkgrey>%10s</darkgrey> %s\n", "Reason", directive.Justification)
+		}
+	}
+	if suppression := res.Suppression(); suppression != nil {
+		_ = tml.Fprintf(w, "  <darkgrey>%10s</darkgrey> %s\n", "Suppressed", suppression.Source)
+	}
+
+	if links := b.GetLinks(res); len(links) > 0 {
+		_ = tml.Fprintf(w, "\n  <darkgrey>More information</darkgrey>\n")
+		for _, link := range links {
+			_ = tml.Fprintf(w, "  <darkgrey>-</darkgrey> <blue>%s</blue>\n", link)
+		}
+	}
+	printLovelySeparator(w)
+	_, _ = fmt.Fprintln(w)
+}
+
+// printLovelySnippet prints the source lines of the result, and returns false if they could not be read
+func printLovelySnippet(w io.Writer, res scan.Result, sources sourceLines) bool {
+	rng := res.Range()
+	if rng.GetStartLine() <= 0 {
+		return false
+	}
+	lines := sources.get(rng)
+
+	start, end := rng.GetStartLine(), rng.GetEndLine()
+	if end < start {
+		end = start
+	}
+	if start > len(lines) {
+		return false
+	}
+	if end > len(lines) {
+		end = len(lines)
+	}
+	truncated := end-start+1 > lovelyMaxLines
+	if truncated {
+		end = start + lovelyMaxLines - 1
+	}
+
+	printLovelySeparator(w)
+	numberWidth := len(fmt.Sprint(end))
+	for number := start; number <= end; number++ {
+		line := strings.ReplaceAll(lines[number-1], "\t", "  ")
+		_ = tml.Fprintf(w, "  <darkgrey>%*d │</darkgrey> %s", numberWidth, number, line)
+		if number == end && !truncated && res.Annotation() != "" {
+			_ = tml.Fprintf(w, "  <darkgrey>%s</darkgrey>", res.Annotation())
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+	if truncated {
+		_ = tml.Fprintf(w, "  <darkgrey>%*s │ ...</darkgrey>\n", numberWidth, "")
+	}
+	return true
+}
+
+func printLovelySeparator(w io.Writer) {
+	_ = tml.Fprintf(w, "<darkgrey>%s</darkgrey>\n", strings.Repeat("─", lovelyWidth))
+}
+
+func printLovelySummary(w io.Writer, results scan.Results) {
+	counts := make(map[severity.Severity]int)
+	var passed, ignored, stale int
+	for _, res := range results {
+		switch res.Status() {
+		case scan.StatusPassed:
+			passed++
+		case scan.StatusIgnored:
+			ignored++
+		case scan.StatusStaleIgnore:
+			stale++
+		case scan.StatusFailed:
+			counts[res.Severity()]++
+		}
+	}
+
+	_ = tml.Fprintf(w, "  <bold>Results</bold>\n")
+	printLovelySeparator(w)
+	_ = tml.Fprintf(w, "  <darkgrey>%-20s</darkgrey> %d\n", "passed", passed)
+	_ = tml.Fprintf(w, "  <darkgrey>%-20s</darkgrey> %d\n", "ignored", ignored)
+	if stale > 0 {
+		_ = tml.Fprintf(w, "  <darkgrey>%-20s</darkgrey> %d\n", "stale ignores", stale)
+	}
+	var failed int
+	for _, sev := range []severity.Severity{severity.Critical, severity.High, severity.Medium, severity.Low} {
+		_ = tml.Fprintf(w, "  <darkgrey>%-20s</darkgrey> %d\n", strings.ToLower(string(sev)), counts[sev])
+		failed += counts[sev]
+	}
+	failed += counts[severity.None]
+	_, _ = fmt.Fprintln(w)
+
+	if failed == 0 {
+		_ = tml.Fprintf(w, "  <green><bold>No problems detected!</bold></green>\n\n")
+		return
+	}
+	_ = tml.Fprintf(w, "  <red><bold>%d potential problem(s) detected.</bold></red>\n\n", failed)
+}
+
+func lovelyStatus(res scan.Result) string {
+	switch res.Status() {
+	case scan.StatusPassed:
+		return tml.Sprintf("<green>PASSED</green>")
+	case scan.StatusIgnored:
+		return tml.Sprintf("<darkgrey>IGNORED</darkgrey>")
+	}
+	switch res.Severity() {
+	case severity.Critical:
+		return tml.Sprintf("<bold><red>CRITICAL</red></bold>")
+	case severity.High:
+		return tml.Sprintf("<red>HIGH</red>")
+	case severity.Medium:
+		return tml.Sprintf("<yellow>MEDIUM</yellow>")
+	case severity.Low:
+		return tml.Sprintf("<lightgrey>LOW</lightgrey>")
+	}
+	return string(res.Severity())
+}
@@ -0,0 +1,105 @@
+package formatters
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers"
+	"github.com/aquasecurity/defsec/pkg/providers/aws/dynamodb"
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
+	"github.com/aquasecurity/defsec/test/testutil"
+)
+
+func lovelyResults(t *testing.T) scan.Results {
+	fs := testutil.CreateFS(t, map[string]string{
+		"project/main.tf": `resource "aws_dynamodb_table" "example" {
+  server_side_encryption {
+	enabled = false
+  }
+}
+`,
+	})
+	metadata := types.NewMetadata(types.NewRange("project/main.tf", 3, 3, "", fs), &types.FakeReference{})
+
+	var results scan.Results
+	results.Add("Table encryption is not enabled.",
+		dynamodb.ServerSideEncryption{
+			Metadata: metadata,
+			Enabled:  types.Bool(false, metadata),
+		})
+	results.AddPassed(types.NewTestMetadata(), "Everything is fine.")
+	results.SetRule(scan.Rule{
+		Severity:   severity.High,
+		Provider:   providers.AWSProvider,
+		Service:    "dynamodb",
+		ShortCode:  "enable-at-rest-encryption",
+		Impact:     "Data can be read if the table is compromised",
+		Resolution: "Enable encryption at rest",
+		Links:      []string{"https://example.com/dynamodb"},
+	})
+	results[0].OverrideAnnotation("false")
+	return results
+}
+
+func Test_Lovely(t *testing.T) {
+	want := `
+Result #1 HIGH Table encryption is not enabled.
+────────────────────────────────────────────────────────────────────────────────
+  project/main.tf:3
+────────────────────────────────────────────────────────────────────────────────
+  3 │   enabled = false  false
+────────────────────────────────────────────────────────────────────────────────
+          ID aws-dynamodb-enable-at-rest-encryption
+      Impact Data can be read if the table is compromised
+  Resolution Enable encryption at rest
+
+  More information
+  - https://example.com/dynamodb
+────────────────────────────────────────────────────────────────────────────────
+
+  Results
+────────────────────────────────────────────────────────────────────────────────
+  passed               1
+  ignored              0
+  critical             0
+  high                 1
+  medium               0
+  low                  0
+
+  1 potential problem(s) detected.
+
+`
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsLovely().WithWriter(buffer).WithColoursEnabled(false).Build()
+	require.NoError(t, formatter.Output(lovelyResults(t)))
+	assert.Equal(t, want, buffer.String())
+}
+
+func Test_Lovely_WithPassedAndWithoutMetrics(t *testing.T) {
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsLovely().WithWriter(buffer).WithColoursEnabled(false).WithIncludePassed(true).WithMetricsEnabled(false).Build()
+	require.NoError(t, formatter.Output(lovelyResults(t)))
+	assert.Contains(t, buffer.String(), "Result #1 HIGH Table encryption is not enabled.")
+	assert.Contains(t, buffer.String(), "Result #2 PASSED Everything is fine.")
+	assert.Contains(t, buffer.String(), "  test.test:123\n")
+	assert.NotContains(t, buffer.String(), "potential problem(s) detected")
+}
+
+func Test_Lovely_WithColours(t *testing.T) {
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsLovely().WithWriter(buffer).WithColoursEnabled(true).Build()
+	require.NoError(t, formatter.Output(lovelyResults(t)))
+	assert.Contains(t, buffer.String(), "\x1b[31mHIGH")
+}
+
+func Test_Lovely_IsDefault(t *testing.T) {
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().WithWriter(buffer).WithColoursEnabled(false).Build()
+	require.NoError(t, formatter.Output(lovelyResults(t)))
+	assert.Contains(t, buffer.String(), "1 potential problem(s) detected.")
+}
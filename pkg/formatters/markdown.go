@@ -0,0 +1,205 @@
+package formatters
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
+)
+
+var markdownSeverities = []severity.Severity{severity.Critical, severity.High, severity.Medium, severity.Low}
+
+type markdownRule struct {
+	rule    scan.Rule
+	links   []string
+	results []scan.Result
+}
+
+// outputMarkdown writes a summary of the failures suitable for a pull request comment, with a collapsible section for
+// each rule. Passed and ignored results are only counted. Rules which would take the comment beyond the maximum length
+// are left out, and a notice is added in their place.
+func outputMarkdown(b ConfigurableFormatter, results scan.Results) error {
+
+	var passed, ignored int
+	counts := make(map[severity.Severity]int)
+	byRule := make(map[string]*markdownRule)
+	for _, res := range results {
+		switch res.Status() {
+		case scan.StatusPassed:
+			passed++
+			continue
+		case scan.StatusIgnored:
+			ignored++
+			continue
+		}
+		counts[res.Severity()]++
+		rule, ok := byRule[res.Rule().LongID()]
+		if !ok {
+			rule = &markdownRule{rule: res.Rule(), links: b.GetLinks(res)}
+			byRule[res.Rule().LongID()] = rule
+		}
+		rule.results = append(rule.results, res)
+	}
+
+	var rules []*markdownRule
+	var failed int
+	for _, rule := range byRule {
+		rules = append(rules, rule)
+		failed += len(rule.results)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if a, b := rules[i].rule.Severity.Ordinal(), rules[j].rule.Severity.Ordinal(); a != b {
+			return a > b
+		}
+		return rules[i].rule.LongID() < rules[j].rule.LongID()
+	})
+
+	var output strings.Builder
+	output.WriteString("## defsec results\n\n")
+	if failed == 0 {
+		_, _ = fmt.Fprintf(&output, "No problems detected (%d passed, %d ignored).\n", passed, ignored)
+		return writeString(b.Writer(), output.String())
+	}
+
+	output.WriteString("| Severity | Failures |\n| --- | ---: |\n")
+	for _, sev := range markdownSeverities {
+		_, _ = fmt.Fprintf(&output, "| %s | %d |\n", sev, counts[sev])
+	}
+	_, _ = fmt.Fprintf(&output, "\n**%d failure(s)** from %d rule(s), %d passed, %d ignored.\n\n", failed, len(rules), passed, ignored)
+
+	maxLength := b.MaxLength()
+	for i, rule := range rules {
+		section := markdownSection(b.BaseDir(), rule, 0)
+		if maxLength <= 0 {
+			output.WriteString(section)
+			continue
+		}
+		notice := markdownTruncationNotice(rules[i:], maxLength)
+		remaining := maxLength - output.Len() - len(notice)
+		if len(section) > remaining {
+			// show as many results of the rule as will fit
+			section = ""
+			if remaining > 0 {
+				section = markdownSection(b.BaseDir(), rule, remaining)
+			}
+			if section == "" {
+				output.WriteString(notice)
+				break
+			}
+			output.WriteString(section)
+			if i < len(rules)-1 {
+				output.WriteString(markdownTruncationNotice(rules[i+1:], maxLength))
+			}
+			break
+		}
+		output.WriteString(section)
+	}
+
+	return writeString(b.Writer(), output.String())
+}
+
+// markdownSection returns the section of a single rule, listing as many of its results as fit within the given length,
+// or all of them if the length is 0. An empty string is returned if not even one result fits.
+func markdownSection(baseDir string, rule *markdownRule, length int) string {
+	var header strings.Builder
+	_, _ = fmt.Fprintf(&header, "<details>\n<summary><b>%s</b> <code>%s</code> %s (%d)</summary>\n\n",
+		rule.rule.Severity, rule.rule.LongID(), html.EscapeString(rule.rule.Summary), len(rule.results))
+	if rule.rule.Impact != "" {
+		_, _ = fmt.Fprintf(&header, "**Impact:** %s\n\n", markdownEscape(rule.rule.Impact))
+	}
+	if rule.rule.Resolution != "" {
+		_, _ = fmt.Fprintf(&header, "**Resolution:** %s\n\n", markdownEscape(rule.rule.Resolution))
+	}
+	header.WriteString("| Location | Resource | Description |\n| --- | --- | --- |\n")
+
+	var footer strings.Builder
+	footer.WriteString("\n")
+	if len(rule.links) > 0 {
+		footer.WriteString("More information:\n")
+		for _, link := range rule.links {
+			_, _ = fmt.Fprintf(&footer, "- %s\n", link)
+		}
+		footer.WriteString("\n")
+	}
+	footer.WriteString("</details>\n\n")
+
+	var rows []string
+	for _, res := range rule.results {
+		rows = append(rows, fmt.Sprintf("| %s | %s | %s |\n",
+			markdownLocation(baseDir, res), markdownEscape(res.Flatten().Resource), markdownEscape(res.Description())))
+	}
+
+	if length <= 0 {
+		return header.String() + strings.Join(rows, "") + footer.String()
+	}
+
+	var body strings.Builder
+	for i, row := range rows {
+		more := ""
+		if i < len(rows)-1 {
+			more = fmt.Sprintf("| … | | %d more result(s) not shown |\n", len(rows)-i-1)
+		}
+		if header.Len()+body.Len()+len(row)+len(more)+footer.Len() > length {
+			if i == 0 {
+				return ""
+			}
+			body.WriteString(fmt.Sprintf("| … | | %d more result(s) not shown |\n", len(rows)-i))
+			break
+		}
+		body.WriteString(row)
+	}
+	return header.String() + body.String() + footer.String()
+}
+
+// markdownTruncationNotice returns the notice added in place of the rules which were left out
+func markdownTruncationNotice(rules []*markdownRule, maxLength int) string {
+	var failures int
+	for _, rule := range rules {
+		failures += len(rule.results)
+	}
+	return fmt.Sprintf("> **Note:** %d rule(s) with %d failure(s) are not shown, as the comment is limited to %d characters.\n", len(rules), failures, maxLength)
+}
+
+// markdownLocation returns a link to the lines of the result, relative to the base directory
+func markdownLocation(baseDir string, res scan.Result) string {
+	location := resultLocation(baseDir, res)
+	rng := res.Range()
+	if location == "" || rng.GetStartLine() <= 0 {
+		return markdownEscape(location)
+	}
+	target := filepath.ToSlash(relativeFilename(baseDir, rng.GetFilename()))
+	anchor := fmt.Sprintf("#L%d", rng.GetStartLine())
+	if rng.GetEndLine() > rng.GetStartLine() {
+		anchor = fmt.Sprintf("%s-L%d", anchor, rng.GetEndLine())
+	}
+	return fmt.Sprintf("[%s](%s%s)", markdownEscape(filepath.ToSlash(location)), strings.ReplaceAll(target, " ", "%20"), anchor)
+}
+
+var markdownReplacer = strings.NewReplacer(
+	"\\", "\\\\",
+	"|", "\\|",
+	"<", "&lt;",
+	">", "&gt;",
+	"`", "\\`",
+	"*", "\\*",
+	"_", "\\_",
+	"[", "\\[",
+	"]", "\\]",
+	"\r\n", " ",
+	"\n", " ",
+)
+
+// markdownEscape escapes text so it is shown as is, including within a table cell
+func markdownEscape(text string) string {
+	return markdownReplacer.Replace(text)
+}
+
+func writeString(w io.Writer, content string) error {
+	_, err := io.WriteString(w, content)
+	return err
+}
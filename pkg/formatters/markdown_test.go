@@ -0,0 +1,105 @@
+package formatters
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers"
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
+)
+
+func markdownResults(ruleCount int, resultsPerRule int) scan.Results {
+	var all scan.Results
+	for i := 0; i < ruleCount; i++ {
+		var results scan.Results
+		for j := 0; j < resultsPerRule; j++ {
+			metadata := types.NewMetadata(types.NewRange("modules/main.tf", 10*j+1, 10*j+3, "", nil), &types.FakeReference{})
+			results.Add(fmt.Sprintf("Problem %d.", j+1), metadata)
+		}
+		results.SetRule(scan.Rule{
+			Provider:  providers.AWSProvider,
+			Service:   "s3",
+			ShortCode: fmt.Sprintf("rule-%d", i+1),
+			Summary:   "Buckets must be <private>",
+			Severity:  severity.High,
+		})
+		all = append(all, results...)
+	}
+	return all
+}
+
+func Test_Markdown(t *testing.T) {
+	want := "## defsec results\n\n" +
+		"| Severity | Failures |\n| --- | ---: |\n" +
+		"| CRITICAL | 0 |\n| HIGH | 1 |\n| MEDIUM | 0 |\n| LOW | 0 |\n\n" +
+		"**1 failure(s)** from 1 rule(s), 1 passed, 0 ignored.\n\n" +
+		"<details>\n<summary><b>HIGH</b> <code>aws-dynamodb-enable-at-rest-encryption</code>  (1)</summary>\n\n" +
+		"**Impact:** Data can be read if the table is compromised\n\n" +
+		"**Resolution:** Enable encryption at rest\n\n" +
+		"| Location | Resource | Description |\n| --- | --- | --- |\n" +
+		"| [project/main.tf:3](project/main.tf#L3) |  | Table encryption is not enabled. |\n\n" +
+		"More information:\n- https://example.com/dynamodb\n\n" +
+		"</details>\n\n"
+
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsMarkdown().WithWriter(buffer).Build()
+	require.NoError(t, formatter.Output(lovelyResults(t)))
+	assert.Equal(t, want, buffer.String())
+}
+
+func Test_Markdown_NoFailures(t *testing.T) {
+	var results scan.Results
+	results.AddPassed(types.NewTestMetadata())
+
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsMarkdown().WithWriter(buffer).Build()
+	require.NoError(t, formatter.Output(results))
+	assert.Equal(t, "## defsec results\n\nNo problems detected (1 passed, 0 ignored).\n", buffer.String())
+}
+
+func Test_Markdown_Escaping(t *testing.T) {
+	var results scan.Results
+	results.Add("Value `a|b` is <bad>", types.NewMetadata(types.NewRange("my dir/main.tf", 1, 2, "", nil), &types.FakeReference{}))
+	results.SetRule(scan.Rule{Provider: providers.AWSProvider, Service: "s3", ShortCode: "rule", Summary: "Use <b>private</b>", Severity: severity.Low})
+
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsMarkdown().WithWriter(buffer).Build()
+	require.NoError(t, formatter.Output(results))
+	assert.Contains(t, buffer.String(), "<code>aws-s3-rule</code> Use &lt;b&gt;private&lt;/b&gt; (1)")
+	assert.Contains(t, buffer.String(), "| [my dir/main.tf:1-2](my%20dir/main.tf#L1-L2) |  | Value \\`a\\|b\\` is &lt;bad&gt; |\n")
+}
+
+func Test_Markdown_Truncation(t *testing.T) {
+	results := markdownResults(3, 20)
+
+	buffer := bytes.NewBuffer([]byte{})
+	require.NoError(t, New().AsMarkdown().WithWriter(buffer).Build().Output(results))
+	full := buffer.String()
+	assert.Contains(t, full, "**60 failure(s)** from 3 rule(s)")
+	assert.NotContains(t, full, "not shown")
+
+	for _, maxLength := range []int{400, 1000, 2500, len(full) - 100} {
+		t.Run(fmt.Sprint(maxLength), func(t *testing.T) {
+			buffer := bytes.NewBuffer([]byte{})
+			require.NoError(t, New().AsMarkdown().WithWriter(buffer).WithMaxLength(maxLength).Build().Output(results))
+			output := buffer.String()
+			assert.LessOrEqual(t, len(output), maxLength)
+			assert.Contains(t, output, "**60 failure(s)** from 3 rule(s)")
+			assert.Contains(t, output, "not shown")
+			assert.Equal(t, strings.Count(output, "<details>"), strings.Count(output, "</details>"))
+		})
+	}
+
+	buffer.Reset()
+	require.NoError(t, New().AsMarkdown().WithWriter(buffer).WithMaxLength(1000).Build().Output(results))
+	assert.Contains(t, buffer.String(), "<code>aws-s3-rule-1</code>")
+	assert.Contains(t, buffer.String(), "more result(s) not shown |\n")
+	assert.Contains(t, buffer.String(), "> **Note:** 2 rule(s) with 40 failure(s) are not shown, as the comment is limited to 1000 characters.\n")
+}
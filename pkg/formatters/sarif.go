@@ -1,6 +1,7 @@
 package formatters
 
 import (
+	"fmt"
 	"path/filepath"
 
 	"github.com/aquasecurity/defsec/pkg/severity"
@@ -10,6 +11,9 @@ import (
 	"github.com/owenrumney/go-sarif/v2/sarif"
 )
 
+// sarifFingerprintKey names the partial fingerprint of a result, see scan.Result.Fingerprint
+const sarifFingerprintKey = "defsecFingerprint/v1"
+
 func outputSARIF(b ConfigurableFormatter, results scan.Results) error {
 	report, err := sarif.New(sarif.Version210)
 	if err != nil {
@@ -43,10 +47,8 @@ func outputSARIF(b ConfigurableFormatter, results scan.Results) error {
 		}
 
 		rng := res.Range()
-		relativePath, err := filepath.Rel(baseDir, rng.GetFilename())
-		if err != nil {
-			return err
-		}
+		// filenames are usually relative to the scanned directory already
+		relativePath := filepath.ToSlash(relativeFilename(baseDir, rng.GetFilename()))
 		if baseDir == rng.GetFilename() {
 			relativePath = filepath.Base(baseDir)
 		}
@@ -73,7 +75,15 @@ func outputSARIF(b ConfigurableFormatter, results scan.Results) error {
 
 		ruleResult.WithMessage(message).
 			WithLevel(level).
+			WithPartialFingerPrints(map[string]interface{}{
+				sarifFingerprintKey: res.Fingerprint(),
+			}).
 			AddLocation(sarif.NewLocation().WithPhysicalLocation(location))
+
+		if suppression := res.Suppression(); suppression != nil {
+			ruleResult.AddSuppression(sarif.NewSuppression("external").
+				WithJustifcation(fmt.Sprintf("%s (owner: %s, expires: %s, %s)", suppression.Reason, suppression.Owner, suppression.Expires, suppression.Source)))
+		}
 	}
 
 	return report.PrettyWrite(b.Writer())
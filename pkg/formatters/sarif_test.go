@@ -58,7 +58,10 @@ func Test_SARIF(t *testing.T) {
                 }
               }
             }
-          ]
+          ],
+          "partialFingerprints": {
+            "defsecFingerprint/v1": "209d7729c305d3479993158d7fd6580a3328b40776b852c2f7874fe44a388564"
+          }
         }
       ]
     }
@@ -90,3 +93,16 @@ func Test_SARIF(t *testing.T) {
 	require.NoError(t, formatter.Output(results))
 	assert.Equal(t, want, buffer.String())
 }
+
+func Test_SARIF_RelativeFilenames(t *testing.T) {
+	var results scan.Results
+	results.Add("Cluster encryption is not enabled.", types.NewMetadata(types.NewRange("modules/main.tf", 1, 2, "", nil), &types.FakeReference{}))
+	results.Add("Cluster encryption is not enabled.", types.NewMetadata(types.NewRange("/code/other/main.tf", 1, 2, "", nil), &types.FakeReference{}))
+	results.SetRule(scan.Rule{Severity: severity.High, Provider: providers.AWSProvider, Service: "dynamodb", ShortCode: "enable-at-rest-encryption"})
+
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsSARIF().WithWriter(buffer).WithBaseDir("/code").Build()
+	require.NoError(t, formatter.Output(results))
+	assert.Contains(t, buffer.String(), `"uri": "modules/main.tf"`)
+	assert.Contains(t, buffer.String(), `"uri": "other/main.tf"`)
+}
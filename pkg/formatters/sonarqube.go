@@ -0,0 +1,84 @@
+package formatters
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
+)
+
+type sonarQubeReport struct {
+	Issues []sonarQubeIssue `json:"issues"`
+}
+
+type sonarQubeIssue struct {
+	EngineID        string            `json:"engineId"`
+	RuleID          string            `json:"ruleId"`
+	Severity        string            `json:"severity"`
+	Type            string            `json:"type"`
+	PrimaryLocation sonarQubeLocation `json:"primaryLocation"`
+}
+
+type sonarQubeLocation struct {
+	Message   string              `json:"message"`
+	FilePath  string              `json:"filePath"`
+	TextRange *sonarQubeTextRange `json:"textRange,omitempty"`
+}
+
+type sonarQubeTextRange struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine,omitempty"`
+}
+
+// outputSonarQube writes failures in the SonarQube generic issue import format
+func outputSonarQube(b ConfigurableFormatter, results scan.Results) error {
+
+	report := sonarQubeReport{
+		Issues: []sonarQubeIssue{},
+	}
+
+	for _, res := range results {
+		if res.Status() != scan.StatusFailed && res.Status() != scan.StatusStaleIgnore {
+			continue
+		}
+		rng := res.Range()
+		issue := sonarQubeIssue{
+			EngineID: "defsec",
+			RuleID:   res.Rule().LongID(),
+			Severity: sonarQubeSeverity(res.Severity()),
+			Type:     "VULNERABILITY",
+			PrimaryLocation: sonarQubeLocation{
+				Message:  res.Description(),
+				FilePath: filepath.ToSlash(relativeFilename(b.BaseDir(), rng.GetFilename())),
+			},
+		}
+		// SonarQube rejects lines which are not in the file, so line 0 must be left out
+		if rng.GetStartLine() > 0 {
+			issue.PrimaryLocation.TextRange = &sonarQubeTextRange{StartLine: rng.GetStartLine()}
+			if rng.GetEndLine() > rng.GetStartLine() {
+				issue.PrimaryLocation.TextRange.EndLine = rng.GetEndLine()
+			}
+		}
+		report.Issues = append(report.Issues, issue)
+	}
+
+	jsonWriter := json.NewEncoder(b.Writer())
+	jsonWriter.SetIndent("", "\t")
+	return jsonWriter.Encode(report)
+}
+
+func sonarQubeSeverity(sev severity.Severity) string {
+	switch sev {
+	case severity.Critical:
+		return "BLOCKER"
+	case severity.High:
+		return "CRITICAL"
+	case severity.Medium:
+		return "MAJOR"
+	case severity.Low:
+		return "MINOR"
+	default:
+		return "INFO"
+	}
+}
@@ -0,0 +1,58 @@
+package formatters
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers"
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
+)
+
+func Test_SonarQube(t *testing.T) {
+	want := `{
+	"issues": [
+		{
+			"engineId": "defsec",
+			"ruleId": "aws-dynamodb-enable-at-rest-encryption",
+			"severity": "CRITICAL",
+			"type": "VULNERABILITY",
+			"primaryLocation": {
+				"message": "Cluster encryption is not enabled.",
+				"filePath": "test.test",
+				"textRange": {
+					"startLine": 123
+				}
+			}
+		}
+	]
+}
+`
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsSonarQube().WithWriter(buffer).Build()
+	require.NoError(t, formatter.Output(serviceResults()))
+	assert.Equal(t, want, buffer.String())
+}
+
+func Test_SonarQube_Locations(t *testing.T) {
+	var results scan.Results
+	results.Add("Spans several lines.", types.NewMetadata(types.NewRange("/code/modules/main.tf", 3, 7, "", nil), &types.FakeReference{}))
+	results.Add("Applies to the whole file.", types.NewMetadata(types.NewRange("/code/Dockerfile", 0, 0, "", nil), &types.FakeReference{}))
+	results.SetRule(scan.Rule{Provider: providers.AWSProvider, Service: "s3", ShortCode: "rule", Severity: severity.Critical})
+
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsSonarQube().WithWriter(buffer).WithBaseDir("/code").Build()
+	require.NoError(t, formatter.Output(results))
+	assert.Contains(t, buffer.String(), `"severity": "BLOCKER"`)
+	assert.Contains(t, buffer.String(), `"filePath": "modules/main.tf",
+				"textRange": {
+					"startLine": 3,
+					"endLine": 7
+				}`)
+	assert.Contains(t, buffer.String(), `"filePath": "Dockerfile"
+			}`)
+}
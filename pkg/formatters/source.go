@@ -0,0 +1,60 @@
+package formatters
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/scan"
+)
+
+// sourceLines caches the lines of the files referenced by results, so each file is only read once
+type sourceLines map[string][]string
+
+// get returns the lines of the file containing the range, or nil if it cannot be read
+func (s sourceLines) get(rng types.Range) []string {
+	if rng == nil || rng.GetFS() == nil {
+		return nil
+	}
+	key := fmt.Sprintf("%p:%s", rng.GetFS(), rng.GetLocalFilename())
+	lines, ok := s[key]
+	if !ok {
+		content, err := fs.ReadFile(rng.GetFS(), rng.GetLocalFilename())
+		if err == nil {
+			lines = strings.Split(strings.TrimSuffix(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n"), "\n")
+		}
+		s[key] = lines
+	}
+	return lines
+}
+
+// relativeFilename returns the filename relative to the base directory where possible
+func relativeFilename(baseDir string, filename string) string {
+	if !filepath.IsAbs(filename) {
+		return filename
+	}
+	if abs, err := filepath.Abs(baseDir); err == nil {
+		if rel, err := filepath.Rel(abs, filename); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	return filename
+}
+
+// resultLocation returns the filename of the result relative to the base directory, followed by its lines
+func resultLocation(baseDir string, res scan.Result) string {
+	rng := res.Range()
+	if rng == nil || rng.GetFilename() == "" {
+		return ""
+	}
+	location := relativeFilename(baseDir, rng.GetFilename())
+	if rng.GetStartLine() > 0 {
+		location = fmt.Sprintf("%s:%d", location, rng.GetStartLine())
+		if rng.GetEndLine() > rng.GetStartLine() {
+			location = fmt.Sprintf("%s-%d", location, rng.GetEndLine())
+		}
+	}
+	return location
+}
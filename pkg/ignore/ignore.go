@@ -0,0 +1,135 @@
+// Package ignore implements the inline ignore comments recognised by every scanner. Directives take the form
+//
+//	defsec:ignore:<rule-id>[<attribute>=<value>,...][:exp:<yyyy-mm-dd>][:ws:<workspace>] [justification]
+//
+// in a comment starting with #, // or /*. The rule ID may contain * and ? wildcards and is matched against the AVD ID,
+// the long ID (e.g. aws-s3-enable-bucket-encryption) and the legacy ID of a rule. The older tfsec: and cfsec: prefixes
+// are accepted in place of defsec:. A directive at the end of a line applies to that line, a directive on a line of its
+// own applies to the line below it, and a stack of directives on consecutive lines applies to the line below the stack.
+// Terraform keeps its original rule, where a directive applies to both its own line and the line below it.
+//
+// Expiry dates are inclusive, so a directive with exp:2022-12-31 still applies on 31 December.
+package ignore
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/defsec/internal/types"
+)
+
+type Directive struct {
+	// Range is the location of the comment containing the directive
+	Range         types.Range
+	RuleID        string
+	Expiry        *time.Time
+	Workspace     string
+	Block         bool
+	Params        map[string]string
+	Justification string
+	// anchor is the line the directive is attached to - the last line of a stack of block directives
+	anchor int
+}
+
+type Directives []Directive
+
+// Expired returns true if the directive has an expiry date which has passed
+func (d Directive) Expired() bool {
+	return d.expiredAt(time.Now())
+}
+
+func (d Directive) expiredAt(now time.Time) bool {
+	return d.Expiry != nil && expiredAt(*d.Expiry, now)
+}
+
+// expiredAt returns true if an expiry date has passed. Expiry dates are inclusive, so they pass at the end of the day
+// (UTC). This applies to both inline directives and suppression files.
+func expiredAt(expiry time.Time, now time.Time) bool {
+	return !now.Before(expiry.AddDate(0, 0, 1))
+}
+
+// MatchesID returns true if the rule ID of the directive matches any of the given IDs
+func (d Directive) MatchesID(ids ...string) bool {
+	return matchesID(d.RuleID, ids...)
+}
+
+// matchesID matches rule IDs against a pattern which may contain wildcards, ignoring case
+func matchesID(pattern string, ids ...string) bool {
+	if pattern == "*" || len(ids) == 0 {
+		return true
+	}
+	pattern = strings.ToLower(pattern)
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+		id = strings.ToLower(id)
+		if id == pattern {
+			return true
+		}
+		if matched, err := path.Match(pattern, id); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// AnchorRange returns the range of the line the directive is attached to. For a stack of directives on consecutive
+// lines this is the last line of the stack.
+func (d Directive) AnchorRange() types.Range {
+	if d.Range == nil || d.anchor == 0 {
+		return d.Range
+	}
+	return types.NewRange(d.Range.GetLocalFilename(), d.anchor, d.anchor, d.Range.GetSourcePrefix(), d.Range.GetFS())
+}
+
+// Covers returns true if the directive applies to a range. A directive at the end of a line applies to a range starting
+// on that line, and a directive on a line of its own applies to a range starting on the line below.
+func (d Directive) Covers(r types.Range) bool {
+	anchor := d.AnchorRange()
+	if r == nil || anchor == nil || anchor.GetFilename() != r.GetFilename() {
+		return false
+	}
+	if d.Block {
+		return r.GetStartLine() == anchor.GetStartLine()+1
+	}
+	return r.GetStartLine() == anchor.GetStartLine()
+}
+
+// Covering returns the first unexpired directive which matches one of the given rule IDs and covers the metadata,
+// one of its parents, or the resource containing it. Directives scoped to a workspace are only used by Terraform.
+func (ds Directives) Covering(m types.Metadata, ids ...string) *Directive {
+	ranges := coveredRanges(m)
+	for i, directive := range ds {
+		if directive.Expired() || directive.Workspace != "" || !directive.MatchesID(ids...) {
+			continue
+		}
+		for _, r := range ranges {
+			if directive.Covers(r) {
+				return &ds[i]
+			}
+		}
+	}
+	return nil
+}
+
+// resourceReference is implemented by references which know the range of the resource containing them, such as those
+// of CloudFormation properties
+type resourceReference interface {
+	ResourceRange() types.Range
+}
+
+func coveredRanges(m types.Metadata) []types.Range {
+	var ranges []types.Range
+	for meta := &m; meta != nil; meta = meta.Parent() {
+		if meta.Range() == nil {
+			break
+		}
+		ranges = append(ranges, meta.Range())
+		if ref, ok := meta.Reference().(resourceReference); ok && ref.ResourceRange() != nil {
+			ranges = append(ranges, ref.ResourceRange())
+		}
+	}
+	return ranges
+}
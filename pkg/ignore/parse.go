@@ -0,0 +1,159 @@
+package ignore
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/defsec/internal/types"
+)
+
+var (
+	commentPattern   = regexp.MustCompile(`(#|//|/\*)\s*(defsec|tfsec|cfsec):ignore:`)
+	directivePattern = regexp.MustCompile(`^(defsec|tfsec|cfsec):`)
+)
+
+// ParseFS parses the directives in each of the given files. Files which cannot be read are skipped.
+func ParseFS(fsys fs.FS, paths ...string) Directives {
+	var directives Directives
+	seen := make(map[string]struct{})
+	for _, path := range paths {
+		if _, ok := seen[path]; ok {
+			continue
+		}
+		seen[path] = struct{}{}
+		content, err := fs.ReadFile(fsys, filepath.ToSlash(path))
+		if err != nil {
+			continue
+		}
+		directives = append(directives, Parse(content, path)...)
+	}
+	return directives
+}
+
+// Parse returns the directives in the comments of the given file content
+func Parse(content []byte, path string) Directives {
+	var directives Directives
+	blockLines := make(map[int]struct{})
+	for i, line := range strings.Split(string(content), "\n") {
+		lineDirectives := parseLine(line)
+		for _, directive := range lineDirectives {
+			directive.Range = types.NewRange(path, i+1, i+1, "", nil)
+			directive.anchor = i + 1
+			if directive.Block {
+				blockLines[i+1] = struct{}{}
+			}
+			directives = append(directives, directive)
+		}
+	}
+
+	// a stack of block directives on consecutive lines applies to the line below the stack
+	lines := make([]int, 0, len(blockLines))
+	for line := range blockLines {
+		lines = append(lines, line)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(lines)))
+	anchors := make(map[int]int)
+	for _, line := range lines {
+		anchors[line] = line
+		if anchor, ok := anchors[line+1]; ok {
+			anchors[line] = anchor
+		}
+	}
+	for i, directive := range directives {
+		if directive.Block {
+			directives[i].anchor = anchors[directive.anchor]
+		}
+	}
+
+	return directives
+}
+
+func parseLine(line string) []Directive {
+	loc := commentPattern.FindStringIndex(line)
+	if loc == nil {
+		return nil
+	}
+
+	block := strings.TrimSpace(line[:loc[0]]) == ""
+	comment := strings.TrimSpace(line[loc[0]:])
+	comment = strings.TrimSuffix(comment, "*/")
+
+	var directives []Directive
+	var justification []string
+	for _, token := range strings.Fields(comment) {
+		token = strings.TrimLeft(token, "#/*")
+		if token == "" {
+			continue
+		}
+		if !directivePattern.MatchString(token) {
+			justification = append(justification, token)
+			continue
+		}
+		directive, err := parseDirective(token)
+		if err != nil {
+			continue
+		}
+		directive.Block = block
+		directives = append(directives, *directive)
+	}
+
+	reason := strings.TrimLeft(strings.Join(justification, " "), "-: ")
+	for i := range directives {
+		directives[i].Justification = reason
+	}
+	return directives
+}
+
+func parseDirective(input string) (*Directive, error) {
+	match := directivePattern.FindString(input)
+	if match == "" {
+		return nil, fmt.Errorf("invalid ignore")
+	}
+
+	var directive Directive
+	segments := strings.Split(strings.TrimPrefix(input, match), ":")
+	for i := 0; i < len(segments)-1; i += 2 {
+		key := segments[i]
+		val := segments[i+1]
+		switch key {
+		case "ignore":
+			directive.RuleID, directive.Params = parseIDWithParams(val)
+		case "exp":
+			parsed, err := time.Parse("2006-01-02", val)
+			if err != nil {
+				return nil, err
+			}
+			directive.Expiry = &parsed
+		case "ws":
+			directive.Workspace = val
+		}
+	}
+	if directive.RuleID == "" {
+		return nil, fmt.Errorf("ignore has no rule ID")
+	}
+
+	return &directive, nil
+}
+
+func parseIDWithParams(input string) (string, map[string]string) {
+	params := make(map[string]string)
+	if !strings.Contains(input, "[") {
+		return input, params
+	}
+	parts := strings.Split(input, "[")
+	id := parts[0]
+	paramStr := strings.TrimSuffix(parts[1], "]")
+	for _, pair := range strings.Split(paramStr, ",") {
+		parts := strings.Split(pair, "=")
+		if len(parts) != 2 {
+			continue
+		}
+		params[parts[0]] = parts[1]
+	}
+	return id, params
+}
@@ -0,0 +1,149 @@
+package ignore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Parse(t *testing.T) {
+	tests := []struct {
+		name          string
+		line          string
+		ruleIDs       []string
+		block         bool
+		expiry        string
+		workspace     string
+		params        map[string]string
+		justification string
+	}{
+		{
+			name:    "hcl inline",
+			line:    `  secure = false # tfsec:ignore:aws-s3-enable-bucket-encryption`,
+			ruleIDs: []string{"aws-s3-enable-bucket-encryption"},
+		},
+		{
+			name:    "double slash block",
+			line:    `//defsec:ignore:AVD-AWS-0086`,
+			ruleIDs: []string{"AVD-AWS-0086"},
+			block:   true,
+		},
+		{
+			name:    "c style comment",
+			line:    `  "enabled": false /* defsec:ignore:AVD-AZU-0001 */`,
+			ruleIDs: []string{"AVD-AZU-0001"},
+		},
+		{
+			name:          "yaml with expiry and justification",
+			line:          `      Public: true # cfsec:ignore:AVD-AWS-0087:exp:2221-01-02 -- public website assets`,
+			ruleIDs:       []string{"AVD-AWS-0087"},
+			expiry:        "2221-01-02",
+			justification: "public website assets",
+		},
+		{
+			name:          "dockerfile with several directives",
+			line:          `# defsec:ignore:DS002 defsec:ignore:DS006 the base image requires root`,
+			ruleIDs:       []string{"DS002", "DS006"},
+			block:         true,
+			justification: "the base image requires root",
+		},
+		{
+			name:      "workspace and params",
+			line:      `# tfsec:ignore:*[secure=false]:ws:staging`,
+			ruleIDs:   []string{"*"},
+			block:     true,
+			workspace: "staging",
+			params:    map[string]string{"secure": "false"},
+		},
+		{
+			name: "invalid expiry drops the directive",
+			line: `secure = false # tfsec:ignore:aws-service-abc123:exp:2221-13-02`,
+		},
+		{
+			name: "not in a comment",
+			line: `value = "defsec:ignore:AVD-AWS-0086"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			directives := Parse([]byte("\n"+test.line), "main.tf")
+			require.Len(t, directives, len(test.ruleIDs))
+			for i, directive := range directives {
+				assert.Equal(t, test.ruleIDs[i], directive.RuleID)
+				assert.Equal(t, test.block, directive.Block)
+				assert.Equal(t, test.workspace, directive.Workspace)
+				assert.Equal(t, test.justification, directive.Justification)
+				assert.Equal(t, 2, directive.Range.GetStartLine())
+				if test.expiry == "" {
+					assert.Nil(t, directive.Expiry)
+				} else {
+					require.NotNil(t, directive.Expiry)
+					assert.Equal(t, test.expiry, directive.Expiry.Format("2006-01-02"))
+				}
+				if test.params != nil {
+					assert.Equal(t, test.params, directive.Params)
+				}
+			}
+		})
+	}
+}
+
+func Test_ParseStackedDirectives(t *testing.T) {
+	directives := Parse([]byte(`# defsec:ignore:a
+
+# defsec:ignore:b
+# defsec:ignore:c
+resource "x" "y" {}
+`), "main.tf")
+	require.Len(t, directives, 3)
+
+	resource := types.NewRange("main.tf", 5, 5, "", nil)
+	assert.False(t, directives[0].Covers(resource))
+	assert.True(t, directives[1].Covers(resource))
+	assert.True(t, directives[2].Covers(resource))
+	assert.Equal(t, 3, directives[1].Range.GetStartLine())
+	assert.Equal(t, 4, directives[1].AnchorRange().GetStartLine())
+}
+
+func Test_Covering(t *testing.T) {
+	expired := time.Now().AddDate(0, 0, -2)
+	directives := Directives{
+		{Range: types.NewRange("a.yaml", 2, 2, "", nil), anchor: 2, Block: true, RuleID: "AVD-KSV-*"},
+		{Range: types.NewRange("a.yaml", 10, 10, "", nil), anchor: 10, Block: true, RuleID: "*", Expiry: &expired},
+		{Range: types.NewRange("a.yaml", 20, 20, "", nil), anchor: 20, Block: true, RuleID: "*", Workspace: "dev"},
+	}
+
+	tests := []struct {
+		name    string
+		line    int
+		ids     []string
+		covered bool
+	}{
+		{name: "wildcard match", line: 3, ids: []string{"AVD-KSV-0001", "KSV001"}, covered: true},
+		{name: "case insensitive", line: 3, ids: []string{"avd-ksv-0012"}, covered: true},
+		{name: "no id match", line: 3, ids: []string{"AVD-DS-0002"}},
+		{name: "too far from directive", line: 4, ids: []string{"AVD-KSV-0001"}},
+		{name: "expired", line: 11, ids: []string{"AVD-KSV-0001"}},
+		{name: "workspace", line: 21, ids: []string{"AVD-KSV-0001"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			metadata := types.NewMetadata(types.NewRange("a.yaml", test.line, test.line+5, "", nil), &types.FakeReference{})
+			assert.Equal(t, test.covered, directives.Covering(metadata, test.ids...) != nil)
+		})
+	}
+}
+
+func Test_DirectiveExpiresAtTheEndOfTheDay(t *testing.T) {
+	directives := Parse([]byte(`# defsec:ignore:*:exp:2026-10-18`), "main.tf")
+	require.Len(t, directives, 1)
+
+	assert.False(t, directives[0].expiredAt(time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, directives[0].expiredAt(time.Date(2026, 10, 18, 23, 59, 59, 0, time.UTC)))
+	assert.True(t, directives[0].expiredAt(time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)))
+}
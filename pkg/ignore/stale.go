@@ -0,0 +1,86 @@
+package ignore
+
+import "fmt"
+
+// StaleReason explains why a directive is reported as stale
+type StaleReason string
+
+const (
+	// StaleExpired is reported for directives whose expiry date has passed
+	StaleExpired StaleReason = "expired"
+	// StaleUnknownRule is reported for directives whose rule ID does not match any rule known to the scan
+	StaleUnknownRule StaleReason = "unknown-rule"
+	// StaleUnused is reported for directives which did not cause any result to be ignored
+	StaleUnused StaleReason = "unused"
+)
+
+// StaleDirective is a directive which should be removed or updated
+type StaleDirective struct {
+	Directive
+	Reason StaleReason
+}
+
+// Description returns a human readable explanation of why the directive is stale
+func (s StaleDirective) Description() string {
+	switch s.Reason {
+	case StaleExpired:
+		return fmt.Sprintf("Ignore directive for '%s' expired on %s.", s.RuleID, s.Expiry.Format("2006-01-02"))
+	case StaleUnknownRule:
+		return fmt.Sprintf("Ignore directive refers to unknown rule '%s'.", s.RuleID)
+	default:
+		return fmt.Sprintf("Ignore directive for '%s' did not ignore any result.", s.RuleID)
+	}
+}
+
+// Stale returns the directives which are expired, refer to a rule ID matching none of the known IDs, or are not in the
+// list of used directives. When no IDs are known no rules were run, so only expired directives are returned.
+func (ds Directives) Stale(used Directives, knownIDs []string) []StaleDirective {
+	var stale []StaleDirective
+	var seen Directives
+	for _, directive := range ds {
+		// the same file may be loaded more than once, e.g. by a module with count
+		if seen.Contains(directive) {
+			continue
+		}
+		seen = append(seen, directive)
+		switch {
+		case directive.Expired():
+			stale = append(stale, StaleDirective{Directive: directive, Reason: StaleExpired})
+		case len(knownIDs) == 0:
+			continue
+		case !directive.matchesAnyKnownID(knownIDs):
+			stale = append(stale, StaleDirective{Directive: directive, Reason: StaleUnknownRule})
+		case !used.Contains(directive):
+			stale = append(stale, StaleDirective{Directive: directive, Reason: StaleUnused})
+		}
+	}
+	return stale
+}
+
+func (d Directive) matchesAnyKnownID(knownIDs []string) bool {
+	for _, id := range knownIDs {
+		if id != "" && d.MatchesID(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains returns true if the list includes a directive parsed from the same comment as the given one
+func (ds Directives) Contains(d Directive) bool {
+	key := d.Key()
+	for _, other := range ds {
+		if other.Key() == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Key identifies the comment a directive was parsed from, along with its rule ID
+func (d Directive) Key() string {
+	if d.Range == nil {
+		return d.RuleID
+	}
+	return fmt.Sprintf("%s:%d:%s", d.Range.GetFilename(), d.Range.GetStartLine(), d.RuleID)
+}
@@ -0,0 +1,47 @@
+package ignore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Stale(t *testing.T) {
+	directives := Parse([]byte(`
+# tfsec:ignore:aws-s3-enable-bucket-encryption
+# tfsec:ignore:aws-s3-enable-versioning:exp:2000-01-01
+# tfsec:ignore:aws-s3-no-such-rule
+# tfsec:ignore:aws-s3-*
+resource "aws_s3_bucket" "bucket" {}
+`), "main.tf")
+	require.Len(t, directives, 4)
+
+	// the same file may be parsed twice, e.g. for a module with count
+	directives = append(directives, directives...)
+
+	used := Directives{directives[0]}
+	known := []string{"aws-s3-enable-bucket-encryption", "aws-s3-enable-versioning", "AVD-AWS-0088"}
+
+	reasons := make(map[string]StaleReason)
+	for _, stale := range directives.Stale(used, known) {
+		reasons[stale.RuleID] = stale.Reason
+	}
+	assert.Equal(t, map[string]StaleReason{
+		"aws-s3-enable-versioning": StaleExpired,
+		"aws-s3-no-such-rule":      StaleUnknownRule,
+		"aws-s3-*":                 StaleUnused,
+	}, reasons)
+}
+
+func Test_StaleWithoutKnownIDs(t *testing.T) {
+	directives := Parse([]byte(`FROM alpine # defsec:ignore:custom-rule
+RUN apk add curl # defsec:ignore:custom-rule:exp:2000-01-01
+`), "Dockerfile")
+	require.Len(t, directives, 2)
+
+	stale := directives.Stale(nil, nil)
+	require.Len(t, stale, 1)
+	assert.Equal(t, StaleExpired, stale[0].Reason)
+	assert.Equal(t, "Ignore directive for 'custom-rule' expired on 2000-01-01.", stale[0].Description())
+}
@@ -0,0 +1,220 @@
+package ignore
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SuppressionFilename is the conventional name of a suppression file, which is read from the root of a scan
+const SuppressionFilename = ".defsecignore"
+
+// Suppression is an entry in a suppression file. Results are suppressed when they match every criterion given by the
+// entry - a rule ID, a file glob, a resource address and a range of lines. The rule ID, file glob and resource address
+// may contain wildcards.
+type Suppression struct {
+	ID       string `json:"id,omitempty" yaml:"id"`
+	Path     string `json:"path,omitempty" yaml:"path"`
+	Resource string `json:"resource,omitempty" yaml:"resource"`
+	Lines    string `json:"lines,omitempty" yaml:"lines"`
+	Reason   string `json:"reason" yaml:"reason"`
+	Owner    string `json:"owner" yaml:"owner"`
+	Expires  string `json:"expires" yaml:"expires"`
+	// Source is the location of the entry, e.g. .defsecignore:12
+	Source string `json:"source" yaml:"-"`
+
+	expiry    time.Time
+	startLine int
+	endLine   int
+}
+
+type Suppressions []Suppression
+
+// Finding describes a result to match against suppressions
+type Finding struct {
+	IDs       []string
+	Filename  string
+	Resource  string
+	StartLine int
+	EndLine   int
+}
+
+type suppressionFile struct {
+	Suppressions []yaml.Node `yaml:"suppressions"`
+}
+
+// LoadSuppressions reads a suppression file in YAML or JSON format. If no path is given, the conventional
+// .defsecignore file is read from the root of the filesystem if it exists.
+func LoadSuppressions(fsys fs.FS, path string) (Suppressions, error) {
+	explicit := path != ""
+	if !explicit {
+		path = SuppressionFilename
+	}
+	content, err := fs.ReadFile(fsys, filepath.ToSlash(path))
+	if err != nil {
+		if !explicit && errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ParseSuppressions(content, path)
+}
+
+// ParseSuppressions parses the content of a suppression file. Every entry must have a reason, an owner and an expiry
+// date, and at least one criterion to match results against.
+func ParseSuppressions(content []byte, path string) (Suppressions, error) {
+	var file suppressionFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("invalid suppression file %s: %w", path, err)
+	}
+
+	var suppressions Suppressions
+	for _, node := range file.Suppressions {
+		var suppression Suppression
+		if err := node.Decode(&suppression); err != nil {
+			return nil, fmt.Errorf("invalid suppression at %s:%d: %w", path, node.Line, err)
+		}
+		suppression.Source = fmt.Sprintf("%s:%d", path, node.Line)
+		if err := suppression.validate(); err != nil {
+			return nil, fmt.Errorf("invalid suppression at %s: %w", suppression.Source, err)
+		}
+		suppressions = append(suppressions, suppression)
+	}
+	return suppressions, nil
+}
+
+func (s *Suppression) validate() error {
+	var missing []string
+	for _, field := range []struct{ name, value string }{
+		{"reason", s.Reason},
+		{"owner", s.Owner},
+		{"expires", s.Expires},
+	} {
+		if strings.TrimSpace(field.value) == "" {
+			missing = append(missing, field.name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required field(s): %s", strings.Join(missing, ", "))
+	}
+	if s.ID == "" && s.Path == "" && s.Resource == "" {
+		return fmt.Errorf("at least one of id, path or resource is required")
+	}
+
+	expiry, err := time.Parse("2006-01-02", s.Expires)
+	if err != nil {
+		return fmt.Errorf("expires must be a date in the form YYYY-MM-DD: %w", err)
+	}
+	s.expiry = expiry
+
+	if s.Lines != "" {
+		start, end, err := parseLineRange(s.Lines)
+		if err != nil {
+			return err
+		}
+		s.startLine, s.endLine = start, end
+	}
+	return nil
+}
+
+func parseLineRange(lines string) (int, int, error) {
+	parts := strings.SplitN(lines, "-", 2)
+	start, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid line range '%s'", lines)
+	}
+	end := start
+	if len(parts) == 2 {
+		if end, err = strconv.Atoi(strings.TrimSpace(parts[1])); err != nil || end < start {
+			return 0, 0, fmt.Errorf("invalid line range '%s'", lines)
+		}
+	}
+	return start, end, nil
+}
+
+// Expired returns true if the expiry date of the suppression has passed
+func (s Suppression) Expired() bool {
+	return s.expiredAt(time.Now())
+}
+
+func (s Suppression) expiredAt(now time.Time) bool {
+	return expiredAt(s.expiry, now)
+}
+
+// Matches returns true if the suppression applies to the finding. Expired suppressions never match.
+func (s Suppression) Matches(finding Finding) bool {
+	if s.Expired() {
+		return false
+	}
+	if s.ID != "" && !matchesID(s.ID, finding.IDs...) {
+		return false
+	}
+	if s.Path != "" && !matchesPath(s.Path, finding.Filename) {
+		return false
+	}
+	// resource addresses may contain brackets, e.g. aws_s3_bucket.logs[0], so only * and ? are treated as wildcards
+	if s.Resource != "" && !globToRegexp(s.Resource).MatchString(finding.Resource) {
+		return false
+	}
+	if s.startLine > 0 && (finding.EndLine < s.startLine || finding.StartLine > s.endLine) {
+		return false
+	}
+	return true
+}
+
+// Matching returns the first suppression which applies to the finding
+func (ss Suppressions) Matching(finding Finding) *Suppression {
+	for i, suppression := range ss {
+		if suppression.Matches(finding) {
+			return &ss[i]
+		}
+	}
+	return nil
+}
+
+// matchesPath matches a filename against a glob, where ** matches any number of directories. A glob without a
+// separator is matched against the base name of the file.
+func matchesPath(pattern string, filename string) bool {
+	filename = strings.TrimPrefix(strings.TrimPrefix(filepath.ToSlash(filename), "./"), "/")
+	pattern = strings.TrimPrefix(strings.TrimPrefix(filepath.ToSlash(pattern), "./"), "/")
+	if !strings.Contains(pattern, "/") {
+		filename = path.Base(filename)
+	}
+	return globToRegexp(pattern).MatchString(filename)
+}
+
+func globToRegexp(pattern string) *regexp.Regexp {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					// **/ matches zero or more directories
+					i++
+					expr.WriteString("(.*/)?")
+					continue
+				}
+				expr.WriteString(".*")
+				continue
+			}
+			expr.WriteString("[^/]*")
+		case '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.MustCompile(expr.String())
+}
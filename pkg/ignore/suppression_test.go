@@ -0,0 +1,186 @@
+package ignore
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseSuppressions(t *testing.T) {
+	suppressions, err := ParseSuppressions([]byte(`{
+  "suppressions": [
+    {
+      "id": "AVD-AWS-0086",
+      "path": "modules/legacy/**",
+      "lines": "10-20",
+      "reason": "legacy bucket",
+      "owner": "storage-team",
+      "expires": "2999-01-01"
+    }
+  ]
+}`), ".defsecignore")
+	require.NoError(t, err)
+	require.Len(t, suppressions, 1)
+	assert.Equal(t, ".defsecignore:3", suppressions[0].Source)
+	assert.Equal(t, 10, suppressions[0].startLine)
+	assert.Equal(t, 20, suppressions[0].endLine)
+}
+
+func Test_ParseSuppressionsErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry string
+		err   string
+	}{
+		{
+			name:  "missing audit fields",
+			entry: `{id: AVD-AWS-0086, reason: legacy}`,
+			err:   "missing required field(s): owner, expires",
+		},
+		{
+			name:  "no criteria",
+			entry: `{reason: legacy, owner: me, expires: 2999-01-01}`,
+			err:   "at least one of id, path or resource is required",
+		},
+		{
+			name:  "invalid expiry",
+			entry: `{id: "*", reason: legacy, owner: me, expires: next year}`,
+			err:   "expires must be a date",
+		},
+		{
+			name:  "invalid lines",
+			entry: `{id: "*", lines: 20-10, reason: legacy, owner: me, expires: 2999-01-01}`,
+			err:   "invalid line range",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseSuppressions([]byte("suppressions:\n  - "+test.entry+"\n"), ".defsecignore")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), ".defsecignore:2")
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+}
+
+func Test_SuppressionMatches(t *testing.T) {
+	finding := Finding{
+		IDs:       []string{"AVD-AWS-0086", "aws-s3-block-public-acls"},
+		Filename:  "modules/legacy/s3/main.tf",
+		Resource:  "aws_s3_bucket.logs",
+		StartLine: 12,
+		EndLine:   18,
+	}
+
+	tests := []struct {
+		name        string
+		suppression string
+		matches     bool
+	}{
+		{name: "rule id", suppression: `id: AVD-AWS-0086`, matches: true},
+		{name: "rule id wildcard", suppression: `id: aws-s3-*`, matches: true},
+		{name: "other rule", suppression: `id: AVD-AWS-0087`},
+		{name: "directory glob", suppression: `path: modules/legacy/**`, matches: true},
+		{name: "nested glob", suppression: `path: "**/s3/*.tf"`, matches: true},
+		{name: "base name glob", suppression: `path: "*.tf"`, matches: true},
+		{name: "other directory", suppression: `path: "modules/new/**"`},
+		{name: "resource", suppression: `resource: aws_s3_bucket.logs`, matches: true},
+		{name: "resource wildcard", suppression: `resource: aws_s3_bucket.*`, matches: true},
+		{name: "overlapping lines", suppression: "id: \"*\"\n    lines: 15-30", matches: true},
+		{name: "other lines", suppression: "id: \"*\"\n    lines: \"20\""},
+		{name: "all criteria", suppression: "id: AVD-AWS-0086\n    path: modules/legacy/**\n    resource: aws_s3_bucket.logs", matches: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			suppressions, err := ParseSuppressions([]byte(`suppressions:
+  - `+test.suppression+`
+    reason: testing
+    owner: me
+    expires: 2999-01-01
+`), ".defsecignore")
+			require.NoError(t, err)
+			require.Len(t, suppressions, 1)
+			assert.Equal(t, test.matches, suppressions[0].Matches(finding))
+		})
+	}
+}
+
+func Test_SuppressionMatchesIndexedResource(t *testing.T) {
+	tests := []struct {
+		resource string
+		finding  string
+		matches  bool
+	}{
+		{resource: `aws_s3_bucket.b[0]`, finding: `aws_s3_bucket.b[0]`, matches: true},
+		{resource: `aws_s3_bucket.b[0]`, finding: `aws_s3_bucket.b[1]`},
+		{resource: `aws_s3_bucket.b[*]`, finding: `aws_s3_bucket.b[1]`, matches: true},
+		{resource: `aws_instance.web["a"]`, finding: `aws_instance.web["a"]`, matches: true},
+		{resource: `sas[?]`, finding: `sas[1]`, matches: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.resource, func(t *testing.T) {
+			suppressions, err := ParseSuppressions([]byte(`suppressions:
+  - resource: '`+test.resource+`'
+    reason: testing
+    owner: me
+    expires: 2999-01-01
+`), ".defsecignore")
+			require.NoError(t, err)
+			require.Len(t, suppressions, 1)
+			assert.Equal(t, test.matches, suppressions[0].Matches(Finding{Resource: test.finding}))
+		})
+	}
+}
+
+func Test_ExpiredSuppression(t *testing.T) {
+	suppressions, err := ParseSuppressions([]byte(`suppressions:
+  - id: "*"
+    reason: testing
+    owner: me
+    expires: 2000-01-01
+`), ".defsecignore")
+	require.NoError(t, err)
+	assert.True(t, suppressions[0].Expired())
+	assert.Nil(t, suppressions.Matching(Finding{IDs: []string{"AVD-AWS-0086"}}))
+}
+
+func Test_SuppressionExpiresAtTheEndOfTheDay(t *testing.T) {
+	suppressions, err := ParseSuppressions([]byte(`suppressions:
+  - id: "*"
+    reason: testing
+    owner: me
+    expires: 2026-10-18
+`), ".defsecignore")
+	require.NoError(t, err)
+	require.Len(t, suppressions, 1)
+
+	assert.False(t, suppressions[0].expiredAt(time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, suppressions[0].expiredAt(time.Date(2026, 10, 18, 23, 59, 59, 0, time.UTC)))
+	assert.True(t, suppressions[0].expiredAt(time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)))
+}
+
+func Test_LoadSuppressions(t *testing.T) {
+	empty := fstest.MapFS{"main.tf": &fstest.MapFile{}}
+
+	suppressions, err := LoadSuppressions(empty, "")
+	require.NoError(t, err)
+	assert.Empty(t, suppressions)
+
+	_, err = LoadSuppressions(empty, "missing.yaml")
+	assert.Error(t, err)
+
+	fs := fstest.MapFS{
+		".defsecignore": &fstest.MapFile{
+			Data: []byte("suppressions:\n  - {id: \"*\", reason: testing, owner: me, expires: 2999-01-01}\n"),
+		},
+	}
+	suppressions, err = LoadSuppressions(fs, "")
+	require.NoError(t, err)
+	assert.Len(t, suppressions, 1)
+}
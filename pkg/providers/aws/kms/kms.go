@@ -2,10 +2,12 @@ package kms
 
 import (
 	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers/aws/iam"
 )
 
 type KMS struct {
-	Keys []Key
+	Keys    []Key
+	Aliases []Alias
 }
 
 const (
@@ -16,4 +18,11 @@ type Key struct {
 	types.Metadata
 	Usage           types.StringValue
 	RotationEnabled types.BoolValue
+	Policies        []iam.Policy
+}
+
+type Alias struct {
+	types.Metadata
+	Name        types.StringValue
+	TargetKeyID types.StringValue
 }
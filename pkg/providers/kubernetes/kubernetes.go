@@ -5,7 +5,23 @@ import (
 )
 
 type Kubernetes struct {
-	NetworkPolicies []NetworkPolicy
+	NetworkPolicies     []NetworkPolicy
+	Workloads           []Workload
+	Roles               []Role
+	ClusterRoles        []Role
+	RoleBindings        []RoleBinding
+	ClusterRoleBindings []RoleBinding
+	Services            []Service
+	Ingresses           []IngressResource
+}
+
+// ObjectMeta is the metadata common to all Kubernetes objects
+type ObjectMeta struct {
+	types.Metadata
+	Name        types.StringValue
+	Namespace   types.StringValue
+	Labels      types.MapValue
+	Annotations types.MapValue
 }
 
 type NetworkPolicy struct {
@@ -0,0 +1,41 @@
+package kubernetes
+
+import (
+	"github.com/aquasecurity/defsec/internal/types"
+)
+
+// Role is a Role or ClusterRole
+type Role struct {
+	types.Metadata
+	Meta  ObjectMeta
+	Rules []PolicyRule
+}
+
+type PolicyRule struct {
+	types.Metadata
+	APIGroups     []types.StringValue
+	Resources     []types.StringValue
+	ResourceNames []types.StringValue
+	Verbs         []types.StringValue
+}
+
+// RoleBinding is a RoleBinding or ClusterRoleBinding
+type RoleBinding struct {
+	types.Metadata
+	Meta     ObjectMeta
+	RoleRef  RoleRef
+	Subjects []Subject
+}
+
+type RoleRef struct {
+	types.Metadata
+	Kind types.StringValue // "Role" or "ClusterRole"
+	Name types.StringValue
+}
+
+type Subject struct {
+	types.Metadata
+	Kind      types.StringValue // "User", "Group" or "ServiceAccount"
+	Name      types.StringValue
+	Namespace types.StringValue
+}
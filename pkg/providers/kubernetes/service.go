@@ -0,0 +1,58 @@
+package kubernetes
+
+import (
+	"github.com/aquasecurity/defsec/internal/types"
+)
+
+type Service struct {
+	types.Metadata
+	Meta                     ObjectMeta
+	Type                     types.StringValue
+	ExternalIPs              []types.StringValue
+	LoadBalancerSourceRanges []types.StringValue
+	Ports                    []ServicePort
+}
+
+const (
+	ServiceTypeClusterIP    = "ClusterIP"
+	ServiceTypeNodePort     = "NodePort"
+	ServiceTypeLoadBalancer = "LoadBalancer"
+	ServiceTypeExternalName = "ExternalName"
+)
+
+type ServicePort struct {
+	types.Metadata
+	Port       types.IntValue
+	TargetPort types.StringValue // e.g. "http" or "8080"
+	NodePort   types.IntValue
+	Protocol   types.StringValue
+}
+
+// IngressResource is an Ingress object, not to be confused with the ingress rules of a NetworkPolicy
+type IngressResource struct {
+	types.Metadata
+	Meta             ObjectMeta
+	IngressClassName types.StringValue
+	TLS              []IngressTLS
+	Rules            []IngressRule
+}
+
+type IngressTLS struct {
+	types.Metadata
+	Hosts      []types.StringValue
+	SecretName types.StringValue
+}
+
+type IngressRule struct {
+	types.Metadata
+	Host  types.StringValue
+	Paths []IngressPath
+}
+
+type IngressPath struct {
+	types.Metadata
+	Path        types.StringValue
+	PathType    types.StringValue
+	ServiceName types.StringValue
+	ServicePort types.StringValue // e.g. "http" or "80"
+}
@@ -0,0 +1,101 @@
+package kubernetes
+
+import (
+	"github.com/aquasecurity/defsec/internal/types"
+)
+
+// Workload is a Pod, or an object which creates pods from a template, e.g. a Deployment or CronJob
+type Workload struct {
+	types.Metadata
+	Kind     types.StringValue // e.g. "Pod" or "Deployment"
+	Meta     ObjectMeta
+	Template PodTemplate
+}
+
+type PodTemplate struct {
+	types.Metadata
+	Meta ObjectMeta
+	Spec PodSpec
+}
+
+type PodSpec struct {
+	types.Metadata
+	ServiceAccountName           types.StringValue
+	AutomountServiceAccountToken types.BoolValue
+	HostNetwork                  types.BoolValue
+	HostPID                      types.BoolValue
+	HostIPC                      types.BoolValue
+	SecurityContext              PodSecurityContext
+	Containers                   []Container
+	InitContainers               []Container
+	Volumes                      []Volume
+}
+
+type PodSecurityContext struct {
+	types.Metadata
+	RunAsUser          types.IntValue
+	RunAsGroup         types.IntValue
+	RunAsNonRoot       types.BoolValue
+	FSGroup            types.IntValue
+	SeccompProfileType types.StringValue
+}
+
+type Container struct {
+	types.Metadata
+	Name            types.StringValue
+	Image           types.StringValue
+	ImagePullPolicy types.StringValue
+	SecurityContext SecurityContext
+	Resources       Resources
+	Ports           []ContainerPort
+}
+
+type SecurityContext struct {
+	types.Metadata
+	Privileged               types.BoolValue
+	AllowPrivilegeEscalation types.BoolValue
+	ReadOnlyRootFilesystem   types.BoolValue
+	RunAsNonRoot             types.BoolValue
+	RunAsUser                types.IntValue
+	RunAsGroup               types.IntValue
+	SeccompProfileType       types.StringValue
+	Capabilities             Capabilities
+}
+
+type Capabilities struct {
+	types.Metadata
+	Add  []types.StringValue
+	Drop []types.StringValue
+}
+
+// Resources are the compute resources of a container, as quantities such as "500m" or "128Mi"
+type Resources struct {
+	types.Metadata
+	LimitsCPU      types.StringValue
+	LimitsMemory   types.StringValue
+	RequestsCPU    types.StringValue
+	RequestsMemory types.StringValue
+}
+
+type ContainerPort struct {
+	types.Metadata
+	ContainerPort types.IntValue
+	HostPort      types.IntValue
+	Protocol      types.StringValue
+}
+
+type Volume struct {
+	types.Metadata
+	Name     types.StringValue
+	Type     types.StringValue // the volume source, e.g. "hostPath", "emptyDir" or "secret"
+	HostPath types.StringValue
+}
+
+const (
+	VolumeTypeHostPath              = "hostPath"
+	VolumeTypeEmptyDir              = "emptyDir"
+	VolumeTypeSecret                = "secret"
+	VolumeTypeConfigMap             = "configMap"
+	VolumeTypePersistentVolumeClaim = "persistentVolumeClaim"
+	VolumeTypeProjected             = "projected"
+)
@@ -41,3 +41,11 @@ func OptionWithDataDirs(dirs ...string) func(s *Scanner) {
 		s.dataDirs = dirs
 	}
 }
+
+// OptionWithNativeInputsOnly - only pass the native inputs of the scanner, e.g. Kubernetes manifests, to policies
+// without input selectors, and not the defsec state scanned alongside them
+func OptionWithNativeInputsOnly() func(s *Scanner) {
+	return func(s *Scanner) {
+		s.nativeInputsOnly = true
+	}
+}
@@ -94,21 +94,36 @@ func parseCause(cause map[string]interface{}) regoResult {
 }
 
 func parseLineNumber(raw interface{}) int {
-	str := fmt.Sprintf("%s", raw)
+	str := fmt.Sprintf("%v", raw)
 	n, _ := strconv.Atoi(str)
 	return n
 }
 
-func (s *Scanner) convertResults(set rego.ResultSet, filepath string, namespace string, rule string, traces []string) scan.Results {
+// inputLocation returns the location of the input. Parsers which produce several inputs from one file, e.g. for each
+// document in a multi-document YAML file, record the lines of each input in its __defsec_metadata.
+func inputLocation(input Input) regoResult {
+	location := regoResult{
+		Filepath: input.Path,
+		Managed:  true,
+	}
+	if contents, ok := input.Contents.(map[string]interface{}); ok {
+		if metadata, ok := contents["__defsec_metadata"].(map[string]interface{}); ok {
+			location.StartLine = parseLineNumber(metadata["startline"])
+			location.EndLine = parseLineNumber(metadata["endline"])
+		}
+	}
+	return location
+}
+
+// convertResults converts the result set of a rule. Results without a location are given the location of the input.
+func (s *Scanner) convertResults(set rego.ResultSet, location regoResult, namespace string, rule string, traces []string) scan.Results {
 	var results scan.Results
 	for _, result := range set {
 		for _, expression := range result.Expressions {
 			values, ok := expression.Value.([]interface{})
 			if !ok {
 				regoResult := parseResult(expression.Value)
-				if regoResult.Filepath == "" && filepath != "" {
-					regoResult.Filepath = filepath
-				}
+				withInputLocation(regoResult, location)
 				if regoResult.Message == "" {
 					regoResult.Message = fmt.Sprintf("Rego policy rule: %s.%s", namespace, rule)
 				}
@@ -118,9 +133,7 @@ func (s *Scanner) convertResults(set rego.ResultSet, filepath string, namespace
 
 			for _, value := range values {
 				regoResult := parseResult(value)
-				if regoResult.Filepath == "" && filepath != "" {
-					regoResult.Filepath = filepath
-				}
+				withInputLocation(regoResult, location)
 				if regoResult.Message == "" {
 					regoResult.Message = fmt.Sprintf("Rego policy rule: %s.%s", namespace, rule)
 				}
@@ -131,6 +144,16 @@ func (s *Scanner) convertResults(set rego.ResultSet, filepath string, namespace
 	return results
 }
 
+func withInputLocation(result *regoResult, location regoResult) {
+	if result.Filepath == "" && location.Filepath != "" {
+		result.Filepath = location.Filepath
+	}
+	if result.Filepath == location.Filepath && result.StartLine == 0 && result.EndLine == 0 {
+		result.StartLine = location.StartLine
+		result.EndLine = location.EndLine
+	}
+}
+
 func (s *Scanner) embellishResultsWithRuleMetadata(results scan.Results, metadata StaticMetadata) scan.Results {
 	results.SetRule(metadata.ToRule())
 	return results
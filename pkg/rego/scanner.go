@@ -28,6 +28,8 @@ type Scanner struct {
 	debugWriter    io.Writer
 	traceWriter    io.Writer
 	retriever      *MetadataRetriever
+	// nativeInputsOnly keeps the defsec state from policies without selectors when native inputs are also scanned
+	nativeInputsOnly bool
 }
 
 type DynamicMetadata struct {
@@ -144,6 +146,8 @@ func (s *Scanner) ScanInput(ctx context.Context, inputs ...Input) (scan.Results,
 					filteredInputs = append(filteredInputs, in)
 				}
 			}
+		} else if s.nativeInputsOnly {
+			filteredInputs = unselectedInputs(inputs)
 		} else {
 			filteredInputs = make([]Input, len(inputs))
 			copy(filteredInputs, inputs)
@@ -170,6 +174,23 @@ func (s *Scanner) ScanInput(ctx context.Context, inputs ...Input) (scan.Results,
 	return results, nil
 }
 
+// unselectedInputs returns the inputs for policies without selectors when only native inputs are enabled. These
+// policies are written against the native input of the scanner, e.g. a Kubernetes manifest, so the defsec state is only
+// included when there is nothing else to scan.
+func unselectedInputs(inputs []Input) []Input {
+	var native []Input
+	for _, in := range inputs {
+		if in.Type != types.SourceDefsec {
+			native = append(native, in)
+		}
+	}
+	if len(native) == 0 {
+		native = make([]Input, len(inputs))
+		copy(native, inputs)
+	}
+	return native
+}
+
 func (s *Scanner) applyRule(ctx context.Context, namespace string, rule string, inputs []Input, combined bool) (scan.Results, error) {
 
 	// handle combined evaluations if possible
@@ -183,22 +204,16 @@ func (s *Scanner) applyRule(ctx context.Context, namespace string, rule string,
 		if ignored, err := s.isIgnored(ctx, namespace, rule, input); err != nil {
 			return nil, err
 		} else if ignored {
-			var result regoResult
-			result.Filepath = input.Path
-			result.Managed = true
-			results.AddIgnored(result)
+			results.AddIgnored(inputLocation(input))
 			continue
 		}
 		set, traces, err := s.runQuery(ctx, qualified, input.Contents, false)
 		if err != nil {
 			return nil, err
 		}
-		ruleResults := s.convertResults(set, input.Path, namespace, rule, traces)
+		ruleResults := s.convertResults(set, inputLocation(input), namespace, rule, traces)
 		if len(ruleResults) == 0 {
-			var result regoResult
-			result.Filepath = input.Path
-			result.Managed = true
-			results.AddPassed(result)
+			results.AddPassed(inputLocation(input))
 			continue
 		}
 		results = append(results, ruleResults...)
@@ -225,7 +240,7 @@ func (s *Scanner) applyRuleCombined(ctx context.Context, namespace string, rule
 	if err != nil {
 		return nil, err
 	}
-	return s.convertResults(set, "", namespace, rule, traces), nil
+	return s.convertResults(set, regoResult{}, namespace, rule, traces), nil
 }
 
 // severity is now set with metadata, so deny/warn/violation now behave the same way
@@ -6,6 +6,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/aquasecurity/defsec/internal/types"
 	"github.com/aquasecurity/defsec/pkg/severity"
 
 	"github.com/aquasecurity/defsec/test/testutil"
@@ -590,3 +591,94 @@ deny {
 
 	assert.Greater(t, len(results.GetFailed()[0].Traces()), 0)
 }
+
+func Test_RegoScanning_WithInputLines(t *testing.T) {
+	srcFS := testutil.CreateFS(t, map[string]string{
+		"policies/test.rego": `
+package defsec.test
+
+deny[msg] {
+    input.evil
+	msg := "oh no"
+}
+`,
+	})
+
+	scanner := NewScanner()
+	require.NoError(
+		t,
+		scanner.LoadPolicies(false, srcFS, []string{"policies"}, nil),
+	)
+
+	results, err := scanner.ScanInput(context.TODO(), Input{
+		Path: "/evil.lol",
+		Contents: map[string]interface{}{
+			"evil": true,
+			"__defsec_metadata": map[string]interface{}{
+				"startline": 10,
+				"endline":   20,
+			},
+		},
+		Type: "???",
+	}, Input{
+		Path: "/good.lol",
+		Contents: map[string]interface{}{
+			"evil": false,
+			"__defsec_metadata": map[string]interface{}{
+				"startline": 3,
+				"endline":   4,
+			},
+		},
+		Type: "???",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 1, len(results.GetFailed()))
+	failure := results.GetFailed()[0]
+	assert.Equal(t, "/evil.lol", failure.Range().GetFilename())
+	assert.Equal(t, 10, failure.Range().GetStartLine())
+	assert.Equal(t, 20, failure.Range().GetEndLine())
+
+	require.Equal(t, 1, len(results.GetPassed()))
+	assert.Equal(t, 3, results.GetPassed()[0].Range().GetStartLine())
+	assert.Equal(t, 4, results.GetPassed()[0].Range().GetEndLine())
+}
+
+func Test_RegoScanning_UnselectedPolicyInputs(t *testing.T) {
+	srcFS := testutil.CreateFS(t, map[string]string{
+		"policies/test.rego": `
+package defsec.test
+
+deny {
+    input.evil
+}
+`,
+	})
+
+	inputs := []Input{
+		{Path: "/manifest.yaml", Contents: map[string]interface{}{"evil": true}, Type: types.SourceKubernetes},
+		{Path: "/", Contents: map[string]interface{}{"evil": true}, Type: types.SourceDefsec},
+	}
+
+	tests := []struct {
+		name     string
+		options  []Option
+		inputs   []Input
+		expected int
+	}{
+		{name: "all inputs by default", inputs: inputs, expected: 2},
+		{name: "native inputs only", options: []Option{OptionWithNativeInputsOnly()}, inputs: inputs, expected: 1},
+		{name: "defsec state when it is the only input", options: []Option{OptionWithNativeInputsOnly()}, inputs: inputs[1:], expected: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scanner := NewScanner(test.options...)
+			require.NoError(t, scanner.LoadPolicies(false, srcFS, []string{"policies"}, nil))
+
+			results, err := scanner.ScanInput(context.TODO(), test.inputs...)
+			require.NoError(t, err)
+			assert.Len(t, results.GetFailed(), test.expected)
+		})
+	}
+}
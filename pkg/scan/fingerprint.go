@@ -0,0 +1,148 @@
+package scan
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/aquasecurity/defsec/internal/types"
+)
+
+// Fingerprint returns a deterministic identifier for the finding which does not depend on its line numbers. It is
+// computed from the rule ID, the filename, the logical ID of the resource, the path of the attribute within the
+// resource and a hash of the offending source lines, ignoring leading and trailing whitespace.
+func (r Result) Fingerprint() string {
+	ruleID := r.rule.AVDID
+	if ruleID == "" {
+		ruleID = r.rule.LongID()
+	}
+	resource, attribute := r.resourceAndAttribute()
+	snippet := sha256.Sum256([]byte(r.snippet()))
+
+	hash := sha256.New()
+	for _, part := range []string{ruleID, r.filename(), resource, attribute, hex.EncodeToString(snippet[:])} {
+		_, _ = hash.Write([]byte(part))
+		_, _ = hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// filename returns the normalised filename of the result. Absolute filenames are replaced by the filename within the
+// scanned filesystem, so the fingerprint does not depend on where the sources are checked out.
+func (r Result) filename() string {
+	rng := r.metadata.Range()
+	if rng == nil {
+		return ""
+	}
+	filename := rng.GetFilename()
+	if filepath.IsAbs(filename) {
+		filename = rng.GetLocalFilename()
+	}
+	if filename == "" {
+		return ""
+	}
+	return strings.TrimPrefix(path.Clean(filepath.ToSlash(filename)), "./")
+}
+
+// resourceAndAttribute returns the logical ID of the resource containing the result, and the path of the attribute
+// at fault relative to it, e.g. "aws_s3_bucket.logs" and "versioning.enabled"
+func (r Result) resourceAndAttribute() (string, string) {
+	root := r.metadata
+	for root.Parent() != nil {
+		root = *root.Parent()
+	}
+	resource := logicalID(root)
+	attribute := logicalID(r.metadata)
+	switch {
+	case attribute == resource:
+		attribute = ""
+	case strings.HasPrefix(attribute, resource+"."):
+		attribute = strings.TrimPrefix(attribute, resource+".")
+	}
+	return resource, attribute
+}
+
+var locationSuffix = regexp.MustCompile(`:\d+(-\d+)?$`)
+
+// logicalID returns the logical ID of the reference of the metadata. References which are only a location, e.g.
+// "deployment.yaml:1-20", are reduced to the filename.
+func logicalID(m types.Metadata) string {
+	if m.Reference() == nil {
+		return ""
+	}
+	id := m.Reference().LogicalID()
+	if m.Range() != nil && locationSuffix.MatchString(id) {
+		if trimmed := locationSuffix.ReplaceAllString(id, ""); trimmed == m.Range().GetFilename() || trimmed == m.Range().GetLocalFilename() {
+			return trimmed
+		}
+	}
+	return id
+}
+
+// snippet returns the source lines of the result with surrounding whitespace removed, or the annotation where the
+// source cannot be read
+func (r Result) snippet() string {
+	rng := r.metadata.Range()
+	if rng == nil || rng.GetFS() == nil || rng.GetStartLine() < 1 {
+		return r.annotation
+	}
+	lines, err := sourceLines(rng)
+	if err != nil {
+		return r.annotation
+	}
+	if rng.GetEndLine() > len(lines) || rng.GetStartLine() > rng.GetEndLine() {
+		return r.annotation
+	}
+	var snippet []string
+	for _, line := range lines[rng.GetStartLine()-1 : rng.GetEndLine()] {
+		snippet = append(snippet, strings.TrimSpace(line))
+	}
+	return strings.Join(snippet, "\n")
+}
+
+// sourceCacheSize is the number of files whose lines are kept between calls to Fingerprint
+const sourceCacheSize = 64
+
+// sourceCache holds the lines of recently fingerprinted files, as most files have several results. Files are keyed by
+// their size and modification time as well as their name, so that changes to a file are picked up.
+var sourceCache = struct {
+	sync.Mutex
+	files map[string][]string
+}{
+	files: make(map[string][]string),
+}
+
+func sourceLines(rng types.Range) ([]string, error) {
+	info, err := fs.Stat(rng.GetFS(), rng.GetLocalFilename())
+	if err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("%s:%s:%d:%d", rng.GetFSKey(), rng.GetLocalFilename(), info.Size(), info.ModTime().UnixNano())
+
+	sourceCache.Lock()
+	lines, ok := sourceCache.files[key]
+	sourceCache.Unlock()
+	if ok {
+		return lines, nil
+	}
+
+	content, err := fs.ReadFile(rng.GetFS(), rng.GetLocalFilename())
+	if err != nil {
+		return nil, err
+	}
+	lines = strings.Split(string(content), "\n")
+
+	sourceCache.Lock()
+	defer sourceCache.Unlock()
+	if len(sourceCache.files) >= sourceCacheSize {
+		sourceCache.files = make(map[string][]string)
+	}
+	sourceCache.files[key] = lines
+	return lines, nil
+}
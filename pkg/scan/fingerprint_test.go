@@ -0,0 +1,84 @@
+package scan
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+func resultAt(fsys fstest.MapFS, filename string, resourceLine int, attributeLine int, resource string) Result {
+	resourceMetadata := types.NewMetadata(
+		types.NewRange(filename, resourceLine, resourceLine+3, "", fsys),
+		types.NewNamedReference(resource),
+	)
+	attributeMetadata := types.NewMetadata(
+		types.NewRange(filename, attributeLine, attributeLine, "", fsys),
+		types.NewNamedReference(resource+".acl"),
+	).WithParent(resourceMetadata)
+
+	var results Results
+	results.Add("bucket is public", types.String("public-read", attributeMetadata))
+	results.SetRule(Rule{AVDID: "AVD-AWS-0092", Provider: providers.AWSProvider, Service: "s3", ShortCode: "no-public-acl"})
+	return results[0]
+}
+
+func Test_Fingerprint(t *testing.T) {
+	original := fstest.MapFS{"main.tf": &fstest.MapFile{Data: []byte(`resource "aws_s3_bucket" "logs" {
+  acl = "public-read"
+}
+`)}}
+	shifted := fstest.MapFS{"main.tf": &fstest.MapFile{Data: []byte(`
+# logs are shared with the analytics team
+resource "aws_s3_bucket" "logs" {
+    acl = "public-read"
+}
+`)}}
+	changed := fstest.MapFS{"main.tf": &fstest.MapFile{Data: []byte(`resource "aws_s3_bucket" "logs" {
+  acl = "public-read-write"
+}
+`)}}
+
+	fingerprint := resultAt(original, "main.tf", 1, 2, "aws_s3_bucket.logs").Fingerprint()
+	assert.Len(t, fingerprint, 64)
+	assert.Equal(t, fingerprint, resultAt(shifted, "main.tf", 3, 4, "aws_s3_bucket.logs").Fingerprint())
+	assert.NotEqual(t, fingerprint, resultAt(changed, "main.tf", 1, 2, "aws_s3_bucket.logs").Fingerprint())
+	assert.NotEqual(t, fingerprint, resultAt(original, "main.tf", 1, 2, "aws_s3_bucket.data").Fingerprint())
+}
+
+func Test_FingerprintIncludesFilename(t *testing.T) {
+	source := &fstest.MapFile{Data: []byte(`resource "aws_s3_bucket" "logs" {
+  acl = "public-read"
+}
+`)}
+	fsys := fstest.MapFS{"a/main.tf": source, "b/main.tf": source}
+
+	a := resultAt(fsys, "a/main.tf", 1, 2, "aws_s3_bucket.logs").Fingerprint()
+	b := resultAt(fsys, "b/main.tf", 1, 2, "aws_s3_bucket.logs").Fingerprint()
+	assert.NotEqual(t, a, b)
+}
+
+func Test_FingerprintReadsChangedFiles(t *testing.T) {
+	fsys := fstest.MapFS{"main.tf": &fstest.MapFile{Data: []byte(`resource "aws_s3_bucket" "logs" {
+  acl = "public-read"
+}
+`)}}
+	before := resultAt(fsys, "main.tf", 1, 2, "aws_s3_bucket.logs").Fingerprint()
+	fsys["main.tf"].Data = []byte(`resource "aws_s3_bucket" "logs" {
+  acl = "authenticated-read"
+}
+`)
+	assert.NotEqual(t, before, resultAt(fsys, "main.tf", 1, 2, "aws_s3_bucket.logs").Fingerprint())
+}
+
+func Test_FingerprintResourceAndAttribute(t *testing.T) {
+	result := resultAt(nil, "main.tf", 1, 2, "aws_s3_bucket.logs")
+	resource, attribute := result.resourceAndAttribute()
+	assert.Equal(t, "aws_s3_bucket.logs", resource)
+	assert.Equal(t, "acl", attribute)
+
+	located := resultAt(nil, "pod.yaml", 1, 2, "pod.yaml:1-4")
+	assert.Equal(t, "pod.yaml", logicalID(*located.Metadata().Parent()))
+}
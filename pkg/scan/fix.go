@@ -0,0 +1,32 @@
+package scan
+
+// FixAction is the kind of change made by a FixOperation
+type FixAction string
+
+const (
+	// FixSetAttribute sets an attribute to the expression in Value, creating any missing blocks on its path
+	FixSetAttribute FixAction = "set-attribute"
+	// FixAddBlock adds a block with the content in Body, unless a block of that type already exists
+	FixAddBlock FixAction = "add-block"
+	// FixRemoveAttribute removes an attribute, if it exists
+	FixRemoveAttribute FixAction = "remove-attribute"
+)
+
+// FixOperation is a single change to the resource at fault
+type FixOperation struct {
+	Action FixAction `json:"action"`
+	// Path is the dot separated path of the attribute or block within the resource, e.g. "metadata_options.http_tokens"
+	Path string `json:"path"`
+	// Value is the source of the expression for FixSetAttribute, e.g. `true` or `"required"`
+	Value string `json:"value,omitempty"`
+	// Body is the source of the content of the block for FixAddBlock
+	Body string `json:"body,omitempty"`
+}
+
+// Fix is a structured remediation for a finding, which can be applied to the source automatically. Fixes are currently
+// only applied to Terraform.
+type Fix struct {
+	// ResourceType restricts the fix to resources of the given type, e.g. aws_s3_bucket
+	ResourceType string         `json:"resource_type,omitempty"`
+	Operations   []FixOperation `json:"operations"`
+}
@@ -1,25 +1,28 @@
 package scan
 
 import (
+	"github.com/aquasecurity/defsec/pkg/ignore"
 	"github.com/aquasecurity/defsec/pkg/providers"
 	"github.com/aquasecurity/defsec/pkg/severity"
 )
 
 type FlatResult struct {
-	RuleID          string             `json:"rule_id"`
-	LongID          string             `json:"long_id"`
-	RuleSummary     string             `json:"rule_description"`
-	RuleProvider    providers.Provider `json:"rule_provider"`
-	RuleService     string             `json:"rule_service"`
-	Impact          string             `json:"impact"`
-	Resolution      string             `json:"resolution"`
-	Links           []string           `json:"links"`
-	Description     string             `json:"description"`
-	RangeAnnotation string             `json:"-"`
-	Severity        severity.Severity  `json:"severity"`
-	Status          Status             `json:"status"`
-	Resource        string             `json:"resource"`
-	Location        FlatRange          `json:"location"`
+	RuleID          string              `json:"rule_id"`
+	LongID          string              `json:"long_id"`
+	RuleSummary     string              `json:"rule_description"`
+	RuleProvider    providers.Provider  `json:"rule_provider"`
+	RuleService     string              `json:"rule_service"`
+	Impact          string              `json:"impact"`
+	Resolution      string              `json:"resolution"`
+	Links           []string            `json:"links"`
+	Description     string              `json:"description"`
+	RangeAnnotation string              `json:"-"`
+	Severity        severity.Severity   `json:"severity"`
+	Status          Status              `json:"status"`
+	Resource        string              `json:"resource"`
+	Location        FlatRange           `json:"location"`
+	Fingerprint     string              `json:"fingerprint"`
+	Suppression     *ignore.Suppression `json:"suppression,omitempty"`
 }
 
 type FlatRange struct {
@@ -69,5 +72,7 @@ func (r *Result) Flatten() FlatResult {
 			StartLine: rng.GetStartLine(),
 			EndLine:   rng.GetEndLine(),
 		},
+		Fingerprint: r.Fingerprint(),
+		Suppression: r.suppression,
 	}
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/aquasecurity/defsec/internal/types"
 
+	"github.com/aquasecurity/defsec/pkg/ignore"
 	"github.com/aquasecurity/defsec/pkg/severity"
 )
 
@@ -17,6 +18,7 @@ const (
 	StatusFailed Status = iota
 	StatusPassed
 	StatusIgnored
+	StatusStaleIgnore
 )
 
 type Result struct {
@@ -30,6 +32,9 @@ type Result struct {
 	regoRule         string
 	warning          bool
 	traces           []string
+	suppression      *ignore.Suppression
+	ignoredBy        *ignore.Directive
+	staleIgnore      *ignore.StaleDirective
 }
 
 func (r Result) RegoNamespace() string {
@@ -103,6 +108,16 @@ func (r Result) Traces() []string {
 	return r.traces
 }
 
+// IgnoredBy returns the inline ignore directive which caused the result to be ignored, if any
+func (r Result) IgnoredBy() *ignore.Directive {
+	return r.ignoredBy
+}
+
+// Suppression returns the entry of a suppression file which caused the result to be ignored, if any
+func (r Result) Suppression() *ignore.Suppression {
+	return r.suppression
+}
+
 type Results []Result
 
 type MetadataProvider interface {
@@ -238,6 +253,52 @@ func (r *Results) SetSourceAndFilesystem(source string, f fs.FS) {
 	}
 }
 
+// ApplyIgnores marks failed results which are covered by an inline ignore directive as ignored, and returns the
+// directives which ignored at least one result
+func (r *Results) ApplyIgnores(directives ignore.Directives) (used ignore.Directives) {
+	if len(directives) == 0 {
+		return nil
+	}
+	for i := range *r {
+		result := (*r)[i]
+		if result.Status() != StatusFailed {
+			continue
+		}
+		rule := result.Rule()
+		if directive := directives.Covering(result.Metadata(), rule.AVDID, rule.LongID(), rule.LegacyID); directive != nil {
+			(*r)[i].OverrideStatus(StatusIgnored)
+			(*r)[i].ignoredBy = directive
+			used = append(used, *directive)
+		}
+	}
+	return used
+}
+
+// ApplySuppressions marks failed results which match an entry of a suppression file as ignored, and records the entry
+func (r *Results) ApplySuppressions(suppressions ignore.Suppressions) {
+	if len(suppressions) == 0 {
+		return
+	}
+	for i := range *r {
+		result := (*r)[i]
+		if result.Status() != StatusFailed {
+			continue
+		}
+		rule := result.Rule()
+		flat := result.Flatten()
+		if suppression := suppressions.Matching(ignore.Finding{
+			IDs:       []string{rule.AVDID, rule.LongID(), rule.LegacyID},
+			Filename:  flat.Location.Filename,
+			Resource:  flat.Resource,
+			StartLine: flat.Location.StartLine,
+			EndLine:   flat.Location.EndLine,
+		}); suppression != nil {
+			(*r)[i].OverrideStatus(StatusIgnored)
+			(*r)[i].suppression = suppression
+		}
+	}
+}
+
 func rawToString(raw interface{}) string {
 	if raw == nil {
 		return ""
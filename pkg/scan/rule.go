@@ -25,6 +25,7 @@ type EngineMetadata struct {
 	BadExamples         []string `json:"bad_examples,omitempty"`
 	RemediationMarkdown string   `json:"remediation_markdown,omitempty"`
 	Links               []string `json:"links,omitempty"`
+	Fixes               []Fix    `json:"fixes,omitempty"`
 }
 
 type CustomChecks struct {
@@ -56,6 +57,11 @@ type Rule struct {
 	RegoPackage    string             `json:"-"`
 }
 
+// IDs returns the AVD ID, long ID and legacy ID of the rule
+func (r Rule) IDs() []string {
+	return []string{r.AVDID, r.LongID(), r.LegacyID}
+}
+
 func (r Rule) LongID() string {
 	return strings.ToLower(fmt.Sprintf("%s-%s-%s", r.Provider, r.Service, r.ShortCode))
 }
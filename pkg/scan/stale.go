@@ -0,0 +1,91 @@
+package scan
+
+import (
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/ignore"
+	"github.com/aquasecurity/defsec/pkg/providers"
+	"github.com/aquasecurity/defsec/pkg/severity"
+)
+
+// StaleIgnoreRule is the rule of results which report stale ignore directives
+var StaleIgnoreRule = Rule{
+	AVDID:       "AVD-GEN-0001",
+	ShortCode:   "no-stale-ignores",
+	Summary:     "Ignore directives should not be expired, refer to unknown rules or be unused",
+	Explanation: "Ignore directives which are expired, refer to a rule which does not exist or no longer match any result hide nothing, and make it harder to review which findings are really being ignored.",
+	Impact:      "Stale ignore directives accumulate and may silently hide new findings",
+	Resolution:  "Remove the directive, or update its rule ID or expiry date",
+	Provider:    providers.GeneralProvider,
+	Service:     "ignores",
+	Severity:    severity.Low,
+}
+
+// StaleIgnore returns the directive reported by a result with StatusStaleIgnore
+func (r Result) StaleIgnore() *ignore.StaleDirective {
+	return r.staleIgnore
+}
+
+func (r *Results) GetStaleIgnores() Results {
+	return r.filterStatus(StatusStaleIgnore)
+}
+
+// AddStaleIgnores adds a result for each directive which is expired, did not ignore a result (i.e. is not in used), or
+// whose rule ID matches neither the rules of the existing results nor the given known IDs
+func (r *Results) AddStaleIgnores(directives ignore.Directives, used ignore.Directives, knownIDs ...string) {
+	if len(directives) == 0 {
+		return
+	}
+	for _, result := range *r {
+		if result.Status() != StatusStaleIgnore {
+			knownIDs = append(knownIDs, result.Rule().IDs()...)
+		}
+	}
+	for _, directive := range directives.Stale(used, knownIDs) {
+		stale := directive
+		if stale.Range == nil {
+			continue
+		}
+		*r = append(*r, Result{
+			rule:        StaleIgnoreRule,
+			description: stale.Description(),
+			status:      StatusStaleIgnore,
+			metadata:    types.NewMetadata(stale.Range, types.NewNamedReference(stale.RuleID)),
+			staleIgnore: &stale,
+		})
+	}
+}
+
+// MergeStaleIgnores combines the stale ignore results of scanners which read the same files, e.g. the YAML and
+// Kubernetes scanners. A directive is dropped if any result was ignored by it, and is only reported as referring to an
+// unknown rule if every scanner reported it as such.
+func (r Results) MergeStaleIgnores() Results {
+	var used ignore.Directives
+	for _, result := range r {
+		if result.IgnoredBy() != nil {
+			used = append(used, *result.IgnoredBy())
+		}
+	}
+
+	var merged Results
+	seen := make(map[string]int)
+	for _, result := range r {
+		stale := result.StaleIgnore()
+		if stale == nil {
+			merged = append(merged, result)
+			continue
+		}
+		if stale.Reason != ignore.StaleExpired && used.Contains(stale.Directive) {
+			continue
+		}
+		key := stale.Key()
+		if index, ok := seen[key]; ok {
+			if stale.Reason == ignore.StaleUnused && merged[index].staleIgnore.Reason == ignore.StaleUnknownRule {
+				merged[index] = result
+			}
+			continue
+		}
+		seen[key] = len(merged)
+		merged = append(merged, result)
+	}
+	return merged
+}
@@ -0,0 +1,82 @@
+package arm
+
+import (
+	"io"
+
+	"github.com/aquasecurity/defsec/pkg/ignore"
+	"github.com/aquasecurity/defsec/pkg/scanners/azure/arm/parser"
+)
+
+// Option - scanner options for passing arguments into the scanner
+type Option func(s *Scanner)
+
+// OptionWithDebug - pass the scanner an io.Writer to log debug messages to
+func OptionWithDebug(debugWriter io.Writer) Option {
+	return func(s *Scanner) {
+		s.debugWriter = debugWriter
+		s.parserOptions = append(s.parserOptions, parser.OptionWithDebugWriter(debugWriter))
+	}
+}
+
+// OptionIncludePassed - tell the scanner to include results for passes checks
+func OptionIncludePassed() Option {
+	return func(s *Scanner) {
+		s.includePassed = true
+	}
+}
+
+// OptionWithExcludedIDs - tell the scanner to exclude the provided IDs
+func OptionWithExcludedIDs(excludedIDs []string) Option {
+	return func(s *Scanner) {
+		s.excludedRuleIDs = excludedIDs
+	}
+}
+
+// OptionWithPolicyDirs - location of rego policy directories - policies are loaded recursively
+func OptionWithPolicyDirs(dirs ...string) Option {
+	return func(s *Scanner) {
+		s.policyDirs = dirs
+	}
+}
+
+// OptionWithDataDirs - location of rego data directories
+func OptionWithDataDirs(dirs ...string) Option {
+	return func(s *Scanner) {
+		s.dataDirs = dirs
+	}
+}
+
+// OptionWithPolicyNamespaces - namespaces which indicate rego policies containing enforced rules
+func OptionWithPolicyNamespaces(namespaces ...string) Option {
+	return func(s *Scanner) {
+		s.policyNamespaces = namespaces
+	}
+}
+
+func OptionWithTrace(w io.Writer) Option {
+	return func(s *Scanner) {
+		s.traceWriter = w
+	}
+}
+
+// OptionWithParserOptions - options to pass to the ARM template parser, e.g. to set the deployment scope
+func OptionWithParserOptions(options ...parser.Option) Option {
+	return func(s *Scanner) {
+		s.parserOptions = append(s.parserOptions, options...)
+	}
+}
+
+// OptionWithSuppressions - ignore results matching entries of a suppression file, e.g. .defsecignore
+func OptionWithSuppressions(suppressions ignore.Suppressions) Option {
+	return func(s *Scanner) {
+		s.suppressions = suppressions
+	}
+}
+
+// OptionReportStaleIgnores - add a result for each inline ignore directive which is expired, refers to an unknown rule
+// or did not ignore anything
+func OptionReportStaleIgnores(report bool) Option {
+	return func(s *Scanner) {
+		s.reportStale = report
+	}
+}
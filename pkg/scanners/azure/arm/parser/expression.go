@@ -0,0 +1,328 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/azure/functions"
+)
+
+// isExpression returns true if the string is a template expression, e.g. "[parameters('name')]". Strings which
+// start with "[[" are escaped literals.
+func isExpression(str string) bool {
+	str = strings.TrimSpace(str)
+	return len(str) >= 2 && strings.HasPrefix(str, "[") && strings.HasSuffix(str, "]") && !strings.HasPrefix(str, "[[")
+}
+
+// unescape removes the escaping from a literal string which starts with "[["
+func unescape(str string) string {
+	if strings.HasPrefix(strings.TrimSpace(str), "[[") {
+		return strings.Replace(str, "[[", "[", 1)
+	}
+	return str
+}
+
+type expression interface {
+	evaluate(dd functions.DeploymentData) (interface{}, error)
+}
+
+type literalExpr struct {
+	value interface{}
+}
+
+func (e literalExpr) evaluate(_ functions.DeploymentData) (interface{}, error) {
+	return e.value, nil
+}
+
+type callExpr struct {
+	name string
+	args []expression
+}
+
+func (e callExpr) evaluate(dd functions.DeploymentData) (interface{}, error) {
+	var args []interface{}
+	for _, arg := range e.args {
+		value, err := arg.evaluate(dd)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, value)
+	}
+	return functions.Evaluate(dd, e.name, args...)
+}
+
+type propertyExpr struct {
+	target expression
+	name   string
+}
+
+func (e propertyExpr) evaluate(dd functions.DeploymentData) (interface{}, error) {
+	target, err := e.target.evaluate(dd)
+	if err != nil {
+		return nil, err
+	}
+	return lookup(target, e.name), nil
+}
+
+type indexExpr struct {
+	target expression
+	index  expression
+}
+
+func (e indexExpr) evaluate(dd functions.DeploymentData) (interface{}, error) {
+	target, err := e.target.evaluate(dd)
+	if err != nil {
+		return nil, err
+	}
+	index, err := e.index.evaluate(dd)
+	if err != nil {
+		return nil, err
+	}
+	if list, ok := target.([]interface{}); ok {
+		var i int
+		switch typed := index.(type) {
+		case int64:
+			i = int(typed)
+		case float64:
+			i = int(typed)
+		default:
+			return functions.Unresolvable{}, nil
+		}
+		if i < 0 || i >= len(list) {
+			return nil, fmt.Errorf("index %d is out of range", i)
+		}
+		return list[i], nil
+	}
+	if key, ok := index.(string); ok {
+		return lookup(target, key), nil
+	}
+	return functions.Unresolvable{}, nil
+}
+
+// lookup returns the (case-insensitive) property of an object, or Unresolvable if it cannot be found
+func lookup(target interface{}, key string) interface{} {
+	obj, ok := target.(map[string]interface{})
+	if !ok {
+		return functions.Unresolvable{}
+	}
+	if value, ok := obj[key]; ok {
+		return value
+	}
+	for name, value := range obj {
+		if strings.EqualFold(name, key) {
+			return value
+		}
+	}
+	return functions.Unresolvable{}
+}
+
+type tokenType int
+
+const (
+	tokenIdent tokenType = iota
+	tokenString
+	tokenNumber
+	tokenOpenParen
+	tokenCloseParen
+	tokenOpenBracket
+	tokenCloseBracket
+	tokenComma
+	tokenDot
+)
+
+type token struct {
+	kind  tokenType
+	value string
+}
+
+func lex(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenOpenParen})
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenCloseParen})
+		case r == '[':
+			tokens = append(tokens, token{kind: tokenOpenBracket})
+		case r == ']':
+			tokens = append(tokens, token{kind: tokenCloseBracket})
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma})
+		case r == '.':
+			tokens = append(tokens, token{kind: tokenDot})
+		case r == '\'':
+			var sb strings.Builder
+			closed := false
+			for i++; i < len(runes); i++ {
+				if runes[i] == '\'' {
+					// a doubled single quote is an escaped single quote
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						sb.WriteRune('\'')
+						i++
+						continue
+					}
+					closed = true
+					break
+				}
+				sb.WriteRune(runes[i])
+			}
+			if !closed {
+				return nil, fmt.Errorf("unterminated string in expression '%s'", input)
+			}
+			tokens = append(tokens, token{kind: tokenString, value: sb.String()})
+		case unicode.IsDigit(r) || r == '-':
+			start := i
+			for i+1 < len(runes) && (unicode.IsDigit(runes[i+1]) || runes[i+1] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, value: string(runes[start : i+1])})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i+1 < len(runes) && (unicode.IsLetter(runes[i+1]) || unicode.IsDigit(runes[i+1]) || runes[i+1] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, value: string(runes[start : i+1])})
+		default:
+			return nil, fmt.Errorf("unexpected character '%c' in expression '%s'", r, input)
+		}
+	}
+	return tokens, nil
+}
+
+type expressionParser struct {
+	tokens   []token
+	position int
+}
+
+// parseExpression parses a template expression, which must include the surrounding square brackets
+func parseExpression(input string) (expression, error) {
+	input = strings.TrimSpace(input)
+	tokens, err := lex(input[1 : len(input)-1])
+	if err != nil {
+		return nil, err
+	}
+	p := &expressionParser{tokens: tokens}
+	expr, err := p.parse()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse expression '%s': %w", input, err)
+	}
+	if p.position < len(p.tokens) {
+		return nil, fmt.Errorf("failed to parse expression '%s': unexpected trailing tokens", input)
+	}
+	return expr, nil
+}
+
+func (p *expressionParser) peek() *token {
+	if p.position >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.position]
+}
+
+func (p *expressionParser) next() (token, error) {
+	if p.position >= len(p.tokens) {
+		return token{}, fmt.Errorf("unexpected end of expression")
+	}
+	t := p.tokens[p.position]
+	p.position++
+	return t, nil
+}
+
+func (p *expressionParser) expect(kind tokenType) (token, error) {
+	t, err := p.next()
+	if err != nil {
+		return t, err
+	}
+	if t.kind != kind {
+		return t, fmt.Errorf("unexpected token '%s'", t.value)
+	}
+	return t, nil
+}
+
+func (p *expressionParser) parse() (expression, error) {
+	expr, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t == nil {
+			return expr, nil
+		}
+		switch t.kind {
+		case tokenDot:
+			p.position++
+			name, err := p.expect(tokenIdent)
+			if err != nil {
+				return nil, err
+			}
+			expr = propertyExpr{target: expr, name: name.value}
+		case tokenOpenBracket:
+			p.position++
+			index, err := p.parse()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect(tokenCloseBracket); err != nil {
+				return nil, err
+			}
+			expr = indexExpr{target: expr, index: index}
+		default:
+			return expr, nil
+		}
+	}
+}
+
+func (p *expressionParser) parsePrimary() (expression, error) {
+	t, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	switch t.kind {
+	case tokenString:
+		return literalExpr{value: t.value}, nil
+	case tokenNumber:
+		if i, err := strconv.ParseInt(t.value, 10, 64); err == nil {
+			return literalExpr{value: i}, nil
+		}
+		f, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s'", t.value)
+		}
+		return literalExpr{value: f}, nil
+	case tokenIdent:
+		if _, err := p.expect(tokenOpenParen); err != nil {
+			return nil, fmt.Errorf("expected '(' after function name '%s'", t.value)
+		}
+		call := callExpr{name: t.value}
+		if next := p.peek(); next != nil && next.kind == tokenCloseParen {
+			p.position++
+			return call, nil
+		}
+		for {
+			arg, err := p.parse()
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+			sep, err := p.next()
+			if err != nil {
+				return nil, err
+			}
+			if sep.kind == tokenCloseParen {
+				return call, nil
+			}
+			if sep.kind != tokenComma {
+				return nil, fmt.Errorf("expected ',' or ')' in call to '%s'", t.value)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unexpected token '%s'", t.value)
+	}
+}
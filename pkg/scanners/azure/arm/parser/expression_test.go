@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/azure/functions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDeploymentData struct {
+	parameters map[string]interface{}
+}
+
+func (f fakeDeploymentData) GetParameter(name string) interface{} {
+	return f.parameters[name]
+}
+
+func (f fakeDeploymentData) GetVariable(_ string) interface{} {
+	return functions.Unresolvable{}
+}
+
+func (f fakeDeploymentData) GetScope() functions.Scope {
+	return functions.DefaultScope
+}
+
+func Test_Expressions(t *testing.T) {
+	dd := fakeDeploymentData{
+		parameters: map[string]interface{}{
+			"config": map[string]interface{}{
+				"Names": []interface{}{"a", "b"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected interface{}
+	}{
+		{
+			name:     "string literal with escaped quote",
+			input:    "['it''s']",
+			expected: "it's",
+		},
+		{
+			name:     "nested calls",
+			input:    "[concat('a', toUpper('b'), string(1))]",
+			expected: "aB1",
+		},
+		{
+			name:     "format",
+			input:    "[format('{0}-{1}', 'web', 2)]",
+			expected: "web-2",
+		},
+		{
+			name:     "property and index access",
+			input:    "[parameters('config').names[1]]",
+			expected: "b",
+		},
+		{
+			name:     "unknown function",
+			input:    "[reference('x').primaryEndpoints.blob]",
+			expected: functions.Unresolvable{},
+		},
+		{
+			name:     "if",
+			input:    "[if(equals(length(parameters('config').names), 2), 'two', 'other')]",
+			expected: "two",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.True(t, isExpression(test.input))
+			expr, err := parseExpression(test.input)
+			require.NoError(t, err)
+			actual, err := expr.evaluate(dd)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func Test_InvalidExpression(t *testing.T) {
+	_, err := parseExpression("[concat('a'")
+	assert.Error(t, err)
+	assert.False(t, isExpression("[[literal]"))
+}
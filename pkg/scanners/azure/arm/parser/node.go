@@ -0,0 +1,79 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/liamg/jfather"
+)
+
+// node is a JSON value from a template, along with the lines it was defined on
+type node struct {
+	kind      jfather.Kind
+	raw       interface{}
+	keys      []string
+	props     map[string]*node
+	items     []*node
+	startLine int
+	endLine   int
+}
+
+func (n *node) UnmarshalJSONWithMetadata(jn jfather.Node) error {
+	n.kind = jn.Kind()
+	n.startLine = jn.Range().Start.Line
+	n.endLine = jn.Range().End.Line
+	switch n.kind {
+	case jfather.KindObject:
+		n.props = make(map[string]*node)
+		content := jn.Content()
+		for i := 0; i+1 < len(content); i += 2 {
+			var key string
+			if err := content[i].Decode(&key); err != nil {
+				return err
+			}
+			child := &node{}
+			if err := child.UnmarshalJSONWithMetadata(content[i+1]); err != nil {
+				return err
+			}
+			n.keys = append(n.keys, key)
+			n.props[key] = child
+		}
+	case jfather.KindArray:
+		for _, item := range jn.Content() {
+			child := &node{}
+			if err := child.UnmarshalJSONWithMetadata(item); err != nil {
+				return err
+			}
+			n.items = append(n.items, child)
+		}
+	case jfather.KindNull:
+	default:
+		return jn.Decode(&n.raw)
+	}
+	return nil
+}
+
+// get returns the property with the given (case-insensitive) name, or nil
+func (n *node) get(key string) *node {
+	if n == nil || n.kind != jfather.KindObject {
+		return nil
+	}
+	if child, ok := n.props[key]; ok {
+		return child
+	}
+	for name, child := range n.props {
+		if strings.EqualFold(name, key) {
+			return child
+		}
+	}
+	return nil
+}
+
+func (n *node) asString() string {
+	if n == nil {
+		return ""
+	}
+	if str, ok := n.raw.(string); ok {
+		return str
+	}
+	return ""
+}
@@ -0,0 +1,126 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/azure"
+	"github.com/aquasecurity/defsec/pkg/scanners/azure/functions"
+	"github.com/liamg/jfather"
+)
+
+type Parser struct {
+	debugWriter io.Writer
+	scope       functions.Scope
+}
+
+type Option func(p *Parser)
+
+func OptionWithDebugWriter(w io.Writer) Option {
+	return func(p *Parser) {
+		p.debugWriter = w
+	}
+}
+
+// OptionWithScope sets the subscription, resource group etc. used when evaluating functions such as resourceGroup()
+func OptionWithScope(scope functions.Scope) Option {
+	return func(p *Parser) {
+		p.scope = scope
+	}
+}
+
+// New creates a new parser
+func New(options ...Option) *Parser {
+	p := &Parser{
+		scope: functions.DefaultScope,
+	}
+	for _, option := range options {
+		option(p)
+	}
+	return p
+}
+
+func (p *Parser) debug(format string, args ...interface{}) {
+	if p.debugWriter == nil {
+		return
+	}
+	prefix := "[debug:parse:arm] "
+	_, _ = p.debugWriter.Write([]byte(fmt.Sprintf(prefix+format+"\n", args...)))
+}
+
+// ParseFS parses all ARM templates found in the given directory. JSON files which are not templates are skipped.
+func (p *Parser) ParseFS(ctx context.Context, target fs.FS, dir string) ([]azure.Deployment, error) {
+	var deployments []azure.Deployment
+	if err := fs.WalkDir(target, filepath.ToSlash(dir), func(path string, entry fs.DirEntry, err error) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !p.Required(path) {
+			return nil
+		}
+		root, err := p.parseNode(target, path)
+		if err != nil {
+			p.debug("Failed to parse '%s': %s", path, err)
+			return nil
+		}
+		if !isTemplate(root) {
+			p.debug("Not an ARM template, skipping %s", path)
+			return nil
+		}
+		deployments = append(deployments, p.convert(root, target, path))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return deployments, nil
+}
+
+// ParseFile parses the ARM template at the given path
+func (p *Parser) ParseFile(_ context.Context, target fs.FS, path string) (*azure.Deployment, error) {
+	root, err := p.parseNode(target, path)
+	if err != nil {
+		return nil, err
+	}
+	if !isTemplate(root) {
+		return nil, fmt.Errorf("'%s' is not an ARM template", path)
+	}
+	deployment := p.convert(root, target, path)
+	return &deployment, nil
+}
+
+// Required returns true if the file at the given path could be an ARM template
+func (p *Parser) Required(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".json")
+}
+
+func (p *Parser) parseNode(target fs.FS, path string) (*node, error) {
+	f, err := target.Open(filepath.ToSlash(path))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	var root node
+	if err := jfather.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	return &root, nil
+}
+
+func (p *Parser) convert(root *node, target fs.FS, path string) azure.Deployment {
+	p.debug("Parsing ARM template %s", path)
+	return newTemplate(root, path, target, p.scope, p.debug).toDeployment()
+}
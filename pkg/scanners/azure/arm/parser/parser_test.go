@@ -0,0 +1,195 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/azure"
+	"github.com/aquasecurity/defsec/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parse(t *testing.T, template string) azure.Deployment {
+	fs := testutil.CreateFS(t, map[string]string{
+		"template.json": template,
+	})
+	deployment, err := New().ParseFile(context.TODO(), fs, "template.json")
+	require.NoError(t, err)
+	return *deployment
+}
+
+func Test_ParameterAndVariableResolution(t *testing.T) {
+	deployment := parse(t, `{
+  "$schema": "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#",
+  "parameters": {
+    "name": { "type": "string", "defaultValue": "web" },
+    "secret": { "type": "securestring" }
+  },
+  "variables": {
+    "siteName": "[concat(parameters('name'), '-', variables('suffix'))]",
+    "suffix": "[toLower('PROD')]"
+  },
+  "resources": [
+    {
+      "type": "Microsoft.Web/sites",
+      "name": "[variables('siteName')]",
+      "properties": {
+        "password": "[parameters('secret')]",
+        "literal": "[[not an expression]",
+        "serverFarmId": "[resourceId('Microsoft.Web/serverfarms', parameters('name'))]"
+      }
+    }
+  ]
+}`)
+
+	require.Len(t, deployment.Resources, 1)
+	resource := deployment.Resources[0]
+	assert.Equal(t, "web-prod", resource.Name.AsString())
+	assert.False(t, resource.Properties.GetMapValue("password").IsResolved())
+	assert.Equal(t, "[not an expression]", resource.Properties.GetMapValue("literal").AsString())
+	assert.Equal(t,
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourceGroup/providers/Microsoft.Web/serverfarms/web",
+		resource.Properties.GetMapValue("serverFarmId").AsString(),
+	)
+}
+
+func Test_NestedResources(t *testing.T) {
+	deployment := parse(t, `{
+  "$schema": "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#",
+  "resources": [
+    {
+      "type": "Microsoft.KeyVault/vaults",
+      "name": "vault",
+      "resources": [
+        {
+          "type": "secrets",
+          "name": "password",
+          "properties": { "attributes": { "exp": 1672531200 } }
+        }
+      ]
+    }
+  ]
+}`)
+
+	secrets := deployment.GetResourcesByType("Microsoft.KeyVault/vaults/secrets")
+	require.Len(t, secrets, 1)
+	assert.Equal(t, "vault/password", secrets[0].Name.AsString())
+	assert.Equal(t, 1672531200, secrets[0].Properties.GetNestedValue("attributes.exp").AsInt())
+	assert.Len(t, deployment.GetChildResources(deployment.Resources[0], "Microsoft.KeyVault/vaults/secrets"), 1)
+}
+
+func Test_ConditionalResources(t *testing.T) {
+	deployment := parse(t, `{
+  "$schema": "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#",
+  "parameters": {
+    "deployLogs": { "type": "bool", "defaultValue": false }
+  },
+  "resources": [
+    {
+      "condition": "[parameters('deployLogs')]",
+      "type": "Microsoft.Storage/storageAccounts",
+      "name": "logs"
+    },
+    {
+      "condition": "[not(parameters('deployLogs'))]",
+      "type": "Microsoft.Storage/storageAccounts",
+      "name": "data"
+    }
+  ]
+}`)
+
+	require.Len(t, deployment.Resources, 1)
+	assert.Equal(t, "data", deployment.Resources[0].Name.AsString())
+}
+
+func Test_ValueRanges(t *testing.T) {
+	deployment := parse(t, `{
+  "$schema": "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#",
+  "resources": [
+    {
+      "type": "Microsoft.Storage/storageAccounts",
+      "name": "data",
+      "properties": {
+        "supportsHttpsTrafficOnly": true
+      }
+    }
+  ]
+}`)
+
+	require.Len(t, deployment.Resources, 1)
+	resource := deployment.Resources[0]
+	assert.Equal(t, 4, resource.Range().GetStartLine())
+	assert.Equal(t, 10, resource.Range().GetEndLine())
+
+	https := resource.Properties.GetMapValue("supportsHttpsTrafficOnly")
+	assert.True(t, https.AsBool())
+	assert.Equal(t, 8, https.Range().GetStartLine())
+	assert.Equal(t, "data", https.Parent().Reference().String())
+}
+
+func Test_NestedValueMetadata(t *testing.T) {
+	deployment := parse(t, `{
+  "$schema": "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#",
+  "resources": [
+    {
+      "type": "Microsoft.Storage/storageAccounts",
+      "name": "data",
+      "properties": {
+        "encryption": {
+          "services": {
+            "blob": { "enabled": true }
+          }
+        }
+      }
+    }
+  ]
+}`)
+
+	require.Len(t, deployment.Resources, 1)
+	enabled := deployment.Resources[0].Properties.GetNestedValue("encryption.services.blob.enabled")
+	assert.True(t, enabled.AsBool())
+	assert.Equal(t, 10, enabled.Range().GetStartLine())
+
+	// the parents are the enclosing objects, up to the resource
+	var parents []int
+	for parent := enabled.Parent(); parent != nil; parent = parent.Parent() {
+		parents = append(parents, parent.Range().GetStartLine())
+	}
+	assert.Equal(t, []int{10, 9, 8, 7, 4}, parents)
+}
+
+func Test_UnresolvedObject(t *testing.T) {
+	deployment := parse(t, `{
+  "$schema": "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#",
+  "parameters": {
+    "encryption": { "type": "object" }
+  },
+  "resources": [
+    {
+      "type": "Microsoft.Storage/storageAccounts",
+      "name": "data",
+      "properties": {
+        "encryption": "[parameters('encryption')]"
+      }
+    }
+  ]
+}`)
+
+	require.Len(t, deployment.Resources, 1)
+	enabled := deployment.Resources[0].Properties.GetNestedValue("encryption.services.blob.enabled")
+	assert.False(t, enabled.IsNull())
+	assert.False(t, enabled.IsResolved())
+}
+
+func Test_NotATemplate(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"data.json": `{"resources": []}`,
+	})
+	_, err := New().ParseFile(context.TODO(), fs, "data.json")
+	assert.Error(t, err)
+
+	deployments, err := New().ParseFS(context.TODO(), fs, ".")
+	require.NoError(t, err)
+	assert.Empty(t, deployments)
+}
@@ -0,0 +1,292 @@
+package parser
+
+import (
+	"io/fs"
+	"strings"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/scanners/azure"
+	"github.com/aquasecurity/defsec/pkg/scanners/azure/functions"
+	"github.com/liamg/jfather"
+)
+
+// template resolves the parameters, variables and expressions of a parsed ARM template
+type template struct {
+	root       *node
+	filename   string
+	filesystem fs.FS
+	scope      functions.Scope
+	parameters map[string]interface{}
+	variables  map[string]interface{}
+	resolving  map[string]bool
+	debug      func(format string, args ...interface{})
+}
+
+var _ functions.DeploymentData = (*template)(nil)
+
+func newTemplate(root *node, filename string, filesystem fs.FS, scope functions.Scope, debug func(string, ...interface{})) *template {
+	return &template{
+		root:       root,
+		filename:   filename,
+		filesystem: filesystem,
+		scope:      scope,
+		parameters: make(map[string]interface{}),
+		variables:  make(map[string]interface{}),
+		resolving:  make(map[string]bool),
+		debug:      debug,
+	}
+}
+
+func (t *template) GetScope() functions.Scope {
+	return t.scope
+}
+
+// GetParameter returns the default value of the named parameter. Parameters without a default are only known at
+// deployment time, so are unresolvable.
+func (t *template) GetParameter(name string) interface{} {
+	return t.resolveNamed("parameters", name, t.parameters, func(definition *node) *node {
+		return definition.get("defaultValue")
+	})
+}
+
+func (t *template) GetVariable(name string) interface{} {
+	return t.resolveNamed("variables", name, t.variables, func(definition *node) *node {
+		return definition
+	})
+}
+
+func (t *template) resolveNamed(section string, name string, cache map[string]interface{}, valueOf func(*node) *node) interface{} {
+	key := strings.ToLower(name)
+	if value, ok := cache[key]; ok {
+		return value
+	}
+	// guard against templates which reference themselves, which Azure would reject
+	guard := section + "." + key
+	if t.resolving[guard] {
+		t.debug("Circular reference to %s('%s').", section, name)
+		return functions.Unresolvable{}
+	}
+	t.resolving[guard] = true
+	defer delete(t.resolving, guard)
+
+	definition := t.root.get(section).get(name)
+	if definition == nil {
+		t.debug("Reference to undefined %s('%s').", section, name)
+		return functions.Unresolvable{}
+	}
+	valueNode := valueOf(definition)
+	if valueNode == nil {
+		cache[key] = functions.Unresolvable{}
+		return cache[key]
+	}
+	cache[key] = t.resolve(valueNode)
+	return cache[key]
+}
+
+// resolve converts the node into a plain go value, evaluating any expressions it contains
+func (t *template) resolve(n *node) interface{} {
+	if n == nil {
+		return nil
+	}
+	switch n.kind {
+	case jfather.KindObject:
+		output := make(map[string]interface{}, len(n.keys))
+		for _, key := range n.keys {
+			output[key] = t.resolve(n.props[key])
+		}
+		return output
+	case jfather.KindArray:
+		output := make([]interface{}, 0, len(n.items))
+		for _, item := range n.items {
+			output = append(output, t.resolve(item))
+		}
+		return output
+	case jfather.KindString:
+		str := n.asString()
+		if !isExpression(str) {
+			return unescape(str)
+		}
+		return t.evaluate(str)
+	default:
+		return n.raw
+	}
+}
+
+func (t *template) evaluate(input string) interface{} {
+	expr, err := parseExpression(input)
+	if err != nil {
+		t.debug("%s", err)
+		return functions.Unresolvable{}
+	}
+	value, err := expr.evaluate(t)
+	if err != nil {
+		t.debug("Failed to evaluate expression '%s': %s", input, err)
+		return functions.Unresolvable{}
+	}
+	return value
+}
+
+func (t *template) metadata(n *node, ref types.Reference) types.Metadata {
+	return types.NewMetadata(types.NewRange(t.filename, n.startLine, n.endLine, "", t.filesystem), ref)
+}
+
+// toValue converts the node into a resolved value. Missing nodes produce a null value with the parent metadata.
+func (t *template) toValue(n *node, parent types.Metadata) azure.Value {
+	if n == nil {
+		return azure.NullValue.WithMetadata(parent)
+	}
+	metadata := t.metadata(n, parent.Reference()).WithParent(parent)
+	switch n.kind {
+	case jfather.KindObject:
+		props := make(map[string]azure.Value, len(n.keys))
+		for _, key := range n.keys {
+			props[key] = t.toValue(n.props[key], metadata)
+		}
+		return azure.NewValue(props, metadata)
+	case jfather.KindArray:
+		items := make([]azure.Value, 0, len(n.items))
+		for _, item := range n.items {
+			items = append(items, t.toValue(item, metadata))
+		}
+		return azure.NewValue(items, metadata)
+	default:
+		value := t.resolve(n)
+		if _, ok := value.(functions.Unresolvable); ok {
+			return azure.NewUnresolvedValue(metadata)
+		}
+		return azure.NewValue(value, metadata)
+	}
+}
+
+func (t *template) toDeployment() azure.Deployment {
+	deployment := azure.Deployment{
+		Metadata: t.metadata(t.root, types.NewNamedReference(t.filename)),
+	}
+
+	if parameters := t.root.get("parameters"); parameters != nil {
+		for _, name := range parameters.keys {
+			definition := parameters.props[name]
+			metadata := t.metadata(definition, types.NewNamedReference("parameters."+name))
+			parameter := azure.Parameter{
+				Metadata: metadata,
+				Name:     name,
+				Type:     t.toValue(definition.get("type"), metadata),
+			}
+			if defaultValue := definition.get("defaultValue"); defaultValue != nil {
+				parameter.DefaultValue = t.toValue(defaultValue, metadata)
+			} else {
+				parameter.DefaultValue = azure.NewUnresolvedValue(metadata)
+			}
+			deployment.Parameters = append(deployment.Parameters, parameter)
+		}
+	}
+
+	if variables := t.root.get("variables"); variables != nil {
+		for _, name := range variables.keys {
+			definition := variables.props[name]
+			metadata := t.metadata(definition, types.NewNamedReference("variables."+name))
+			deployment.Variables = append(deployment.Variables, azure.Variable{
+				Metadata: metadata,
+				Name:     name,
+				Value:    azure.NewValue(t.GetVariable(name), metadata),
+			})
+		}
+	}
+
+	if resources := t.root.get("resources"); resources != nil {
+		for _, resourceNode := range resources.items {
+			if resource, ok := t.toResource(resourceNode, nil); ok {
+				deployment.Resources = append(deployment.Resources, resource)
+			}
+		}
+	}
+
+	if outputs := t.root.get("outputs"); outputs != nil {
+		for _, name := range outputs.keys {
+			definition := outputs.props[name]
+			metadata := t.metadata(definition, types.NewNamedReference("outputs."+name))
+			deployment.Outputs = append(deployment.Outputs, azure.Output{
+				Metadata: metadata,
+				Name:     name,
+				Value:    t.toValue(definition.get("value"), metadata),
+			})
+		}
+	}
+
+	return deployment
+}
+
+// toResource converts a resource definition, including any nested child resources. Child resources use a type
+// and name relative to their parent, so these are expanded to the full type and name.
+func (t *template) toResource(n *node, parent *azure.Resource) (azure.Resource, bool) {
+	if n.kind != jfather.KindObject {
+		return azure.Resource{}, false
+	}
+
+	if condition := n.get("condition"); condition != nil {
+		if value, ok := t.resolve(condition).(bool); ok && !value {
+			t.debug("Skipping resource at line %d as its condition is false.", n.startLine)
+			return azure.Resource{}, false
+		}
+	}
+
+	resourceType := resolveString(t.resolve(n.get("type")))
+	name := resolveString(t.resolve(n.get("name")))
+	if parent != nil && !isFullResourceType(resourceType) {
+		resourceType = parent.Type.AsString() + "/" + resourceType
+		name = parent.Name.AsString() + "/" + name
+	}
+
+	metadata := t.metadata(n, types.NewNamedReference(name))
+	resource := azure.Resource{
+		Metadata:   metadata,
+		APIVersion: t.toValue(n.get("apiVersion"), metadata),
+		Type:       azure.NewValue(resourceType, t.metadata(nodeOrParent(n.get("type"), n), metadata.Reference()).WithParent(metadata)),
+		Kind:       t.toValue(n.get("kind"), metadata),
+		Name:       azure.NewValue(name, t.metadata(nodeOrParent(n.get("name"), n), metadata.Reference()).WithParent(metadata)),
+		Location:   t.toValue(n.get("location"), metadata),
+		Tags:       t.toValue(n.get("tags"), metadata),
+		Sku:        t.toValue(n.get("sku"), metadata),
+		Identity:   t.toValue(n.get("identity"), metadata),
+		Properties: t.toValue(n.get("properties"), metadata),
+	}
+
+	if children := n.get("resources"); children != nil {
+		for _, childNode := range children.items {
+			if child, ok := t.toResource(childNode, &resource); ok {
+				resource.Resources = append(resource.Resources, child)
+			}
+		}
+	}
+
+	return resource, true
+}
+
+func nodeOrParent(n *node, parent *node) *node {
+	if n == nil {
+		return parent
+	}
+	return n
+}
+
+func resolveString(value interface{}) string {
+	if str, ok := value.(string); ok {
+		return str
+	}
+	return ""
+}
+
+// isFullResourceType returns true for types which include the resource provider namespace, e.g. Microsoft.Web/sites
+func isFullResourceType(resourceType string) bool {
+	namespace := strings.Split(resourceType, "/")[0]
+	return strings.Contains(resourceType, "/") && strings.Contains(namespace, ".")
+}
+
+// isTemplate returns true if the node looks like the root of an ARM deployment template
+func isTemplate(root *node) bool {
+	if root == nil || root.kind != jfather.KindObject {
+		return false
+	}
+	schema := strings.ToLower(root.get("$schema").asString())
+	return strings.Contains(schema, "deploymenttemplate.json") && root.get("resources") != nil
+}
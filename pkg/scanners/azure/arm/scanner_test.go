@@ -0,0 +1,66 @@
+package arm
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/aquasecurity/defsec/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ScanTemplate(t *testing.T) {
+
+	results, err := New().ScanFS(context.TODO(), os.DirFS("test/testdata"), ".")
+	require.NoError(t, err)
+
+	failed := make(map[string][]string)
+	for _, result := range results.GetFailed() {
+		failed[result.Rule().AVDID] = append(failed[result.Rule().AVDID], result.Flatten().Resource)
+		assert.Equal(t, "storage.json", result.Range().GetFilename())
+	}
+
+	// https enforcement
+	assert.Equal(t, []string{"acmelogs"}, failed["AVD-AZU-0008"])
+
+	// minimum tls version, resolved from the parameter default
+	assert.Equal(t, []string{"acmelogs"}, failed["AVD-AZU-0011"])
+
+	// public access on the nested container
+	assert.Equal(t, []string{"acmelogs/default/public"}, failed["AVD-AZU-0007"])
+
+	// network rules deny by default and allow azure services
+	assert.Empty(t, failed["AVD-AZU-0010"])
+	assert.Empty(t, failed["AVD-AZU-0012"])
+}
+
+func Test_ResultRange(t *testing.T) {
+
+	results, err := New().ScanFile(context.TODO(), os.DirFS("test/testdata"), "storage.json")
+	require.NoError(t, err)
+
+	var found bool
+	for _, result := range results.GetFailed() {
+		if result.Rule().AVDID != "AVD-AZU-0008" {
+			continue
+		}
+		found = true
+		assert.Equal(t, 25, result.Range().GetStartLine())
+		assert.Equal(t, 25, result.Range().GetEndLine())
+	}
+	assert.True(t, found)
+}
+
+func Test_NonTemplateJSONIsSkipped(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"code/data.json": `{"resources": []}`,
+	})
+
+	_, err := New().ScanFile(context.TODO(), fs, "code/data.json")
+	assert.Error(t, err)
+
+	results, err := New().ScanFS(context.TODO(), fs, "code")
+	require.NoError(t, err)
+	assert.Len(t, results, 0)
+}
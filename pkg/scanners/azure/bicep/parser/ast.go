@@ -0,0 +1,165 @@
+package parser
+
+// span is the range of lines an element of a bicep file was defined on
+type span struct {
+	startLine int
+	endLine   int
+}
+
+func (s span) lines() span {
+	return s
+}
+
+type expression interface {
+	lines() span
+}
+
+type literalExpr struct {
+	span
+	value interface{}
+}
+
+// stringExpr is a (possibly interpolated) string - each part is either a literal string or an expression
+type stringExpr struct {
+	span
+	parts []expression
+}
+
+type identExpr struct {
+	span
+	name string
+}
+
+type property struct {
+	span
+	key   string
+	value expression
+}
+
+type objectExpr struct {
+	span
+	properties []property
+	// resources which are declared inside the body of another resource
+	resources []*resourceDecl
+}
+
+func (o *objectExpr) get(key string) expression {
+	if o == nil {
+		return nil
+	}
+	for _, prop := range o.properties {
+		if prop.key == key {
+			return prop.value
+		}
+	}
+	return nil
+}
+
+type arrayExpr struct {
+	span
+	items []expression
+}
+
+// forExpr is a loop, e.g. [for (item, i) in items: if (condition) { ... }]
+type forExpr struct {
+	span
+	itemVar   string
+	indexVar  string
+	source    expression
+	condition expression
+	body      expression
+}
+
+type memberExpr struct {
+	span
+	target expression
+	name   string
+	safe   bool
+}
+
+type indexExpr struct {
+	span
+	target expression
+	index  expression
+	safe   bool
+}
+
+type callExpr struct {
+	span
+	name string
+	args []expression
+}
+
+type unaryExpr struct {
+	span
+	operator string
+	operand  expression
+}
+
+type binaryExpr struct {
+	span
+	operator string
+	left     expression
+	right    expression
+}
+
+type ternaryExpr struct {
+	span
+	condition expression
+	then      expression
+	otherwise expression
+}
+
+// lambdaExpr is parsed so that files which use lambda functions can still be read, but is never evaluated
+type lambdaExpr struct {
+	span
+}
+
+type paramDecl struct {
+	span
+	name         string
+	defaultValue expression
+}
+
+type varDecl struct {
+	span
+	name  string
+	value expression
+}
+
+type outputDecl struct {
+	span
+	name  string
+	value expression
+}
+
+type resourceDecl struct {
+	span
+	symbol       string
+	resourceType string
+	apiVersion   string
+	existing     bool
+	condition    expression
+	// body is either an objectExpr or a forExpr with an objectExpr body
+	body expression
+}
+
+type moduleDecl struct {
+	span
+	symbol    string
+	path      string
+	condition expression
+	body      expression
+}
+
+// file is the syntax tree of a bicep file
+type file struct {
+	span
+	path        string
+	targetScope string
+	params      []*paramDecl
+	vars        []*varDecl
+	resources   []*resourceDecl
+	modules     []*moduleDecl
+	outputs     []*outputDecl
+}
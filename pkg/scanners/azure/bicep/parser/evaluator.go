@@ -0,0 +1,812 @@
+package parser
+
+import (
+	"fmt"
+	"io/fs"
+	"math"
+	"path"
+	"strings"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/scanners/azure"
+	"github.com/aquasecurity/defsec/pkg/scanners/azure/functions"
+)
+
+// maxModuleDepth prevents modules which (indirectly) reference themselves from being expanded forever
+const maxModuleDepth = 10
+
+// evaluator resolves the symbols and expressions of a parsed bicep file
+type evaluator struct {
+	parser     *Parser
+	file       *file
+	filesystem fs.FS
+	depth      int
+	// overrides are the parameter values passed in by a module declaration
+	overrides map[string]interface{}
+	values    map[string]interface{}
+	resolving map[string]bool
+	// locals holds the loop variables currently in scope, innermost last
+	locals    []map[string]interface{}
+	resources map[string]*resourceDecl
+	parents   map[*resourceDecl]*resourceDecl
+	modules   map[string]*moduleDecl
+}
+
+var _ functions.DeploymentData = (*evaluator)(nil)
+
+func newEvaluator(p *Parser, f *file, filesystem fs.FS, overrides map[string]interface{}, depth int) *evaluator {
+	e := &evaluator{
+		parser:     p,
+		file:       f,
+		filesystem: filesystem,
+		depth:      depth,
+		overrides:  overrides,
+		values:     make(map[string]interface{}),
+		resolving:  make(map[string]bool),
+		resources:  make(map[string]*resourceDecl),
+		parents:    make(map[*resourceDecl]*resourceDecl),
+		modules:    make(map[string]*moduleDecl),
+	}
+	for _, resource := range f.resources {
+		e.registerResource(resource, nil)
+	}
+	for _, module := range f.modules {
+		e.modules[module.symbol] = module
+	}
+	return e
+}
+
+// registerResource adds the resource and its nested resources to the symbol table. Nested resources can be
+// referenced as parent::child, or by their own name when it doesn't clash with another symbol.
+func (e *evaluator) registerResource(resource *resourceDecl, parent *resourceDecl) {
+	if parent != nil {
+		e.parents[resource] = parent
+		e.resources[e.symbolOf(parent)+"::"+resource.symbol] = resource
+		if _, exists := e.resources[resource.symbol]; !exists {
+			e.resources[resource.symbol] = resource
+		}
+	} else {
+		e.resources[resource.symbol] = resource
+	}
+	if body := bodyObject(resource.body); body != nil {
+		for _, child := range body.resources {
+			e.registerResource(child, resource)
+		}
+	}
+}
+
+func (e *evaluator) symbolOf(resource *resourceDecl) string {
+	if parent, ok := e.parents[resource]; ok {
+		return e.symbolOf(parent) + "::" + resource.symbol
+	}
+	return resource.symbol
+}
+
+func (e *evaluator) debug(format string, args ...interface{}) {
+	e.parser.debug(format, args...)
+}
+
+func (e *evaluator) GetScope() functions.Scope {
+	return e.parser.scope
+}
+
+func (e *evaluator) GetParameter(name string) interface{} {
+	for _, param := range e.file.params {
+		if strings.EqualFold(param.name, name) {
+			return e.paramValue(param)
+		}
+	}
+	return functions.Unresolvable{}
+}
+
+func (e *evaluator) GetVariable(name string) interface{} {
+	for _, v := range e.file.vars {
+		if strings.EqualFold(v.name, name) {
+			return e.varValue(v)
+		}
+	}
+	return functions.Unresolvable{}
+}
+
+// cached evaluates a top-level symbol once, guarding against symbols which reference themselves. Loop variables
+// are never visible to top-level symbols, so they are hidden while the value is calculated.
+func (e *evaluator) cached(key string, calculate func() interface{}) interface{} {
+	if value, ok := e.values[key]; ok {
+		return value
+	}
+	if e.resolving[key] {
+		e.debug("Circular reference to %s in %s.", key, e.file.path)
+		return functions.Unresolvable{}
+	}
+	e.resolving[key] = true
+	locals := e.locals
+	e.locals = nil
+	value := calculate()
+	e.locals = locals
+	delete(e.resolving, key)
+	e.values[key] = value
+	return value
+}
+
+// paramValue returns the value passed in by the calling module, or the default value. Parameters without either
+// are only known at deployment time, so are unresolvable.
+func (e *evaluator) paramValue(param *paramDecl) interface{} {
+	return e.cached("param:"+param.name, func() interface{} {
+		if value, ok := e.overrides[param.name]; ok {
+			return value
+		}
+		if param.defaultValue == nil {
+			return functions.Unresolvable{}
+		}
+		return e.evaluate(param.defaultValue)
+	})
+}
+
+func (e *evaluator) varValue(v *varDecl) interface{} {
+	return e.cached("var:"+v.name, func() interface{} {
+		return e.evaluate(v.value)
+	})
+}
+
+func (e *evaluator) lookup(name string) interface{} {
+	for i := len(e.locals) - 1; i >= 0; i-- {
+		if value, ok := e.locals[i][name]; ok {
+			return value
+		}
+	}
+	for _, param := range e.file.params {
+		if param.name == name {
+			return e.paramValue(param)
+		}
+	}
+	for _, v := range e.file.vars {
+		if v.name == name {
+			return e.varValue(v)
+		}
+	}
+	if resource, ok := e.resources[name]; ok {
+		return e.cached("resource:"+e.symbolOf(resource), func() interface{} {
+			return e.resourceSymbolValue(resource)
+		})
+	}
+	if module, ok := e.modules[name]; ok {
+		return e.cached("module:"+name, func() interface{} {
+			return e.moduleSymbolValue(module)
+		})
+	}
+	e.debug("Reference to unknown symbol '%s' in %s.", name, e.file.path)
+	return functions.Unresolvable{}
+}
+
+// forEach calls fn for every iteration of the loop which passes the loop condition, with the loop variables in
+// scope. If the loop source can't be resolved, fn is called once with unresolvable loop variables, so the
+// body is still scanned.
+func (e *evaluator) forEach(loop *forExpr, fn func(index int)) {
+	sourceItems, ok := e.evaluate(loop.source).([]interface{})
+	if !ok {
+		e.debug("Could not resolve loop source at %s:%d, the loop body is evaluated once.", e.file.path, loop.startLine)
+		e.iterate(loop, functions.Unresolvable{}, functions.Unresolvable{}, 0, fn)
+		return
+	}
+	for i, item := range sourceItems {
+		e.iterate(loop, item, int64(i), i, fn)
+	}
+}
+
+func (e *evaluator) iterate(loop *forExpr, item interface{}, indexValue interface{}, index int, fn func(index int)) {
+	scope := map[string]interface{}{loop.itemVar: item}
+	if loop.indexVar != "" {
+		scope[loop.indexVar] = indexValue
+	}
+	e.locals = append(e.locals, scope)
+	defer func() { e.locals = e.locals[:len(e.locals)-1] }()
+	if loop.condition != nil {
+		if ok, isBool := e.evaluate(loop.condition).(bool); isBool && !ok {
+			return
+		}
+	}
+	fn(index)
+}
+
+// evaluate converts the expression into a plain go value, or functions.Unresolvable if the value is only known
+// at deployment time
+func (e *evaluator) evaluate(expr expression) interface{} {
+	switch typed := expr.(type) {
+	case nil:
+		return nil
+	case *literalExpr:
+		return typed.value
+	case *stringExpr:
+		var sb strings.Builder
+		for _, part := range typed.parts {
+			value := e.evaluate(part)
+			if _, ok := value.(functions.Unresolvable); ok {
+				return value
+			}
+			str, _ := functions.Evaluate(e, "string", value)
+			sb.WriteString(str.(string))
+		}
+		return sb.String()
+	case *identExpr:
+		return e.lookup(typed.name)
+	case *objectExpr:
+		output := make(map[string]interface{}, len(typed.properties))
+		for _, prop := range typed.properties {
+			output[prop.key] = e.evaluate(prop.value)
+		}
+		return output
+	case *arrayExpr:
+		output := make([]interface{}, 0, len(typed.items))
+		for _, item := range typed.items {
+			output = append(output, e.evaluate(item))
+		}
+		return output
+	case *forExpr:
+		output := []interface{}{}
+		e.forEach(typed, func(int) {
+			output = append(output, e.evaluate(typed.body))
+		})
+		return output
+	case *memberExpr:
+		target := e.evaluate(typed.target)
+		if target == nil && typed.safe {
+			return nil
+		}
+		return e.member(target, typed.name, typed.safe)
+	case *indexExpr:
+		target := e.evaluate(typed.target)
+		if target == nil && typed.safe {
+			return nil
+		}
+		return e.index(target, e.evaluate(typed.index), typed.safe)
+	case *callExpr:
+		return e.call(typed)
+	case *unaryExpr:
+		return unary(typed.operator, e.evaluate(typed.operand))
+	case *binaryExpr:
+		return e.binary(typed)
+	case *ternaryExpr:
+		condition, ok := e.evaluate(typed.condition).(bool)
+		if !ok {
+			return functions.Unresolvable{}
+		}
+		if condition {
+			return e.evaluate(typed.then)
+		}
+		return e.evaluate(typed.otherwise)
+	default:
+		return functions.Unresolvable{}
+	}
+}
+
+func (e *evaluator) member(target interface{}, name string, safe bool) interface{} {
+	obj, ok := target.(map[string]interface{})
+	if !ok {
+		return functions.Unresolvable{}
+	}
+	for key, value := range obj {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	if safe {
+		return nil
+	}
+	return functions.Unresolvable{}
+}
+
+func (e *evaluator) index(target interface{}, index interface{}, safe bool) interface{} {
+	switch typed := target.(type) {
+	case []interface{}:
+		i, ok := index.(int64)
+		if !ok {
+			return functions.Unresolvable{}
+		}
+		if i < 0 || int(i) >= len(typed) {
+			if safe {
+				return nil
+			}
+			return functions.Unresolvable{}
+		}
+		return typed[i]
+	case map[string]interface{}:
+		key, ok := index.(string)
+		if !ok {
+			return functions.Unresolvable{}
+		}
+		return e.member(typed, key, safe)
+	default:
+		return functions.Unresolvable{}
+	}
+}
+
+func (e *evaluator) call(call *callExpr) interface{} {
+	args := make([]interface{}, 0, len(call.args))
+	for _, arg := range call.args {
+		args = append(args, e.evaluate(arg))
+	}
+	if strings.EqualFold(call.name, "any") && len(args) == 1 {
+		return args[0]
+	}
+	value, err := functions.Evaluate(e, call.name, args...)
+	if err != nil {
+		e.debug("Failed to evaluate %s() at %s:%d: %s", call.name, e.file.path, call.startLine, err)
+		return functions.Unresolvable{}
+	}
+	return value
+}
+
+func (e *evaluator) binary(expr *binaryExpr) interface{} {
+	left := e.evaluate(expr.left)
+	switch expr.operator {
+	case "??":
+		if left == nil {
+			return e.evaluate(expr.right)
+		}
+		return left
+	case "&&", "||":
+		// short circuit, so that the right hand side can rely on the left, e.g. x != null && x.enabled
+		if l, ok := left.(bool); ok && l == (expr.operator == "||") {
+			return l
+		}
+	}
+	right := e.evaluate(expr.right)
+	if _, ok := left.(functions.Unresolvable); ok {
+		return left
+	}
+	if _, ok := right.(functions.Unresolvable); ok {
+		return right
+	}
+	var name string
+	switch expr.operator {
+	case "&&", "||":
+		l, lok := left.(bool)
+		r, rok := right.(bool)
+		if !lok || !rok {
+			return functions.Unresolvable{}
+		}
+		if expr.operator == "&&" {
+			return l && r
+		}
+		return l || r
+	case "==", "!=":
+		equal, _ := functions.Evaluate(e, "equals", left, right)
+		return equal == (expr.operator == "==")
+	case "=~", "!~":
+		l, lok := left.(string)
+		r, rok := right.(string)
+		if !lok || !rok {
+			return functions.Unresolvable{}
+		}
+		return strings.EqualFold(l, r) == (expr.operator == "=~")
+	case "<":
+		name = "less"
+	case "<=":
+		name = "lessOrEquals"
+	case ">":
+		name = "greater"
+	case ">=":
+		name = "greaterOrEquals"
+	default:
+		return arithmetic(expr.operator, left, right)
+	}
+	value, err := functions.Evaluate(e, name, left, right)
+	if err != nil {
+		return functions.Unresolvable{}
+	}
+	return value
+}
+
+func unary(operator string, operand interface{}) interface{} {
+	switch typed := operand.(type) {
+	case bool:
+		if operator == "!" {
+			return !typed
+		}
+	case int64:
+		if operator == "-" {
+			return -typed
+		}
+	case float64:
+		if operator == "-" {
+			return -typed
+		}
+	}
+	return functions.Unresolvable{}
+}
+
+func arithmetic(operator string, left interface{}, right interface{}) interface{} {
+	l, lok := left.(int64)
+	r, rok := right.(int64)
+	if lok && rok {
+		switch operator {
+		case "+":
+			return l + r
+		case "-":
+			return l - r
+		case "*":
+			return l * r
+		case "/":
+			if r != 0 {
+				return l / r
+			}
+		case "%":
+			if r != 0 {
+				return l % r
+			}
+		}
+		return functions.Unresolvable{}
+	}
+	lf, lok := toFloat(left)
+	rf, rok := toFloat(right)
+	if !lok || !rok {
+		return functions.Unresolvable{}
+	}
+	switch operator {
+	case "+":
+		return lf + rf
+	case "-":
+		return lf - rf
+	case "*":
+		return lf * rf
+	case "/":
+		if rf != 0 {
+			return lf / rf
+		}
+	case "%":
+		if rf != 0 {
+			return math.Mod(lf, rf)
+		}
+	}
+	return functions.Unresolvable{}
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch typed := value.(type) {
+	case int64:
+		return float64(typed), true
+	case float64:
+		return typed, true
+	}
+	return 0, false
+}
+
+// resourceSymbolValue is the value of a resource symbol when it is referenced by an expression, e.g. storage.id
+func (e *evaluator) resourceSymbolValue(resource *resourceDecl) interface{} {
+	var parent interface{}
+	if parentDecl, ok := e.parents[resource]; ok {
+		parent = e.lookup(e.symbolOf(parentDecl))
+	}
+	if loop, ok := resource.body.(*forExpr); ok {
+		output := []interface{}{}
+		e.forEach(loop, func(int) {
+			output = append(output, e.resourceObject(resource, bodyObject(loop), parent))
+		})
+		return output
+	}
+	return e.resourceObject(resource, bodyObject(resource.body), parent)
+}
+
+func (e *evaluator) resourceObject(resource *resourceDecl, body *objectExpr, parent interface{}) map[string]interface{} {
+	parentName, parentType := parentIdentity(parent)
+	resourceType, name := e.resourceIdentity(resource, body, parentName, parentType)
+	output := map[string]interface{}{
+		"name":       name,
+		"type":       resourceType,
+		"apiVersion": resource.apiVersion,
+		"id":         functions.Unresolvable{},
+	}
+	if str, ok := name.(string); ok {
+		args := []interface{}{resourceType}
+		for _, segment := range strings.Split(str, "/") {
+			args = append(args, segment)
+		}
+		output["id"], _ = functions.Evaluate(e, "resourceId", args...)
+	}
+	for _, prop := range body.properties {
+		if prop.key == "name" || prop.key == "parent" {
+			continue
+		}
+		output[prop.key] = e.evaluate(prop.value)
+	}
+	return output
+}
+
+func parentIdentity(parent interface{}) (interface{}, string) {
+	obj, ok := parent.(map[string]interface{})
+	if !ok {
+		return functions.Unresolvable{}, ""
+	}
+	parentType, _ := obj["type"].(string)
+	return obj["name"], parentType
+}
+
+// resourceIdentity returns the full type and name of the resource. The names of nested resources and resources
+// with a parent property are prefixed with the name of the parent, as they would be in an ARM template.
+func (e *evaluator) resourceIdentity(resource *resourceDecl, body *objectExpr, parentName interface{}, parentType string) (string, interface{}) {
+	resourceType := resource.resourceType
+	name := e.evaluate(body.get("name"))
+	if _, nested := e.parents[resource]; nested {
+		if !isFullResourceType(resourceType) && parentType != "" {
+			resourceType = parentType + "/" + resourceType
+		}
+	} else if parentExpr := body.get("parent"); parentExpr != nil {
+		parentName, _ = parentIdentity(e.evaluate(parentExpr))
+	} else {
+		return resourceType, name
+	}
+	p, pok := parentName.(string)
+	n, nok := name.(string)
+	if !pok || !nok {
+		return resourceType, functions.Unresolvable{}
+	}
+	return resourceType, p + "/" + n
+}
+
+// moduleSymbolValue is the value of a module symbol when it is referenced by an expression, e.g. storage.outputs.id
+func (e *evaluator) moduleSymbolValue(module *moduleDecl) interface{} {
+	if loop, ok := module.body.(*forExpr); ok {
+		output := []interface{}{}
+		e.forEach(loop, func(int) {
+			output = append(output, e.moduleObject(module, bodyObject(loop)))
+		})
+		return output
+	}
+	return e.moduleObject(module, bodyObject(module.body))
+}
+
+func (e *evaluator) moduleObject(module *moduleDecl, body *objectExpr) interface{} {
+	outputs := make(map[string]interface{})
+	if child := e.moduleEvaluator(module, body); child != nil {
+		for _, output := range child.file.outputs {
+			outputs[output.name] = child.evaluate(output.value)
+		}
+	}
+	return map[string]interface{}{
+		"name":    e.evaluate(body.get("name")),
+		"outputs": outputs,
+	}
+}
+
+// moduleEvaluator loads the file referenced by a module declaration. Modules from registries and template specs
+// are not available locally, so are ignored.
+func (e *evaluator) moduleEvaluator(module *moduleDecl, body *objectExpr) *evaluator {
+	if strings.Contains(module.path, ":") {
+		e.debug("Skipping module '%s' as only local modules are supported.", module.path)
+		return nil
+	}
+	if e.depth >= maxModuleDepth {
+		e.debug("Skipping module '%s' as the maximum module depth was reached.", module.path)
+		return nil
+	}
+	modulePath := modulePath(e.file.path, module.path)
+	f, err := e.parser.parseSyntax(e.filesystem, modulePath)
+	if err != nil {
+		e.debug("Failed to load module '%s': %s", modulePath, err)
+		return nil
+	}
+	params, ok := e.evaluate(body.get("params")).(map[string]interface{})
+	if !ok {
+		params = make(map[string]interface{})
+	}
+	for key, value := range params {
+		// unresolvable values passed in by the caller are treated as if they were not set
+		if _, unresolvable := value.(functions.Unresolvable); unresolvable {
+			delete(params, key)
+		}
+	}
+	return newEvaluator(e.parser, f, e.filesystem, params, e.depth+1)
+}
+
+func modulePath(filePath string, module string) string {
+	return path.Join(path.Dir(filePath), module)
+}
+
+func (e *evaluator) metadata(s span, ref types.Reference) types.Metadata {
+	return types.NewMetadata(types.NewRange(e.file.path, s.startLine, s.endLine, "", e.filesystem), ref)
+}
+
+// toValue converts the expression into a resolved value, with metadata pointing at the bicep source
+func (e *evaluator) toValue(expr expression, parent types.Metadata) azure.Value {
+	if expr == nil {
+		return azure.NullValue.WithMetadata(parent)
+	}
+	metadata := e.metadata(expr.lines(), parent.Reference()).WithParent(parent)
+	switch typed := expr.(type) {
+	case *objectExpr:
+		props := make(map[string]azure.Value, len(typed.properties))
+		for _, prop := range typed.properties {
+			props[prop.key] = e.toValue(prop.value, parent)
+		}
+		return azure.NewValue(props, metadata)
+	case *arrayExpr:
+		items := make([]azure.Value, 0, len(typed.items))
+		for _, item := range typed.items {
+			items = append(items, e.toValue(item, parent))
+		}
+		return azure.NewValue(items, metadata)
+	case *forExpr:
+		items := []azure.Value{}
+		e.forEach(typed, func(int) {
+			items = append(items, e.toValue(typed.body, parent))
+		})
+		return azure.NewValue(items, metadata)
+	case *ternaryExpr:
+		if condition, ok := e.evaluate(typed.condition).(bool); ok {
+			if condition {
+				return e.toValue(typed.then, parent)
+			}
+			return e.toValue(typed.otherwise, parent)
+		}
+		return azure.NewUnresolvedValue(metadata)
+	default:
+		value := e.evaluate(expr)
+		if _, ok := value.(functions.Unresolvable); ok {
+			return azure.NewUnresolvedValue(metadata)
+		}
+		return azure.NewValue(value, metadata)
+	}
+}
+
+func (e *evaluator) toDeployment() azure.Deployment {
+	deployment := azure.Deployment{
+		Metadata: e.metadata(e.file.span, types.NewNamedReference(e.file.path)),
+	}
+
+	for _, param := range e.file.params {
+		metadata := e.metadata(param.span, types.NewNamedReference("parameters."+param.name))
+		parameter := azure.Parameter{
+			Metadata: metadata,
+			Name:     param.name,
+			Type:     azure.NullValue.WithMetadata(metadata),
+		}
+		if value, ok := e.overrides[param.name]; ok {
+			parameter.DefaultValue = azure.NewValue(value, metadata)
+		} else if param.defaultValue != nil {
+			parameter.DefaultValue = e.toValue(param.defaultValue, metadata)
+		} else {
+			parameter.DefaultValue = azure.NewUnresolvedValue(metadata)
+		}
+		deployment.Parameters = append(deployment.Parameters, parameter)
+	}
+
+	for _, v := range e.file.vars {
+		metadata := e.metadata(v.span, types.NewNamedReference("variables."+v.name))
+		deployment.Variables = append(deployment.Variables, azure.Variable{
+			Metadata: metadata,
+			Name:     v.name,
+			Value:    e.toValue(v.value, metadata),
+		})
+	}
+
+	for _, resource := range e.file.resources {
+		deployment.Resources = append(deployment.Resources, e.toResources(resource, resource.symbol, nil)...)
+	}
+
+	for _, module := range e.file.modules {
+		deployment.Resources = append(deployment.Resources, e.moduleResources(module)...)
+	}
+
+	for _, output := range e.file.outputs {
+		metadata := e.metadata(output.span, types.NewNamedReference("outputs."+output.name))
+		deployment.Outputs = append(deployment.Outputs, azure.Output{
+			Metadata: metadata,
+			Name:     output.name,
+			Value:    e.toValue(output.value, metadata),
+		})
+	}
+
+	return deployment
+}
+
+// isDeployed returns false if the declaration has a condition which is false
+func (e *evaluator) isDeployed(condition expression, s span) bool {
+	if condition == nil {
+		return true
+	}
+	if value, ok := e.evaluate(condition).(bool); ok && !value {
+		e.debug("Skipping declaration at %s:%d as its condition is false.", e.file.path, s.startLine)
+		return false
+	}
+	return true
+}
+
+// toResources converts a resource declaration into a resource for each iteration of its loop, if it has one.
+// Existing resources are not deployed by the file, so are not included.
+func (e *evaluator) toResources(decl *resourceDecl, symbol string, parent *azure.Resource) []azure.Resource {
+	if decl.existing || !e.isDeployed(decl.condition, decl.span) {
+		return nil
+	}
+	loop, ok := decl.body.(*forExpr)
+	if !ok {
+		return []azure.Resource{e.toResource(decl, bodyObject(decl.body), symbol, parent)}
+	}
+	var resources []azure.Resource
+	e.forEach(loop, func(index int) {
+		resources = append(resources, e.toResource(decl, bodyObject(loop), fmt.Sprintf("%s[%d]", symbol, index), parent))
+	})
+	return resources
+}
+
+func (e *evaluator) toResource(decl *resourceDecl, body *objectExpr, symbol string, parent *azure.Resource) azure.Resource {
+	var parentName interface{} = functions.Unresolvable{}
+	var parentType string
+	if parent != nil {
+		parentName, parentType = parent.Name.Raw(), parent.Type.AsString()
+		if !parent.Name.IsResolved() {
+			parentName = functions.Unresolvable{}
+		}
+	}
+	resourceType, name := e.resourceIdentity(decl, body, parentName, parentType)
+
+	metadata := e.metadata(decl.span, types.NewNamedReference(symbol))
+	nameValue := azure.NewUnresolvedValue(metadata)
+	if str, ok := name.(string); ok {
+		nameValue = azure.NewValue(str, e.metadata(exprOrSpan(body.get("name"), decl.span), metadata.Reference()).WithParent(metadata))
+	}
+	resource := azure.Resource{
+		Metadata:   metadata,
+		APIVersion: azure.NewValue(decl.apiVersion, metadata),
+		Type:       azure.NewValue(resourceType, metadata),
+		Kind:       e.toValue(body.get("kind"), metadata),
+		Name:       nameValue,
+		Location:   e.toValue(body.get("location"), metadata),
+		Tags:       e.toValue(body.get("tags"), metadata),
+		Sku:        e.toValue(body.get("sku"), metadata),
+		Identity:   e.toValue(body.get("identity"), metadata),
+		Properties: e.toValue(body.get("properties"), metadata),
+	}
+
+	for _, child := range body.resources {
+		resource.Resources = append(resource.Resources, e.toResources(child, symbol+"::"+child.symbol, &resource)...)
+	}
+
+	return resource
+}
+
+// moduleResources returns the resources deployed by a local module, with metadata pointing at the module file
+func (e *evaluator) moduleResources(module *moduleDecl) []azure.Resource {
+	if !e.isDeployed(module.condition, module.span) {
+		return nil
+	}
+	var resources []azure.Resource
+	expand := func(body *objectExpr) {
+		if child := e.moduleEvaluator(module, body); child != nil {
+			resources = append(resources, child.toDeployment().Resources...)
+		}
+	}
+	if loop, ok := module.body.(*forExpr); ok {
+		e.forEach(loop, func(int) {
+			expand(bodyObject(loop))
+		})
+	} else {
+		expand(bodyObject(module.body))
+	}
+	return resources
+}
+
+func exprOrSpan(expr expression, fallback span) span {
+	if expr == nil {
+		return fallback
+	}
+	return expr.lines()
+}
+
+// bodyObject returns the object which defines a resource or module, unwrapping any loop
+func bodyObject(body expression) *objectExpr {
+	switch typed := body.(type) {
+	case *objectExpr:
+		return typed
+	case *forExpr:
+		if obj, ok := typed.body.(*objectExpr); ok {
+			return obj
+		}
+	}
+	return &objectExpr{}
+}
+
+// isFullResourceType returns true for types which include the resource provider namespace, e.g. Microsoft.Web/sites
+func isFullResourceType(resourceType string) bool {
+	namespace := strings.Split(resourceType, "/")[0]
+	return strings.Contains(resourceType, "/") && strings.Contains(namespace, ".")
+}
@@ -0,0 +1,252 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNewline
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenSymbol
+)
+
+// stringPart is either a literal segment of a string, or the source of an interpolated ${...} expression
+type stringPart struct {
+	literal string
+	expr    string
+	line    int
+	isExpr  bool
+}
+
+type token struct {
+	kind  tokenKind
+	value string
+	parts []stringPart
+	line  int
+}
+
+func (t token) is(kind tokenKind, value string) bool {
+	return t.kind == kind && t.value == value
+}
+
+// symbols are matched longest first
+var symbols = []string{
+	"=~", "!~", "==", "!=", "<=", ">=", "&&", "||", "??", "::", "=>",
+	"{", "}", "[", "]", "(", ")", ",", ":", ".", "?", "=", "<", ">", "!", "+", "-", "*", "/", "%", "@", "|",
+}
+
+type lexer struct {
+	input []rune
+	pos   int
+	line  int
+}
+
+func lex(input string, line int) ([]token, error) {
+	l := &lexer{input: []rune(input), line: line}
+	var tokens []token
+	for {
+		t, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t)
+		if t.kind == tokenEOF {
+			return tokens, nil
+		}
+	}
+}
+
+func (l *lexer) peek(offset int) rune {
+	if l.pos+offset >= len(l.input) {
+		return 0
+	}
+	return l.input[l.pos+offset]
+}
+
+func (l *lexer) hasPrefix(prefix string) bool {
+	return l.hasPrefixAt(l.pos, prefix)
+}
+
+func (l *lexer) hasPrefixAt(pos int, prefix string) bool {
+	for i, r := range []rune(prefix) {
+		if pos+i >= len(l.input) || l.input[pos+i] != r {
+			return false
+		}
+	}
+	return true
+}
+
+// indexFrom returns the (rune) index of the next occurrence of the string at or after the given index, or -1
+func (l *lexer) indexFrom(from int, str string) int {
+	for i := from; i < len(l.input); i++ {
+		if l.hasPrefixAt(i, str) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (l *lexer) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", l.line, fmt.Sprintf(format, args...))
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.input) {
+		r := l.input[l.pos]
+		switch {
+		case r == '\n':
+			l.pos++
+			l.line++
+			return token{kind: tokenNewline, line: l.line - 1}, nil
+		case unicode.IsSpace(r):
+			l.pos++
+		case l.hasPrefix("//"):
+			for l.pos < len(l.input) && l.input[l.pos] != '\n' {
+				l.pos++
+			}
+		case l.hasPrefix("/*"):
+			end := l.indexFrom(l.pos+2, "*/")
+			if end < 0 {
+				return token{}, l.errorf("unterminated comment")
+			}
+			l.line += strings.Count(string(l.input[l.pos:end]), "\n")
+			l.pos = end + 2
+		case l.hasPrefix("'''"):
+			return l.lexMultilineString()
+		case r == '\'':
+			return l.lexString()
+		case unicode.IsDigit(r):
+			start := l.pos
+			for l.pos < len(l.input) && (unicode.IsDigit(l.input[l.pos]) || (l.input[l.pos] == '.' && unicode.IsDigit(l.peek(1)))) {
+				l.pos++
+			}
+			return token{kind: tokenNumber, value: string(l.input[start:l.pos]), line: l.line}, nil
+		case unicode.IsLetter(r) || r == '_':
+			start := l.pos
+			for l.pos < len(l.input) && (unicode.IsLetter(l.input[l.pos]) || unicode.IsDigit(l.input[l.pos]) || l.input[l.pos] == '_') {
+				l.pos++
+			}
+			return token{kind: tokenIdent, value: string(l.input[start:l.pos]), line: l.line}, nil
+		default:
+			for _, symbol := range symbols {
+				if l.hasPrefix(symbol) {
+					l.pos += len(symbol)
+					return token{kind: tokenSymbol, value: symbol, line: l.line}, nil
+				}
+			}
+			return token{}, l.errorf("unexpected character '%c'", r)
+		}
+	}
+	return token{kind: tokenEOF, line: l.line}, nil
+}
+
+func (l *lexer) lexMultilineString() (token, error) {
+	startLine := l.line
+	end := l.indexFrom(l.pos+3, "'''")
+	if end < 0 {
+		return token{}, l.errorf("unterminated multi-line string")
+	}
+	value := string(l.input[l.pos+3 : end])
+	l.pos = end + 3
+	l.line += strings.Count(value, "\n")
+	// a leading line break is not part of the value
+	str := strings.TrimPrefix(strings.TrimPrefix(value, "\r"), "\n")
+	return token{kind: tokenString, line: startLine, parts: []stringPart{{literal: str}}}, nil
+}
+
+func (l *lexer) lexString() (token, error) {
+	startLine := l.line
+	l.pos++
+	var parts []stringPart
+	var sb strings.Builder
+	for {
+		if l.pos >= len(l.input) || l.input[l.pos] == '\n' {
+			return token{}, l.errorf("unterminated string")
+		}
+		r := l.input[l.pos]
+		switch {
+		case r == '\'':
+			l.pos++
+			if sb.Len() > 0 || len(parts) == 0 {
+				parts = append(parts, stringPart{literal: sb.String()})
+			}
+			return token{kind: tokenString, line: startLine, parts: parts}, nil
+		case r == '\\':
+			escaped := l.peek(1)
+			l.pos += 2
+			switch escaped {
+			case 'n':
+				sb.WriteRune('\n')
+			case 'r':
+				sb.WriteRune('\r')
+			case 't':
+				sb.WriteRune('\t')
+			case 'u':
+				// \u{XXXX}
+				end := l.indexFrom(l.pos, "}")
+				if l.peek(0) != '{' || end < 0 {
+					return token{}, l.errorf("invalid unicode escape")
+				}
+				var code int
+				if _, err := fmt.Sscanf(string(l.input[l.pos+1:end]), "%x", &code); err != nil {
+					return token{}, l.errorf("invalid unicode escape")
+				}
+				sb.WriteRune(rune(code))
+				l.pos = end + 1
+			default:
+				sb.WriteRune(escaped)
+			}
+		case r == '$' && l.peek(1) == '{':
+			if sb.Len() > 0 {
+				parts = append(parts, stringPart{literal: sb.String()})
+				sb.Reset()
+			}
+			l.pos += 2
+			expr, err := l.readInterpolation()
+			if err != nil {
+				return token{}, err
+			}
+			parts = append(parts, stringPart{expr: expr, isExpr: true, line: l.line})
+		default:
+			sb.WriteRune(r)
+			l.pos++
+		}
+	}
+}
+
+// readInterpolation reads the source of an interpolated expression, up to the matching closing brace
+func (l *lexer) readInterpolation() (string, error) {
+	start := l.pos
+	depth := 0
+	inString := false
+	for l.pos < len(l.input) {
+		r := l.input[l.pos]
+		switch {
+		case inString && r == '\\':
+			l.pos++
+		case r == '\'':
+			inString = !inString
+		case inString:
+		case r == '{':
+			depth++
+		case r == '}':
+			if depth == 0 {
+				expr := string(l.input[start:l.pos])
+				l.pos++
+				return expr, nil
+			}
+			depth--
+		case r == '\n':
+			return "", l.errorf("unterminated string interpolation")
+		}
+		l.pos++
+	}
+	return "", l.errorf("unterminated string interpolation")
+}
@@ -0,0 +1,144 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/azure"
+	"github.com/aquasecurity/defsec/pkg/scanners/azure/functions"
+)
+
+type Parser struct {
+	debugWriter io.Writer
+	scope       functions.Scope
+	files       map[string]*file
+}
+
+type Option func(p *Parser)
+
+func OptionWithDebugWriter(w io.Writer) Option {
+	return func(p *Parser) {
+		p.debugWriter = w
+	}
+}
+
+// OptionWithScope sets the subscription, resource group etc. used when evaluating functions such as resourceGroup()
+func OptionWithScope(scope functions.Scope) Option {
+	return func(p *Parser) {
+		p.scope = scope
+	}
+}
+
+// New creates a new parser
+func New(options ...Option) *Parser {
+	p := &Parser{
+		scope: functions.DefaultScope,
+		files: make(map[string]*file),
+	}
+	for _, option := range options {
+		option(p)
+	}
+	return p
+}
+
+func (p *Parser) debug(format string, args ...interface{}) {
+	if p.debugWriter == nil {
+		return
+	}
+	prefix := "[debug:parse:bicep] "
+	_, _ = p.debugWriter.Write([]byte(fmt.Sprintf(prefix+format+"\n", args...)))
+}
+
+// ParseFS parses all bicep files found in the given directory. Files which are used as modules by other files in
+// the directory are only parsed as part of the file which uses them, as they usually depend on the parameters
+// passed in by that file.
+func (p *Parser) ParseFS(ctx context.Context, target fs.FS, dir string) ([]azure.Deployment, error) {
+	var files []*file
+	if err := fs.WalkDir(target, filepath.ToSlash(dir), func(path string, entry fs.DirEntry, err error) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !p.Required(path) {
+			return nil
+		}
+		f, err := p.parseSyntax(target, path)
+		if err != nil {
+			p.debug("Failed to parse '%s': %s", path, err)
+			return nil
+		}
+		files = append(files, f)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	modules := make(map[string]bool)
+	for _, f := range files {
+		for _, module := range f.modules {
+			modules[modulePath(f.path, module.path)] = true
+		}
+	}
+
+	var deployments []azure.Deployment
+	for _, f := range files {
+		if modules[f.path] {
+			p.debug("Skipping %s as it is used as a module", f.path)
+			continue
+		}
+		deployments = append(deployments, p.convert(f, target))
+	}
+	return deployments, nil
+}
+
+// ParseFile parses the bicep file at the given path
+func (p *Parser) ParseFile(_ context.Context, target fs.FS, path string) (*azure.Deployment, error) {
+	f, err := p.parseSyntax(target, path)
+	if err != nil {
+		return nil, err
+	}
+	deployment := p.convert(f, target)
+	return &deployment, nil
+}
+
+// Required returns true if the file at the given path is a bicep file
+func (p *Parser) Required(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".bicep")
+}
+
+// parseSyntax reads and parses the file at the given path. Files are cached, as modules may be used many times.
+func (p *Parser) parseSyntax(target fs.FS, path string) (*file, error) {
+	path = filepath.ToSlash(path)
+	if f, ok := p.files[path]; ok {
+		return f, nil
+	}
+	f, err := target.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parseSyntax(path, string(data))
+	if err != nil {
+		return nil, err
+	}
+	p.files[path] = parsed
+	return parsed, nil
+}
+
+func (p *Parser) convert(f *file, target fs.FS) azure.Deployment {
+	p.debug("Parsing bicep file %s", f.path)
+	return newEvaluator(p, f, target, nil, 0).toDeployment()
+}
@@ -0,0 +1,253 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/azure"
+	"github.com/aquasecurity/defsec/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parse(t *testing.T, source string) azure.Deployment {
+	fs := testutil.CreateFS(t, map[string]string{
+		"main.bicep": source,
+	})
+	deployment, err := New().ParseFile(context.TODO(), fs, "main.bicep")
+	require.NoError(t, err)
+	return *deployment
+}
+
+func Test_ParamsAndVars(t *testing.T) {
+	deployment := parse(t, `
+@description('The name of the site')
+param name string = 'web'
+@secure()
+param secret string
+
+var siteName = '${name}-${toLower('PROD')}'
+
+resource site 'Microsoft.Web/sites@2022-03-01' = {
+  name: siteName
+  location: resourceGroup().location
+  properties: {
+    password: secret
+    serverFarmId: resourceId('Microsoft.Web/serverfarms', name)
+    httpsOnly: !false
+  }
+}
+`)
+
+	require.Len(t, deployment.Resources, 1)
+	resource := deployment.Resources[0]
+	assert.Equal(t, "Microsoft.Web/sites", resource.Type.AsString())
+	assert.Equal(t, "2022-03-01", resource.APIVersion.AsString())
+	assert.Equal(t, "web-prod", resource.Name.AsString())
+	assert.Equal(t, "westeurope", resource.Location.AsString())
+	assert.False(t, resource.Properties.GetMapValue("password").IsResolved())
+	assert.True(t, resource.Properties.GetMapValue("httpsOnly").AsBool())
+	assert.Equal(t,
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourceGroup/providers/Microsoft.Web/serverfarms/web",
+		resource.Properties.GetMapValue("serverFarmId").AsString(),
+	)
+
+	require.Len(t, deployment.Parameters, 2)
+	assert.Equal(t, "web", deployment.Parameters[0].DefaultValue.AsString())
+	require.Len(t, deployment.Variables, 1)
+	assert.Equal(t, "web-prod", deployment.Variables[0].Value.AsString())
+}
+
+func Test_SourceRanges(t *testing.T) {
+	deployment := parse(t, `param location string = 'uksouth'
+
+resource account 'Microsoft.Storage/storageAccounts@2022-09-01' = {
+  name: 'acme'
+  location: location
+  properties: {
+    supportsHttpsTrafficOnly: false
+  }
+}
+`)
+
+	require.Len(t, deployment.Resources, 1)
+	resource := deployment.Resources[0]
+	assert.Equal(t, "main.bicep", resource.Metadata.Range().GetFilename())
+	assert.Equal(t, 3, resource.Metadata.Range().GetStartLine())
+	assert.Equal(t, 9, resource.Metadata.Range().GetEndLine())
+	assert.Equal(t, "account", resource.Metadata.Reference().LogicalID())
+
+	https := resource.Properties.GetMapValue("supportsHttpsTrafficOnly")
+	assert.Equal(t, 7, https.Metadata.Range().GetStartLine())
+	assert.Equal(t, 5, resource.Location.Metadata.Range().GetStartLine())
+}
+
+func Test_NestedAndParentResources(t *testing.T) {
+	deployment := parse(t, `
+resource vault 'Microsoft.KeyVault/vaults@2022-07-01' = {
+  name: 'vault'
+  resource secret 'secrets' = {
+    name: 'password'
+    properties: {
+      attributes: {
+        exp: 1672531200
+      }
+    }
+  }
+}
+
+resource key 'Microsoft.KeyVault/vaults/keys@2022-07-01' = {
+  parent: vault
+  name: 'key'
+  properties: {}
+}
+
+output secretName string = vault::secret.name
+`)
+
+	secrets := deployment.GetResourcesByType("Microsoft.KeyVault/vaults/secrets")
+	require.Len(t, secrets, 1)
+	assert.Equal(t, "vault/password", secrets[0].Name.AsString())
+	assert.Equal(t, 1672531200, secrets[0].Properties.GetNestedValue("attributes.exp").AsInt())
+	assert.Equal(t, "vault::secret", secrets[0].Metadata.Reference().LogicalID())
+
+	keys := deployment.GetResourcesByType("Microsoft.KeyVault/vaults/keys")
+	require.Len(t, keys, 1)
+	assert.Equal(t, "vault/key", keys[0].Name.AsString())
+	assert.Len(t, deployment.GetChildResources(deployment.Resources[0], "Microsoft.KeyVault/vaults/keys"), 1)
+
+	require.Len(t, deployment.Outputs, 1)
+	assert.Equal(t, "vault/password", deployment.Outputs[0].Value.AsString())
+}
+
+func Test_ConditionsAndLoops(t *testing.T) {
+	deployment := parse(t, `
+param deployLogs bool = false
+param names array = [
+  'one'
+  'two'
+  'three'
+]
+
+resource logs 'Microsoft.Storage/storageAccounts@2022-09-01' = if (deployLogs) {
+  name: 'logs'
+}
+
+resource accounts 'Microsoft.Storage/storageAccounts@2022-09-01' = [for (name, i) in names: if (name != 'two') {
+  name: '${name}${i}'
+  properties: {
+    minimumTlsVersion: i == 0 ? 'TLS1_0' : 'TLS1_2'
+  }
+}]
+
+resource containers 'Microsoft.Storage/storageAccounts/blobServices/containers@2022-09-01' = [for i in range(0, 2): {
+  name: 'acme/default/c${i}'
+}]
+
+var tags = {
+  team: 'platform'
+  env: deployLogs ? 'logs' : 'nologs'
+}
+
+output first string = accounts[0].name
+output env string = tags.env
+`)
+
+	accounts := deployment.GetResourcesByType("Microsoft.Storage/storageAccounts")
+	require.Len(t, accounts, 2)
+	assert.Equal(t, "one0", accounts[0].Name.AsString())
+	assert.Equal(t, "TLS1_0", accounts[0].Properties.GetMapValue("minimumTlsVersion").AsString())
+	assert.Equal(t, "accounts[0]", accounts[0].Metadata.Reference().LogicalID())
+	assert.Equal(t, "three2", accounts[1].Name.AsString())
+	assert.Equal(t, "TLS1_2", accounts[1].Properties.GetMapValue("minimumTlsVersion").AsString())
+
+	containers := deployment.GetResourcesByType("Microsoft.Storage/storageAccounts/blobServices/containers")
+	require.Len(t, containers, 2)
+	assert.Equal(t, "acme/default/c1", containers[1].Name.AsString())
+
+	require.Len(t, deployment.Outputs, 2)
+	assert.Equal(t, "one0", deployment.Outputs[0].Value.AsString())
+	assert.Equal(t, "nologs", deployment.Outputs[1].Value.AsString())
+}
+
+func Test_ExistingResourcesAreNotDeployed(t *testing.T) {
+	deployment := parse(t, `
+resource plan 'Microsoft.Web/serverfarms@2022-03-01' existing = {
+  name: 'shared-plan'
+}
+
+resource site 'Microsoft.Web/sites@2022-03-01' = {
+  name: 'site'
+  properties: {
+    serverFarmId: plan.id
+  }
+}
+`)
+
+	require.Len(t, deployment.Resources, 1)
+	assert.Equal(t,
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourceGroup/providers/Microsoft.Web/serverfarms/shared-plan",
+		deployment.Resources[0].Properties.GetMapValue("serverFarmId").AsString(),
+	)
+}
+
+func Test_Modules(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"main.bicep": `
+param prefix string = 'acme'
+
+module storage 'modules/storage.bicep' = {
+  name: 'storage'
+  params: {
+    name: '${prefix}logs'
+    httpsOnly: false
+  }
+}
+
+module remote 'br/public:storage/account:1.0.0' = {
+  name: 'remote'
+}
+
+output accountId string = storage.outputs.id
+`,
+		"modules/storage.bicep": `
+param name string
+param httpsOnly bool = true
+
+resource account 'Microsoft.Storage/storageAccounts@2022-09-01' = {
+  name: name
+  properties: {
+    supportsHttpsTrafficOnly: httpsOnly
+  }
+}
+
+output id string = account.id
+`,
+	})
+
+	deployments, err := New().ParseFS(context.TODO(), fs, ".")
+	require.NoError(t, err)
+	require.Len(t, deployments, 1)
+	deployment := deployments[0]
+
+	require.Len(t, deployment.Resources, 1)
+	account := deployment.Resources[0]
+	assert.Equal(t, "acmelogs", account.Name.AsString())
+	assert.False(t, account.Properties.GetMapValue("supportsHttpsTrafficOnly").AsBool())
+	assert.Equal(t, "modules/storage.bicep", account.Metadata.Range().GetFilename())
+	assert.Equal(t, 5, account.Metadata.Range().GetStartLine())
+
+	require.Len(t, deployment.Outputs, 1)
+	assert.Equal(t,
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourceGroup/providers/Microsoft.Storage/storageAccounts/acmelogs",
+		deployment.Outputs[0].Value.AsString(),
+	)
+}
+
+func Test_InvalidSyntax(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"main.bicep": `resource account 'Microsoft.Storage/storageAccounts@2022-09-01' = {`,
+	})
+	_, err := New().ParseFile(context.TODO(), fs, "main.bicep")
+	require.Error(t, err)
+}
@@ -0,0 +1,747 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type syntaxParser struct {
+	tokens []token
+	pos    int
+}
+
+// parseSyntax parses the source of a bicep file into a syntax tree
+func parseSyntax(path string, source string) (*file, error) {
+	tokens, err := lex(source, 1)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	p := &syntaxParser{tokens: tokens}
+	f, err := p.parseFile()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	f.path = path
+	f.span = span{1, tokens[len(tokens)-1].line}
+	return f, nil
+}
+
+func (p *syntaxParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *syntaxParser) peekAt(offset int) token {
+	if p.pos+offset >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *syntaxParser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// previousLine returns the line of the last consumed token
+func (p *syntaxParser) previousLine() int {
+	if p.pos == 0 {
+		return p.tokens[0].line
+	}
+	return p.tokens[p.pos-1].line
+}
+
+func (p *syntaxParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", p.peek().line, fmt.Sprintf(format, args...))
+}
+
+func (p *syntaxParser) skipNewlines() {
+	for p.peek().kind == tokenNewline {
+		p.next()
+	}
+}
+
+func (p *syntaxParser) acceptSymbol(value string) bool {
+	if p.peek().is(tokenSymbol, value) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *syntaxParser) expectSymbol(value string) error {
+	if !p.acceptSymbol(value) {
+		return p.errorf("expected '%s'", value)
+	}
+	return nil
+}
+
+func (p *syntaxParser) expectIdent() (token, error) {
+	t := p.peek()
+	if t.kind != tokenIdent {
+		return t, p.errorf("expected identifier")
+	}
+	return p.next(), nil
+}
+
+// expectLiteralString reads a string which must not contain interpolation, e.g. a resource type or module path
+func (p *syntaxParser) expectLiteralString() (string, error) {
+	t := p.peek()
+	if t.kind != tokenString || len(t.parts) != 1 || t.parts[0].isExpr {
+		return "", p.errorf("expected a string literal")
+	}
+	p.next()
+	return t.parts[0].literal, nil
+}
+
+func (p *syntaxParser) parseFile() (*file, error) {
+	f := &file{}
+	for {
+		p.skipNewlines()
+		if p.peek().kind == tokenEOF {
+			return f, nil
+		}
+		if err := p.parseStatement(f); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *syntaxParser) parseDecorators() error {
+	for p.acceptSymbol("@") {
+		if _, err := p.parseExpression(); err != nil {
+			return err
+		}
+		p.skipNewlines()
+	}
+	return nil
+}
+
+func (p *syntaxParser) parseStatement(f *file) error {
+	if err := p.parseDecorators(); err != nil {
+		return err
+	}
+	keyword := p.peek()
+	if keyword.kind != tokenIdent {
+		return p.errorf("unexpected token '%s'", keyword.value)
+	}
+	switch keyword.value {
+	case "targetScope":
+		p.next()
+		if err := p.expectSymbol("="); err != nil {
+			return err
+		}
+		scope, err := p.expectLiteralString()
+		if err != nil {
+			return err
+		}
+		f.targetScope = scope
+	case "param":
+		param, err := p.parseParam()
+		if err != nil {
+			return err
+		}
+		f.params = append(f.params, param)
+	case "var":
+		p.next()
+		name, err := p.expectIdent()
+		if err != nil {
+			return err
+		}
+		p.skipType()
+		if err := p.expectSymbol("="); err != nil {
+			return err
+		}
+		value, err := p.parseExpression()
+		if err != nil {
+			return err
+		}
+		f.vars = append(f.vars, &varDecl{span: span{keyword.line, p.previousLine()}, name: name.value, value: value})
+	case "output":
+		p.next()
+		name, err := p.expectIdent()
+		if err != nil {
+			return err
+		}
+		p.skipType()
+		if err := p.expectSymbol("="); err != nil {
+			return err
+		}
+		value, err := p.parseExpression()
+		if err != nil {
+			return err
+		}
+		f.outputs = append(f.outputs, &outputDecl{span: span{keyword.line, p.previousLine()}, name: name.value, value: value})
+	case "resource":
+		resource, err := p.parseResource()
+		if err != nil {
+			return err
+		}
+		f.resources = append(f.resources, resource)
+	case "module":
+		module, err := p.parseModule()
+		if err != nil {
+			return err
+		}
+		f.modules = append(f.modules, module)
+	default:
+		// type, func, import, metadata etc. do not affect the deployed resources
+		p.skipStatement()
+	}
+	return nil
+}
+
+func (p *syntaxParser) parseParam() (*paramDecl, error) {
+	keyword := p.next()
+	name, err := p.expectIdent()
+	if err != nil {
+		return nil, err
+	}
+	param := &paramDecl{name: name.value}
+	p.skipType()
+	if p.acceptSymbol("=") {
+		if param.defaultValue, err = p.parseExpression(); err != nil {
+			return nil, err
+		}
+	}
+	param.span = span{keyword.line, p.previousLine()}
+	return param, nil
+}
+
+// skipType skips a type expression, which ends at an '=' or the end of the line
+func (p *syntaxParser) skipType() {
+	depth := 0
+	for {
+		t := p.peek()
+		switch {
+		case t.kind == tokenEOF:
+			return
+		case depth == 0 && (t.kind == tokenNewline || t.is(tokenSymbol, "=")):
+			return
+		case t.is(tokenSymbol, "{") || t.is(tokenSymbol, "[") || t.is(tokenSymbol, "("):
+			depth++
+		case t.is(tokenSymbol, "}") || t.is(tokenSymbol, "]") || t.is(tokenSymbol, ")"):
+			depth--
+		}
+		p.next()
+	}
+}
+
+// skipStatement skips to the end of the current statement, including any bracketed blocks
+func (p *syntaxParser) skipStatement() {
+	p.next()
+	p.skipType()
+	if p.acceptSymbol("=") {
+		p.skipType()
+	}
+}
+
+func (p *syntaxParser) parseResource() (*resourceDecl, error) {
+	keyword := p.next()
+	symbol, err := p.expectIdent()
+	if err != nil {
+		return nil, err
+	}
+	typeString, err := p.expectLiteralString()
+	if err != nil {
+		return nil, err
+	}
+	resource := &resourceDecl{symbol: symbol.value}
+	resource.resourceType, resource.apiVersion, _ = strings.Cut(typeString, "@")
+	if p.peek().is(tokenIdent, "existing") {
+		p.next()
+		resource.existing = true
+	}
+	if err := p.expectSymbol("="); err != nil {
+		return nil, err
+	}
+	if resource.condition, resource.body, err = p.parseDeclarationBody(); err != nil {
+		return nil, err
+	}
+	resource.span = span{keyword.line, p.previousLine()}
+	return resource, nil
+}
+
+func (p *syntaxParser) parseModule() (*moduleDecl, error) {
+	keyword := p.next()
+	symbol, err := p.expectIdent()
+	if err != nil {
+		return nil, err
+	}
+	path, err := p.expectLiteralString()
+	if err != nil {
+		return nil, err
+	}
+	module := &moduleDecl{symbol: symbol.value, path: path}
+	if err := p.expectSymbol("="); err != nil {
+		return nil, err
+	}
+	if module.condition, module.body, err = p.parseDeclarationBody(); err != nil {
+		return nil, err
+	}
+	module.span = span{keyword.line, p.previousLine()}
+	return module, nil
+}
+
+// parseDeclarationBody parses the body of a resource or module, which may be conditional or a loop
+func (p *syntaxParser) parseDeclarationBody() (expression, expression, error) {
+	var condition expression
+	if p.peek().is(tokenIdent, "if") {
+		p.next()
+		var err error
+		if condition, err = p.parseParenthesised(); err != nil {
+			return nil, nil, err
+		}
+		p.skipNewlines()
+	}
+	body, err := p.parsePrimary()
+	if err != nil {
+		return nil, nil, err
+	}
+	switch body.(type) {
+	case *objectExpr, *forExpr:
+		return condition, body, nil
+	default:
+		return nil, nil, p.errorf("expected an object or a loop")
+	}
+}
+
+func (p *syntaxParser) parseParenthesised() (expression, error) {
+	if err := p.expectSymbol("("); err != nil {
+		return nil, err
+	}
+	p.skipNewlines()
+	expr, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	p.skipNewlines()
+	if err := p.expectSymbol(")"); err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+func (p *syntaxParser) parseExpression() (expression, error) {
+	return p.parseTernary()
+}
+
+func (p *syntaxParser) parseTernary() (expression, error) {
+	condition, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if !p.peek().is(tokenSymbol, "?") {
+		return condition, nil
+	}
+	p.next()
+	p.skipNewlines()
+	then, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+	p.skipNewlines()
+	if err := p.expectSymbol(":"); err != nil {
+		return nil, err
+	}
+	p.skipNewlines()
+	otherwise, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+	return &ternaryExpr{
+		span:      span{condition.lines().startLine, otherwise.lines().endLine},
+		condition: condition,
+		then:      then,
+		otherwise: otherwise,
+	}, nil
+}
+
+// binary operators, from lowest to highest precedence
+var precedence = [][]string{
+	{"??"},
+	{"||"},
+	{"&&"},
+	{"==", "!=", "=~", "!~"},
+	{"<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *syntaxParser) parseBinary(level int) (expression, error) {
+	if level >= len(precedence) {
+		return p.parseUnary()
+	}
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t.kind != tokenSymbol || !contains(precedence[level], t.value) {
+			return left, nil
+		}
+		p.next()
+		p.skipNewlines()
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpr{
+			span:     span{left.lines().startLine, right.lines().endLine},
+			operator: t.value,
+			left:     left,
+			right:    right,
+		}
+	}
+}
+
+func (p *syntaxParser) parseUnary() (expression, error) {
+	t := p.peek()
+	if t.is(tokenSymbol, "!") || t.is(tokenSymbol, "-") {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryExpr{span: span{t.line, operand.lines().endLine}, operator: t.value, operand: operand}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *syntaxParser) parsePostfix() (expression, error) {
+	expr, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		switch {
+		case t.is(tokenSymbol, "."):
+			p.next()
+			// safe dereference, e.g. foo.?bar
+			safe := p.acceptSymbol("?")
+			name, err := p.expectIdent()
+			if err != nil {
+				return nil, err
+			}
+			if p.peek().is(tokenSymbol, "(") {
+				// namespaced function call, e.g. az.resourceGroup() or sys.concat()
+				if expr, err = p.parseCall(name); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			expr = &memberExpr{span: span{expr.lines().startLine, name.line}, target: expr, name: name.value, safe: safe}
+		case t.is(tokenSymbol, "::"):
+			// nested resource access, e.g. storage::blobServices
+			p.next()
+			name, err := p.expectIdent()
+			if err != nil {
+				return nil, err
+			}
+			ident, ok := expr.(*identExpr)
+			if !ok {
+				return nil, p.errorf("unexpected '::'")
+			}
+			expr = &identExpr{span: span{ident.startLine, name.line}, name: ident.name + "::" + name.value}
+		case t.is(tokenSymbol, "["):
+			p.next()
+			// safe index, e.g. foo[?0]
+			safe := p.acceptSymbol("?")
+			if expr, err = p.parseIndex(expr, safe); err != nil {
+				return nil, err
+			}
+		case t.is(tokenSymbol, "!"):
+			// non-null assertion
+			p.next()
+		default:
+			return expr, nil
+		}
+	}
+}
+
+func (p *syntaxParser) parseIndex(target expression, safe bool) (expression, error) {
+	p.skipNewlines()
+	index, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	p.skipNewlines()
+	if err := p.expectSymbol("]"); err != nil {
+		return nil, err
+	}
+	return &indexExpr{span: span{target.lines().startLine, p.previousLine()}, target: target, index: index, safe: safe}, nil
+}
+
+func (p *syntaxParser) parseCall(name token) (expression, error) {
+	if err := p.expectSymbol("("); err != nil {
+		return nil, err
+	}
+	call := &callExpr{name: name.value}
+	for {
+		p.skipNewlines()
+		if p.acceptSymbol(")") {
+			call.span = span{name.line, p.previousLine()}
+			return call, nil
+		}
+		arg, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, arg)
+		p.skipNewlines()
+		if !p.acceptSymbol(",") && !p.peek().is(tokenSymbol, ")") {
+			return nil, p.errorf("expected ',' or ')' in call to %s()", name.value)
+		}
+	}
+}
+
+func (p *syntaxParser) parsePrimary() (expression, error) {
+	t := p.peek()
+	switch t.kind {
+	case tokenNumber:
+		p.next()
+		if i, err := strconv.ParseInt(t.value, 10, 64); err == nil {
+			return &literalExpr{span: span{t.line, t.line}, value: i}, nil
+		}
+		f, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, p.errorf("invalid number '%s'", t.value)
+		}
+		return &literalExpr{span: span{t.line, t.line}, value: f}, nil
+	case tokenString:
+		p.next()
+		return p.parseStringParts(t)
+	case tokenIdent:
+		p.next()
+		switch t.value {
+		case "true":
+			return &literalExpr{span: span{t.line, t.line}, value: true}, nil
+		case "false":
+			return &literalExpr{span: span{t.line, t.line}, value: false}, nil
+		case "null":
+			return &literalExpr{span: span{t.line, t.line}, value: nil}, nil
+		}
+		if p.peek().is(tokenSymbol, "(") {
+			return p.parseCall(t)
+		}
+		if p.peek().is(tokenSymbol, "=>") {
+			return p.parseLambdaBody(t.line)
+		}
+		return &identExpr{span: span{t.line, t.line}, name: t.value}, nil
+	case tokenSymbol:
+		switch t.value {
+		case "{":
+			return p.parseObject()
+		case "[":
+			return p.parseArray()
+		case "(":
+			if p.isLambda() {
+				for !p.acceptSymbol(")") {
+					p.next()
+				}
+				return p.parseLambdaBody(t.line)
+			}
+			return p.parseParenthesised()
+		}
+	}
+	return nil, p.errorf("unexpected token '%s'", t.value)
+}
+
+// isLambda returns true if the parenthesised list at the current position is the argument list of a lambda
+func (p *syntaxParser) isLambda() bool {
+	for offset := 1; ; offset++ {
+		t := p.peekAt(offset)
+		switch {
+		case t.kind == tokenIdent || t.is(tokenSymbol, ","):
+		case t.is(tokenSymbol, ")"):
+			return p.peekAt(offset+1).is(tokenSymbol, "=>")
+		default:
+			return false
+		}
+	}
+}
+
+func (p *syntaxParser) parseLambdaBody(startLine int) (expression, error) {
+	if err := p.expectSymbol("=>"); err != nil {
+		return nil, err
+	}
+	p.skipNewlines()
+	body, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	return &lambdaExpr{span: span{startLine, body.lines().endLine}}, nil
+}
+
+func (p *syntaxParser) parseStringParts(t token) (expression, error) {
+	str := &stringExpr{span: span{t.line, t.line}}
+	for _, part := range t.parts {
+		if !part.isExpr {
+			str.parts = append(str.parts, &literalExpr{span: span{t.line, t.line}, value: part.literal})
+			continue
+		}
+		tokens, err := lex(part.expr, part.line)
+		if err != nil {
+			return nil, err
+		}
+		inner := &syntaxParser{tokens: tokens}
+		expr, err := inner.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		if inner.peek().kind != tokenEOF {
+			return nil, inner.errorf("unexpected token '%s' in string interpolation", inner.peek().value)
+		}
+		str.parts = append(str.parts, expr)
+	}
+	if len(str.parts) == 1 {
+		if literal, ok := str.parts[0].(*literalExpr); ok {
+			literal.span = str.span
+			return literal, nil
+		}
+	}
+	return str, nil
+}
+
+func (p *syntaxParser) parseObject() (expression, error) {
+	start := p.next()
+	obj := &objectExpr{}
+	for {
+		p.skipNewlines()
+		if p.acceptSymbol("}") {
+			obj.span = span{start.line, p.previousLine()}
+			return obj, nil
+		}
+		if err := p.parseDecorators(); err != nil {
+			return nil, err
+		}
+		key := p.peek()
+		if key.is(tokenIdent, "resource") && p.peekAt(1).kind == tokenIdent {
+			resource, err := p.parseResource()
+			if err != nil {
+				return nil, err
+			}
+			obj.resources = append(obj.resources, resource)
+			continue
+		}
+		var name string
+		switch key.kind {
+		case tokenIdent:
+			name = key.value
+		case tokenString:
+			if len(key.parts) != 1 || key.parts[0].isExpr {
+				return nil, p.errorf("object keys cannot be interpolated")
+			}
+			name = key.parts[0].literal
+		default:
+			return nil, p.errorf("expected object key")
+		}
+		p.next()
+		if err := p.expectSymbol(":"); err != nil {
+			return nil, err
+		}
+		p.skipNewlines()
+		value, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		obj.properties = append(obj.properties, property{
+			span:  span{key.line, value.lines().endLine},
+			key:   name,
+			value: value,
+		})
+		p.acceptSymbol(",")
+	}
+}
+
+func (p *syntaxParser) parseArray() (expression, error) {
+	start := p.next()
+	p.skipNewlines()
+	if p.peek().is(tokenIdent, "for") {
+		return p.parseFor(start)
+	}
+	arr := &arrayExpr{}
+	for {
+		p.skipNewlines()
+		if p.acceptSymbol("]") {
+			arr.span = span{start.line, p.previousLine()}
+			return arr, nil
+		}
+		item, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		arr.items = append(arr.items, item)
+		p.acceptSymbol(",")
+	}
+}
+
+func (p *syntaxParser) parseFor(start token) (expression, error) {
+	p.next()
+	loop := &forExpr{}
+	if p.acceptSymbol("(") {
+		item, err := p.expectIdent()
+		if err != nil {
+			return nil, err
+		}
+		loop.itemVar = item.value
+		if p.acceptSymbol(",") {
+			index, err := p.expectIdent()
+			if err != nil {
+				return nil, err
+			}
+			loop.indexVar = index.value
+		}
+		if err := p.expectSymbol(")"); err != nil {
+			return nil, err
+		}
+	} else {
+		item, err := p.expectIdent()
+		if err != nil {
+			return nil, err
+		}
+		loop.itemVar = item.value
+	}
+	if !p.peek().is(tokenIdent, "in") {
+		return nil, p.errorf("expected 'in'")
+	}
+	p.next()
+	var err error
+	if loop.source, err = p.parseExpression(); err != nil {
+		return nil, err
+	}
+	if err := p.expectSymbol(":"); err != nil {
+		return nil, err
+	}
+	p.skipNewlines()
+	if p.peek().is(tokenIdent, "if") {
+		p.next()
+		if loop.condition, err = p.parseParenthesised(); err != nil {
+			return nil, err
+		}
+		p.skipNewlines()
+	}
+	if loop.body, err = p.parseExpression(); err != nil {
+		return nil, err
+	}
+	p.skipNewlines()
+	if err := p.expectSymbol("]"); err != nil {
+		return nil, err
+	}
+	loop.span = span{start.line, p.previousLine()}
+	return loop, nil
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
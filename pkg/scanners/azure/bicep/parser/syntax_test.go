@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/azure/functions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Expressions(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected interface{}
+	}{
+		{
+			name:     "escaped string",
+			input:    `'it\'s a \${literal}\n'`,
+			expected: "it's a ${literal}\n",
+		},
+		{
+			name:     "interpolation with nested strings",
+			input:    `'${toUpper('a')}-${'b'}'`,
+			expected: "A-b",
+		},
+		{
+			name:     "multi-line string",
+			input:    "'''\nline one\nline ${two}'''",
+			expected: "line one\nline ${two}",
+		},
+		{
+			name:     "operator precedence",
+			input:    `1 + 2 * 3 == 7 && !(4 < 3)`,
+			expected: true,
+		},
+		{
+			name:     "case insensitive comparison",
+			input:    `'ABC' =~ 'abc'`,
+			expected: true,
+		},
+		{
+			name:     "coalesce and safe access",
+			input:    `{ a: null }.?b ?? 'default'`,
+			expected: "default",
+		},
+		{
+			name:     "safe index",
+			input:    `[1, 2][?5] ?? [1, 2][?1]`,
+			expected: int64(2),
+		},
+		{
+			name:     "namespaced function",
+			input:    `sys.concat([1], [2])[1]`,
+			expected: int64(2),
+		},
+		{
+			name:     "loop with condition",
+			input:    `length([for i in range(0, 10): if (i % 2 == 0) i])`,
+			expected: int64(5),
+		},
+		{
+			name:     "lambda is unresolvable",
+			input:    `filter([1, 2], x => x > 1)`,
+			expected: functions.Unresolvable{},
+		},
+		{
+			name: "multi-line object with comments",
+			input: `{
+  // a comment
+  a: 'x' /* another
+  comment */
+  'b-c': [
+    1
+    2
+  ]
+}['b-c'][1]`,
+			expected: int64(2),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := parseSyntax("test.bicep", "var test = "+test.input)
+			require.NoError(t, err)
+			require.Len(t, f.vars, 1)
+			e := newEvaluator(New(), f, nil, nil, 0)
+			assert.Equal(t, test.expected, e.GetVariable("test"))
+		})
+	}
+}
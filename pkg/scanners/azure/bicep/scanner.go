@@ -0,0 +1,184 @@
+package bicep
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"strings"
+	"sync"
+
+	adapter "github.com/aquasecurity/defsec/internal/adapters/arm"
+	"github.com/aquasecurity/defsec/internal/rules"
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/ignore"
+	"github.com/aquasecurity/defsec/pkg/rego"
+	_ "github.com/aquasecurity/defsec/pkg/rules"
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/scanners"
+	"github.com/aquasecurity/defsec/pkg/scanners/azure"
+	"github.com/aquasecurity/defsec/pkg/scanners/azure/bicep/parser"
+)
+
+var _ scanners.Scanner = (*Scanner)(nil)
+
+type Scanner struct {
+	includePassed    bool
+	excludedRuleIDs  []string
+	debugWriter      io.Writer
+	traceWriter      io.Writer
+	policyDirs       []string
+	dataDirs         []string
+	policyNamespaces []string
+	parserOptions    []parser.Option
+	regoScanner      *rego.Scanner
+	suppressions     ignore.Suppressions
+	reportStale      bool
+	sync.Mutex
+}
+
+// New creates a new Scanner
+func New(options ...Option) *Scanner {
+	s := &Scanner{}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+func (s *Scanner) debug(format string, args ...interface{}) {
+	if s.debugWriter == nil {
+		return
+	}
+	prefix := "[debug:scan:bicep] "
+	_, _ = s.debugWriter.Write([]byte(fmt.Sprintf(prefix+format+"\n", args...)))
+}
+
+func (s *Scanner) initRegoScanner(srcFS fs.FS) (*rego.Scanner, error) {
+	s.Lock()
+	defer s.Unlock()
+	if s.regoScanner != nil {
+		return s.regoScanner, nil
+	}
+	regoOpts := []rego.Option{
+		rego.OptionWithPolicyNamespaces(true, s.policyNamespaces...),
+		rego.OptionWithDataDirs(s.dataDirs...),
+	}
+	if s.traceWriter != nil {
+		regoOpts = append(regoOpts, rego.OptionWithTrace(s.traceWriter))
+	}
+	regoScanner := rego.NewScanner(regoOpts...)
+	if err := regoScanner.LoadPolicies(true, srcFS, s.policyDirs, nil); err != nil {
+		return nil, err
+	}
+	s.regoScanner = regoScanner
+	return regoScanner, nil
+}
+
+func (s *Scanner) ScanFS(ctx context.Context, target fs.FS, dir string) (scan.Results, error) {
+	deployments, err := parser.New(s.parserOptions...).ParseFS(ctx, target, dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(deployments) == 0 {
+		return nil, nil
+	}
+
+	regoScanner, err := s.initRegoScanner(target)
+	if err != nil {
+		return nil, err
+	}
+
+	var results scan.Results
+	for _, deployment := range deployments {
+		deploymentResults, err := s.scanDeployment(ctx, regoScanner, target, deployment)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, deploymentResults...)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Rule().AVDID < results[j].Rule().AVDID
+	})
+	return results, nil
+}
+
+func (s *Scanner) ScanFile(ctx context.Context, target fs.FS, path string) (scan.Results, error) {
+	deployment, err := parser.New(s.parserOptions...).ParseFile(ctx, target, path)
+	if err != nil {
+		return nil, err
+	}
+
+	regoScanner, err := s.initRegoScanner(target)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.scanDeployment(ctx, regoScanner, target, *deployment)
+	if err != nil {
+		return nil, err
+	}
+	results.SetSourceAndFilesystem("", target)
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Rule().AVDID < results[j].Rule().AVDID
+	})
+	return results, nil
+}
+
+func (s *Scanner) scanDeployment(ctx context.Context, regoScanner *rego.Scanner, target fs.FS, deployment azure.Deployment) (scan.Results, error) {
+	var results scan.Results
+	state := adapter.Adapt(ctx, deployment)
+	directives := ignore.ParseFS(target, deployment.Metadata.Range().GetLocalFilename())
+	var used ignore.Directives
+	var known []string
+	for _, rule := range rules.GetRegistered() {
+		known = append(known, rule.Rule().IDs()...)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		evalResult := rule.Evaluate(state)
+		if len(evalResult) > 0 {
+			s.debug("Found %d results for %s", len(evalResult), rule.Rule().AVDID)
+			used = append(used, evalResult.ApplyIgnores(directives)...)
+			evalResult.ApplySuppressions(s.suppressions)
+			for _, scanResult := range evalResult {
+				if s.isExcluded(scanResult) {
+					scanResult.OverrideStatus(scan.StatusIgnored)
+				}
+				if scanResult.Status() == scan.StatusPassed && !s.includePassed {
+					continue
+				}
+				results = append(results, scanResult)
+			}
+		}
+	}
+
+	regoResults, err := regoScanner.ScanInput(ctx, rego.Input{
+		Path:     deployment.Metadata.Range().GetFilename(),
+		Contents: state,
+		Type:     types.SourceDefsec,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rego scan error: %w", err)
+	}
+	used = append(used, regoResults.ApplyIgnores(directives)...)
+	regoResults.ApplySuppressions(s.suppressions)
+	results = append(results, regoResults...)
+	if s.reportStale {
+		results.AddStaleIgnores(directives, used, known...)
+	}
+	return results, nil
+}
+
+func (s *Scanner) isExcluded(result scan.Result) bool {
+	for _, excluded := range s.excludedRuleIDs {
+		if strings.EqualFold(excluded, result.Flatten().RuleID) {
+			return true
+		}
+	}
+	return false
+}
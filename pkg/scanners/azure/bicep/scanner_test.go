@@ -0,0 +1,68 @@
+package bicep
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ScanBicep(t *testing.T) {
+
+	results, err := New().ScanFS(context.TODO(), os.DirFS("test/testdata"), ".")
+	require.NoError(t, err)
+
+	failed := make(map[string][]string)
+	for _, result := range results.GetFailed() {
+		failed[result.Rule().AVDID] = append(failed[result.Rule().AVDID], result.Flatten().Resource)
+	}
+
+	// https enforcement
+	assert.Equal(t, []string{"logs"}, failed["AVD-AZU-0008"])
+
+	// minimum tls version, resolved from the parameter default
+	assert.Equal(t, []string{"logs"}, failed["AVD-AZU-0011"])
+
+	// public access on the nested container
+	assert.Equal(t, []string{"logs::blobs::container"}, failed["AVD-AZU-0007"])
+
+	// network rules deny by default and allow azure services
+	assert.Empty(t, failed["AVD-AZU-0010"])
+	assert.Empty(t, failed["AVD-AZU-0012"])
+}
+
+func Test_ResultRange(t *testing.T) {
+
+	results, err := New().ScanFile(context.TODO(), os.DirFS("test/testdata"), "main.bicep")
+	require.NoError(t, err)
+
+	var found bool
+	for _, result := range results.GetFailed() {
+		if result.Rule().AVDID != "AVD-AZU-0008" {
+			continue
+		}
+		found = true
+		assert.Equal(t, 11, result.Range().GetStartLine())
+		assert.Equal(t, 11, result.Range().GetEndLine())
+	}
+	assert.True(t, found)
+}
+
+func Test_ModuleResultsPointAtModuleFile(t *testing.T) {
+
+	results, err := New().ScanFS(context.TODO(), os.DirFS("test/testdata"), ".")
+	require.NoError(t, err)
+
+	var found bool
+	for _, result := range results.GetFailed() {
+		if result.Flatten().Resource != "account" {
+			continue
+		}
+		found = true
+		assert.Equal(t, "modules/storage.bicep", result.Range().GetFilename())
+		assert.Equal(t, 4, result.Range().GetStartLine())
+	}
+	assert.True(t, found)
+}
@@ -0,0 +1,84 @@
+package azure
+
+import (
+	"strings"
+
+	"github.com/aquasecurity/defsec/internal/types"
+)
+
+// Deployment is the source-independent representation of an Azure deployment, as produced from an ARM template
+// or a Bicep file, with all template expressions resolved where possible
+type Deployment struct {
+	types.Metadata
+	Parameters []Parameter
+	Variables  []Variable
+	Resources  []Resource
+	Outputs    []Output
+}
+
+type Parameter struct {
+	types.Metadata
+	Name         string
+	Type         Value
+	DefaultValue Value
+}
+
+type Variable struct {
+	types.Metadata
+	Name  string
+	Value Value
+}
+
+type Output struct {
+	types.Metadata
+	Name  string
+	Value Value
+}
+
+type Resource struct {
+	types.Metadata
+	APIVersion Value
+	Type       Value
+	Kind       Value
+	Name       Value
+	Location   Value
+	Tags       Value
+	Sku        Value
+	Identity   Value
+	Properties Value
+	Resources  []Resource
+}
+
+// GetResourcesByType returns all resources (including nested child resources) of the given type, e.g.
+// Microsoft.Storage/storageAccounts. Types are compared case-insensitively, as they are by Azure.
+func (d *Deployment) GetResourcesByType(resourceType string) []Resource {
+	var resources []Resource
+	for _, resource := range d.Resources {
+		resources = append(resources, resource.getResourcesByType(resourceType)...)
+	}
+	return resources
+}
+
+func (r Resource) getResourcesByType(resourceType string) []Resource {
+	var resources []Resource
+	if strings.EqualFold(r.Type.AsString(), resourceType) {
+		resources = append(resources, r)
+	}
+	for _, child := range r.Resources {
+		resources = append(resources, child.getResourcesByType(resourceType)...)
+	}
+	return resources
+}
+
+// GetChildResources returns resources of the given type which belong to the given parent resource, either by
+// nesting or because their name is prefixed with the name of the parent
+func (d *Deployment) GetChildResources(parent Resource, resourceType string) []Resource {
+	var children []Resource
+	prefix := parent.Name.AsString() + "/"
+	for _, resource := range d.GetResourcesByType(resourceType) {
+		if strings.HasPrefix(resource.Name.AsString(), prefix) {
+			children = append(children, resource)
+		}
+	}
+	return children
+}
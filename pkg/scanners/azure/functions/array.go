@@ -0,0 +1,259 @@
+package functions
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+func toArray(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("array", args, 1); err != nil {
+		return nil, err
+	}
+	if list, ok := args[0].([]interface{}); ok {
+		return list, nil
+	}
+	return []interface{}{args[0]}, nil
+}
+
+func createArray(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	output := []interface{}{}
+	return append(output, args...), nil
+}
+
+func createObject(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if len(args)%2 != 0 {
+		return nil, fmt.Errorf("createObject() requires an even number of arguments")
+	}
+	output := make(map[string]interface{})
+	for i := 0; i < len(args); i += 2 {
+		output[toString(args[i])] = args[i+1]
+	}
+	return output, nil
+}
+
+func empty(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("empty", args, 1); err != nil {
+		return nil, err
+	}
+	switch typed := args[0].(type) {
+	case nil:
+		return true, nil
+	case string:
+		return typed == "", nil
+	case []interface{}:
+		return len(typed) == 0, nil
+	case map[string]interface{}:
+		return len(typed) == 0, nil
+	default:
+		return false, nil
+	}
+}
+
+func length(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("length", args, 1); err != nil {
+		return nil, err
+	}
+	switch typed := args[0].(type) {
+	case string:
+		return int64(len(typed)), nil
+	case []interface{}:
+		return int64(len(typed)), nil
+	case map[string]interface{}:
+		return int64(len(typed)), nil
+	default:
+		return int64(0), nil
+	}
+}
+
+func contains(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("contains", args, 2); err != nil {
+		return nil, err
+	}
+	switch typed := args[0].(type) {
+	case string:
+		return strings.Contains(typed, toString(args[1])), nil
+	case []interface{}:
+		for _, item := range typed {
+			if isEqual(item, args[1]) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case map[string]interface{}:
+		_, ok := typed[toString(args[1])]
+		return ok, nil
+	default:
+		return false, nil
+	}
+}
+
+func first(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("first", args, 1); err != nil {
+		return nil, err
+	}
+	switch typed := args[0].(type) {
+	case string:
+		if typed == "" {
+			return "", nil
+		}
+		return typed[:1], nil
+	case []interface{}:
+		if len(typed) == 0 {
+			return nil, nil
+		}
+		return typed[0], nil
+	default:
+		return nil, nil
+	}
+}
+
+func last(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("last", args, 1); err != nil {
+		return nil, err
+	}
+	switch typed := args[0].(type) {
+	case string:
+		if typed == "" {
+			return "", nil
+		}
+		return typed[len(typed)-1:], nil
+	case []interface{}:
+		if len(typed) == 0 {
+			return nil, nil
+		}
+		return typed[len(typed)-1], nil
+	default:
+		return nil, nil
+	}
+}
+
+func skip(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("skip", args, 2); err != nil {
+		return nil, err
+	}
+	count := toInteger(args[1])
+	if count < 0 {
+		count = 0
+	}
+	switch typed := args[0].(type) {
+	case string:
+		if count > len(typed) {
+			return "", nil
+		}
+		return typed[count:], nil
+	case []interface{}:
+		if count > len(typed) {
+			return []interface{}{}, nil
+		}
+		return typed[count:], nil
+	default:
+		return args[0], nil
+	}
+}
+
+func take(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("take", args, 2); err != nil {
+		return nil, err
+	}
+	count := toInteger(args[1])
+	if count < 0 {
+		count = 0
+	}
+	switch typed := args[0].(type) {
+	case string:
+		if count > len(typed) {
+			return typed, nil
+		}
+		return typed[:count], nil
+	case []interface{}:
+		if count > len(typed) {
+			return typed, nil
+		}
+		return typed[:count], nil
+	default:
+		return args[0], nil
+	}
+}
+
+func union(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("union", args, 1); err != nil {
+		return nil, err
+	}
+	if _, ok := args[0].(map[string]interface{}); ok {
+		output := make(map[string]interface{})
+		for _, arg := range args {
+			if obj, ok := arg.(map[string]interface{}); ok {
+				for key, value := range obj {
+					output[key] = value
+				}
+			}
+		}
+		return output, nil
+	}
+	output := []interface{}{}
+	for _, arg := range args {
+		list, _ := arg.([]interface{})
+	items:
+		for _, item := range list {
+			for _, existing := range output {
+				if isEqual(existing, item) {
+					continue items
+				}
+			}
+			output = append(output, item)
+		}
+	}
+	return output, nil
+}
+
+func toJSON(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("json", args, 1); err != nil {
+		return nil, err
+	}
+	var output interface{}
+	if err := json.Unmarshal([]byte(toString(args[0])), &output); err != nil {
+		return nil, fmt.Errorf("json() argument is not valid JSON: %w", err)
+	}
+	return output, nil
+}
+
+func rangeFunc(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("range", args, 2); err != nil {
+		return nil, err
+	}
+	start, count := toInteger(args[0]), toInteger(args[1])
+	if count < 0 {
+		return nil, fmt.Errorf("range() count must not be negative")
+	}
+	output := make([]interface{}, 0, count)
+	for i := 0; i < count; i++ {
+		output = append(output, int64(start+i))
+	}
+	return output, nil
+}
+
+// items converts an object into an array of key/value objects, sorted by key
+func items(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("items", args, 1); err != nil {
+		return nil, err
+	}
+	obj, ok := args[0].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("items() requires an object")
+	}
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	output := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		output = append(output, map[string]interface{}{
+			"key":   key,
+			"value": obj[key],
+		})
+	}
+	return output, nil
+}
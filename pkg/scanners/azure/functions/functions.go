@@ -0,0 +1,137 @@
+package functions
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Unresolvable is returned by functions whose result can only be known at deployment time, e.g. reference()
+type Unresolvable struct{}
+
+// DeploymentData provides the deployment-specific values required by some template functions
+type DeploymentData interface {
+	GetParameter(name string) interface{}
+	GetVariable(name string) interface{}
+	GetScope() Scope
+}
+
+// Scope describes the (fake) environment a deployment is evaluated in
+type Scope struct {
+	SubscriptionID    string
+	TenantID          string
+	ResourceGroupName string
+	Location          string
+	DeploymentName    string
+}
+
+// DefaultScope is used when no specific scope is provided
+var DefaultScope = Scope{
+	SubscriptionID:    "00000000-0000-0000-0000-000000000000",
+	TenantID:          "00000000-0000-0000-0000-000000000000",
+	ResourceGroupName: "resourceGroup",
+	Location:          "westeurope",
+	DeploymentName:    "deployment",
+}
+
+type function func(dd DeploymentData, args ...interface{}) (interface{}, error)
+
+var registry = map[string]function{
+	"and":                    and,
+	"array":                  toArray,
+	"base64":                 base64Encode,
+	"bool":                   toBool,
+	"coalesce":               coalesce,
+	"concat":                 concat,
+	"contains":               contains,
+	"createarray":            createArray,
+	"createobject":           createObject,
+	"deployment":             deployment,
+	"empty":                  empty,
+	"endswith":               endsWith,
+	"equals":                 equals,
+	"false":                  constant(false),
+	"first":                  first,
+	"format":                 format,
+	"greater":                greater,
+	"greaterorequals":        greaterOrEquals,
+	"guid":                   guid,
+	"if":                     ifFunc,
+	"int":                    toInt,
+	"items":                  items,
+	"json":                   toJSON,
+	"last":                   last,
+	"length":                 length,
+	"less":                   less,
+	"lessorequals":           lessOrEquals,
+	"not":                    not,
+	"null":                   constant(nil),
+	"or":                     or,
+	"parameters":             parameters,
+	"range":                  rangeFunc,
+	"replace":                replace,
+	"resourcegroup":          resourceGroup,
+	"resourceid":             resourceID,
+	"skip":                   skip,
+	"split":                  split,
+	"startswith":             startsWith,
+	"string":                 toStringFunc,
+	"subscription":           subscription,
+	"subscriptionresourceid": subscriptionResourceID,
+	"substring":              substring,
+	"take":                   take,
+	"tolower":                toLower,
+	"toupper":                toUpper,
+	"trim":                   trim,
+	"true":                   constant(true),
+	"union":                  union,
+	"uniquestring":           uniqueString,
+	"variables":              variables,
+}
+
+// Evaluate calls the template function with the given (case-insensitive) name. Functions which are not supported,
+// or which can only be evaluated at deployment time, return Unresolvable.
+func Evaluate(dd DeploymentData, name string, args ...interface{}) (interface{}, error) {
+	fn, ok := registry[strings.ToLower(name)]
+	if !ok {
+		return Unresolvable{}, nil
+	}
+	for _, arg := range args {
+		if _, unresolvable := arg.(Unresolvable); unresolvable {
+			return Unresolvable{}, nil
+		}
+	}
+	return fn(dd, args...)
+}
+
+// IsSupported returns true if the named function can be evaluated
+func IsSupported(name string) bool {
+	_, ok := registry[strings.ToLower(name)]
+	return ok
+}
+
+func constant(value interface{}) function {
+	return func(_ DeploymentData, _ ...interface{}) (interface{}, error) {
+		return value, nil
+	}
+}
+
+func requireArgs(name string, args []interface{}, min int) error {
+	if len(args) < min {
+		return fmt.Errorf("%s() requires at least %d argument(s), %d provided", name, min, len(args))
+	}
+	return nil
+}
+
+func parameters(dd DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("parameters", args, 1); err != nil {
+		return nil, err
+	}
+	return dd.GetParameter(toString(args[0])), nil
+}
+
+func variables(dd DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("variables", args, 1); err != nil {
+		return nil, err
+	}
+	return dd.GetVariable(toString(args[0])), nil
+}
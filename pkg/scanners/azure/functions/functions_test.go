@@ -0,0 +1,92 @@
+package functions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testDeploymentData struct{}
+
+func (testDeploymentData) GetParameter(_ string) interface{} { return nil }
+func (testDeploymentData) GetVariable(_ string) interface{}  { return nil }
+func (testDeploymentData) GetScope() Scope                   { return DefaultScope }
+
+func Test_Evaluate(t *testing.T) {
+	tests := []struct {
+		name     string
+		function string
+		args     []interface{}
+		expected interface{}
+	}{
+		{
+			name:     "resource id in current resource group",
+			function: "resourceId",
+			args:     []interface{}{"Microsoft.Network/virtualNetworks/subnets", "vnet", "default"},
+			expected: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/resourceGroup/providers/Microsoft.Network/virtualNetworks/vnet/subnets/default",
+		},
+		{
+			name:     "resource id in another resource group",
+			function: "resourceId",
+			args:     []interface{}{"other", "Microsoft.Storage/storageAccounts", "logs"},
+			expected: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/other/providers/Microsoft.Storage/storageAccounts/logs",
+		},
+		{
+			name:     "case insensitive name",
+			function: "CONCAT",
+			args:     []interface{}{"a", int64(1)},
+			expected: "a1",
+		},
+		{
+			name:     "concat arrays",
+			function: "concat",
+			args:     []interface{}{[]interface{}{"a"}, []interface{}{"b"}},
+			expected: []interface{}{"a", "b"},
+		},
+		{
+			name:     "range",
+			function: "range",
+			args:     []interface{}{int64(2), int64(3)},
+			expected: []interface{}{int64(2), int64(3), int64(4)},
+		},
+		{
+			name:     "items sorted by key",
+			function: "items",
+			args:     []interface{}{map[string]interface{}{"b": int64(2), "a": int64(1)}},
+			expected: []interface{}{
+				map[string]interface{}{"key": "a", "value": int64(1)},
+				map[string]interface{}{"key": "b", "value": int64(2)},
+			},
+		},
+		{
+			name:     "unresolvable argument",
+			function: "toLower",
+			args:     []interface{}{Unresolvable{}},
+			expected: Unresolvable{},
+		},
+		{
+			name:     "unsupported function",
+			function: "listKeys",
+			args:     []interface{}{"x"},
+			expected: Unresolvable{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := Evaluate(testDeploymentData{}, test.function, test.args...)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func Test_UniqueStringIsDeterministic(t *testing.T) {
+	first, err := Evaluate(testDeploymentData{}, "uniqueString", "a", "b")
+	require.NoError(t, err)
+	second, err := Evaluate(testDeploymentData{}, "uniqueString", "a", "b")
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Len(t, first, 13)
+}
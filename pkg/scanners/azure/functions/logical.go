@@ -0,0 +1,188 @@
+package functions
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+func toBoolean(raw interface{}) bool {
+	switch typed := raw.(type) {
+	case bool:
+		return typed
+	case string:
+		return strings.EqualFold(typed, "true")
+	case int64:
+		return typed != 0
+	case float64:
+		return typed != 0
+	case int:
+		return typed != 0
+	default:
+		return false
+	}
+}
+
+func toInteger(raw interface{}) int {
+	switch typed := raw.(type) {
+	case int:
+		return typed
+	case int64:
+		return int(typed)
+	case float64:
+		return int(typed)
+	case string:
+		i, _ := strconv.Atoi(typed)
+		return i
+	case bool:
+		if typed {
+			return 1
+		}
+		return 0
+	default:
+		return 0
+	}
+}
+
+func toBool(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("bool", args, 1); err != nil {
+		return nil, err
+	}
+	return toBoolean(args[0]), nil
+}
+
+func toInt(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("int", args, 1); err != nil {
+		return nil, err
+	}
+	return int64(toInteger(args[0])), nil
+}
+
+func and(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("and", args, 2); err != nil {
+		return nil, err
+	}
+	for _, arg := range args {
+		if !toBoolean(arg) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func or(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("or", args, 2); err != nil {
+		return nil, err
+	}
+	for _, arg := range args {
+		if toBoolean(arg) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func not(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("not", args, 1); err != nil {
+		return nil, err
+	}
+	return !toBoolean(args[0]), nil
+}
+
+func ifFunc(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("if", args, 3); err != nil {
+		return nil, err
+	}
+	if toBoolean(args[0]) {
+		return args[1], nil
+	}
+	return args[2], nil
+}
+
+func equals(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("equals", args, 2); err != nil {
+		return nil, err
+	}
+	return isEqual(args[0], args[1]), nil
+}
+
+func isEqual(a interface{}, b interface{}) bool {
+	if isNumber(a) && isNumber(b) {
+		return toFloat(a) == toFloat(b)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func isNumber(raw interface{}) bool {
+	switch raw.(type) {
+	case int, int64, float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func toFloat(raw interface{}) float64 {
+	switch typed := raw.(type) {
+	case int:
+		return float64(typed)
+	case int64:
+		return float64(typed)
+	case float64:
+		return typed
+	default:
+		return 0
+	}
+}
+
+func compare(args []interface{}) int {
+	if isNumber(args[0]) && isNumber(args[1]) {
+		a, b := toFloat(args[0]), toFloat(args[1])
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return strings.Compare(toString(args[0]), toString(args[1]))
+}
+
+func greater(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("greater", args, 2); err != nil {
+		return nil, err
+	}
+	return compare(args) > 0, nil
+}
+
+func greaterOrEquals(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("greaterOrEquals", args, 2); err != nil {
+		return nil, err
+	}
+	return compare(args) >= 0, nil
+}
+
+func less(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("less", args, 2); err != nil {
+		return nil, err
+	}
+	return compare(args) < 0, nil
+}
+
+func lessOrEquals(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("lessOrEquals", args, 2); err != nil {
+		return nil, err
+	}
+	return compare(args) <= 0, nil
+}
+
+func coalesce(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	for _, arg := range args {
+		if arg != nil {
+			return arg, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,114 @@
+package functions
+
+import (
+	"fmt"
+	"strings"
+)
+
+// resourceID implements resourceId([subscriptionId], [resourceGroupName], resourceType, resourceName1, [resourceName2], ...)
+func resourceID(dd DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("resourceId", args, 2); err != nil {
+		return nil, err
+	}
+	scope := dd.GetScope()
+	subscriptionID := scope.SubscriptionID
+	resourceGroupName := scope.ResourceGroupName
+
+	typeIndex := findResourceTypeIndex(args)
+	if typeIndex < 0 {
+		return nil, fmt.Errorf("resourceId() requires a resource type")
+	}
+	switch typeIndex {
+	case 0:
+	case 1:
+		resourceGroupName = toString(args[0])
+	default:
+		subscriptionID = toString(args[typeIndex-2])
+		resourceGroupName = toString(args[typeIndex-1])
+	}
+
+	path, err := resourcePath(toString(args[typeIndex]), args[typeIndex+1:])
+	if err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/%s", subscriptionID, resourceGroupName, path), nil
+}
+
+// subscriptionResourceID implements subscriptionResourceId([subscriptionId], resourceType, resourceName1, ...)
+func subscriptionResourceID(dd DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("subscriptionResourceId", args, 2); err != nil {
+		return nil, err
+	}
+	subscriptionID := dd.GetScope().SubscriptionID
+	typeIndex := findResourceTypeIndex(args)
+	if typeIndex < 0 {
+		return nil, fmt.Errorf("subscriptionResourceId() requires a resource type")
+	}
+	if typeIndex > 0 {
+		subscriptionID = toString(args[typeIndex-1])
+	}
+	path, err := resourcePath(toString(args[typeIndex]), args[typeIndex+1:])
+	if err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf("/subscriptions/%s/providers/%s", subscriptionID, path), nil
+}
+
+// findResourceTypeIndex finds the first argument which looks like a resource type, e.g. Microsoft.Storage/storageAccounts
+func findResourceTypeIndex(args []interface{}) int {
+	for i, arg := range args {
+		str := toString(arg)
+		if strings.Contains(str, "/") && strings.Contains(strings.Split(str, "/")[0], ".") {
+			return i
+		}
+	}
+	return -1
+}
+
+// resourcePath interleaves the segments of a (possibly nested) resource type with the resource names
+func resourcePath(resourceType string, names []interface{}) (string, error) {
+	segments := strings.Split(resourceType, "/")
+	if len(segments) < 2 {
+		return "", fmt.Errorf("invalid resource type '%s'", resourceType)
+	}
+	if len(names) != len(segments)-1 {
+		return "", fmt.Errorf("resource type '%s' requires %d name(s), %d provided", resourceType, len(segments)-1, len(names))
+	}
+	parts := []string{segments[0]}
+	for i, name := range names {
+		parts = append(parts, segments[i+1], toString(name))
+	}
+	return strings.Join(parts, "/"), nil
+}
+
+func resourceGroup(dd DeploymentData, _ ...interface{}) (interface{}, error) {
+	scope := dd.GetScope()
+	return map[string]interface{}{
+		"id":       fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", scope.SubscriptionID, scope.ResourceGroupName),
+		"name":     scope.ResourceGroupName,
+		"type":     "Microsoft.Resources/resourceGroups",
+		"location": scope.Location,
+		"properties": map[string]interface{}{
+			"provisioningState": "Succeeded",
+		},
+	}, nil
+}
+
+func subscription(dd DeploymentData, _ ...interface{}) (interface{}, error) {
+	scope := dd.GetScope()
+	return map[string]interface{}{
+		"id":             fmt.Sprintf("/subscriptions/%s", scope.SubscriptionID),
+		"subscriptionId": scope.SubscriptionID,
+		"tenantId":       scope.TenantID,
+		"displayName":    "subscription",
+	}, nil
+}
+
+func deployment(dd DeploymentData, _ ...interface{}) (interface{}, error) {
+	scope := dd.GetScope()
+	return map[string]interface{}{
+		"name":       scope.DeploymentName,
+		"location":   scope.Location,
+		"properties": map[string]interface{}{},
+	}, nil
+}
@@ -0,0 +1,205 @@
+package functions
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+func toString(raw interface{}) string {
+	switch typed := raw.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case bool:
+		return strconv.FormatBool(typed)
+	case int:
+		return strconv.Itoa(typed)
+	case int64:
+		return strconv.FormatInt(typed, 10)
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	default:
+		data, err := json.Marshal(typed)
+		if err != nil {
+			return fmt.Sprintf("%v", typed)
+		}
+		return string(data)
+	}
+}
+
+func toStringFunc(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("string", args, 1); err != nil {
+		return nil, err
+	}
+	return toString(args[0]), nil
+}
+
+func concat(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if len(args) > 0 {
+		if _, ok := args[0].([]interface{}); ok {
+			var output []interface{}
+			for _, arg := range args {
+				if list, ok := arg.([]interface{}); ok {
+					output = append(output, list...)
+				} else {
+					output = append(output, arg)
+				}
+			}
+			return output, nil
+		}
+	}
+	var sb strings.Builder
+	for _, arg := range args {
+		sb.WriteString(toString(arg))
+	}
+	return sb.String(), nil
+}
+
+var formatPlaceholder = regexp.MustCompile(`{(\d+)(:[^}]*)?}`)
+
+// format supports the positional placeholders of the .NET composite format used by ARM, without format specifiers
+func format(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("format", args, 1); err != nil {
+		return nil, err
+	}
+	formatString := toString(args[0])
+	formatArgs := args[1:]
+	return formatPlaceholder.ReplaceAllStringFunc(formatString, func(match string) string {
+		index, err := strconv.Atoi(formatPlaceholder.FindStringSubmatch(match)[1])
+		if err != nil || index >= len(formatArgs) {
+			return match
+		}
+		return toString(formatArgs[index])
+	}), nil
+}
+
+func toLower(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("toLower", args, 1); err != nil {
+		return nil, err
+	}
+	return strings.ToLower(toString(args[0])), nil
+}
+
+func toUpper(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("toUpper", args, 1); err != nil {
+		return nil, err
+	}
+	return strings.ToUpper(toString(args[0])), nil
+}
+
+func trim(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("trim", args, 1); err != nil {
+		return nil, err
+	}
+	return strings.TrimSpace(toString(args[0])), nil
+}
+
+func replace(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("replace", args, 3); err != nil {
+		return nil, err
+	}
+	return strings.ReplaceAll(toString(args[0]), toString(args[1]), toString(args[2])), nil
+}
+
+func split(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("split", args, 2); err != nil {
+		return nil, err
+	}
+	input := toString(args[0])
+	var delimiters []string
+	if list, ok := args[1].([]interface{}); ok {
+		for _, item := range list {
+			delimiters = append(delimiters, toString(item))
+		}
+	} else {
+		delimiters = append(delimiters, toString(args[1]))
+	}
+	parts := []string{input}
+	for _, delimiter := range delimiters {
+		var next []string
+		for _, part := range parts {
+			next = append(next, strings.Split(part, delimiter)...)
+		}
+		parts = next
+	}
+	var output []interface{}
+	for _, part := range parts {
+		output = append(output, part)
+	}
+	return output, nil
+}
+
+func substring(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("substring", args, 2); err != nil {
+		return nil, err
+	}
+	input := toString(args[0])
+	start := toInteger(args[1])
+	if start < 0 || start > len(input) {
+		return nil, fmt.Errorf("substring() start index %d is out of range", start)
+	}
+	end := len(input)
+	if len(args) > 2 {
+		end = start + toInteger(args[2])
+	}
+	if end < start || end > len(input) {
+		return nil, fmt.Errorf("substring() length is out of range")
+	}
+	return input[start:end], nil
+}
+
+func startsWith(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("startsWith", args, 2); err != nil {
+		return nil, err
+	}
+	return strings.HasPrefix(strings.ToLower(toString(args[0])), strings.ToLower(toString(args[1]))), nil
+}
+
+func endsWith(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("endsWith", args, 2); err != nil {
+		return nil, err
+	}
+	return strings.HasSuffix(strings.ToLower(toString(args[0])), strings.ToLower(toString(args[1]))), nil
+}
+
+func base64Encode(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("base64", args, 1); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.EncodeToString([]byte(toString(args[0]))), nil
+}
+
+// uniqueString returns a deterministic 13 character hash of the arguments. The value will not match the one
+// generated by Azure, but is stable for the same inputs, which is what templates rely on.
+func uniqueString(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("uniqueString", args, 1); err != nil {
+		return nil, err
+	}
+	var parts []string
+	for _, arg := range args {
+		parts = append(parts, toString(arg))
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "-")))
+	return strings.ToLower(base32.StdEncoding.EncodeToString(sum[:]))[:13], nil
+}
+
+// guid returns a deterministic GUID derived from the arguments
+func guid(_ DeploymentData, args ...interface{}) (interface{}, error) {
+	if err := requireArgs("guid", args, 1); err != nil {
+		return nil, err
+	}
+	var parts []string
+	for _, arg := range args {
+		parts = append(parts, toString(arg))
+	}
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(strings.Join(parts, "-"))).String(), nil
+}
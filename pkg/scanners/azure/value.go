@@ -0,0 +1,304 @@
+package azure
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/defsec/internal/types"
+)
+
+type Kind string
+
+const (
+	KindUnresolvable Kind = "unresolvable"
+	KindNull         Kind = "null"
+	KindBoolean      Kind = "boolean"
+	KindString       Kind = "string"
+	KindNumber       Kind = "number"
+	KindObject       Kind = "object"
+	KindArray        Kind = "array"
+)
+
+// Value is a resolved value from an Azure deployment source (ARM template, Bicep file etc.) along with the
+// location it was defined at
+type Value struct {
+	types.Metadata
+	kind Kind
+	rLit interface{}
+	rMap map[string]Value
+	rArr []Value
+}
+
+var NullValue = Value{
+	kind: KindNull,
+}
+
+// NewValue creates a value from a raw go value (as produced by encoding/json), recursively applying the metadata
+func NewValue(raw interface{}, metadata types.Metadata) Value {
+	v := Value{
+		Metadata: metadata,
+	}
+	switch typed := raw.(type) {
+	case nil:
+		v.kind = KindNull
+	case bool:
+		v.kind = KindBoolean
+		v.rLit = typed
+	case string:
+		v.kind = KindString
+		v.rLit = typed
+	case int:
+		v.kind = KindNumber
+		v.rLit = int64(typed)
+	case int64, float64:
+		v.kind = KindNumber
+		v.rLit = typed
+	case map[string]interface{}:
+		v.kind = KindObject
+		v.rMap = make(map[string]Value, len(typed))
+		for key, item := range typed {
+			v.rMap[key] = NewValue(item, metadata)
+		}
+	case map[string]Value:
+		v.kind = KindObject
+		v.rMap = typed
+	case []interface{}:
+		v.kind = KindArray
+		for _, item := range typed {
+			v.rArr = append(v.rArr, NewValue(item, metadata))
+		}
+	case []Value:
+		v.kind = KindArray
+		v.rArr = typed
+	case Value:
+		return typed
+	default:
+		v.kind = KindUnresolvable
+	}
+	return v
+}
+
+// NewUnresolvedValue creates a value which could not be resolved, e.g. a reference to a deployed resource
+func NewUnresolvedValue(metadata types.Metadata) Value {
+	return Value{
+		Metadata: metadata,
+		kind:     KindUnresolvable,
+	}
+}
+
+func (v Value) Kind() Kind {
+	return v.kind
+}
+
+func (v Value) IsNull() bool {
+	return v.kind == KindNull || v.kind == ""
+}
+
+func (v Value) IsResolved() bool {
+	return v.kind != KindUnresolvable
+}
+
+// Raw returns the value as a plain go value, e.g. for passing to template functions
+func (v Value) Raw() interface{} {
+	switch v.kind {
+	case KindObject:
+		raw := make(map[string]interface{}, len(v.rMap))
+		for key, item := range v.rMap {
+			raw[key] = item.Raw()
+		}
+		return raw
+	case KindArray:
+		raw := make([]interface{}, 0, len(v.rArr))
+		for _, item := range v.rArr {
+			raw = append(raw, item.Raw())
+		}
+		return raw
+	default:
+		return v.rLit
+	}
+}
+
+// GetMapValue returns the property with the given key (case-insensitive, as with ARM), or a null value. The properties
+// of an unresolved value are also unresolved.
+func (v Value) GetMapValue(key string) Value {
+	if v.kind == KindUnresolvable {
+		return NewUnresolvedValue(v.Metadata)
+	}
+	if v.kind != KindObject {
+		return NullValue
+	}
+	if item, ok := v.rMap[key]; ok {
+		return item
+	}
+	for name, item := range v.rMap {
+		if strings.EqualFold(name, key) {
+			return item
+		}
+	}
+	return NullValue
+}
+
+// GetNestedValue returns the value at the given dot-separated path, or a null value
+func (v Value) GetNestedValue(path string) Value {
+	current := v
+	for _, part := range strings.Split(path, ".") {
+		current = current.GetMapValue(part)
+		if current.IsNull() {
+			return NullValue
+		}
+	}
+	return current
+}
+
+// AsMap returns the properties of an object value
+func (v Value) AsMap() map[string]Value {
+	if v.kind != KindObject {
+		return nil
+	}
+	return v.rMap
+}
+
+// MapKeys returns the sorted property names of an object value
+func (v Value) MapKeys() []string {
+	var keys []string
+	for key := range v.AsMap() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (v Value) AsList() []Value {
+	if v.kind != KindArray {
+		return nil
+	}
+	return v.rArr
+}
+
+func (v Value) AsString() string {
+	switch v.kind {
+	case KindString:
+		return v.rLit.(string)
+	case KindNumber, KindBoolean:
+		return toString(v.rLit)
+	default:
+		return ""
+	}
+}
+
+func (v Value) AsBool() bool {
+	switch v.kind {
+	case KindBoolean:
+		return v.rLit.(bool)
+	case KindString:
+		return strings.EqualFold(v.rLit.(string), "true")
+	default:
+		return false
+	}
+}
+
+func (v Value) AsInt() int {
+	switch typed := v.rLit.(type) {
+	case int64:
+		return int(typed)
+	case float64:
+		return int(typed)
+	case string:
+		i, _ := strconv.Atoi(typed)
+		return i
+	default:
+		return 0
+	}
+}
+
+func (v Value) AsStringValue(defaultValue string, metadata types.Metadata) types.StringValue {
+	switch v.kind {
+	case KindString, KindNumber, KindBoolean:
+		return types.String(v.AsString(), v.Metadata)
+	case KindUnresolvable:
+		return types.StringUnresolvable(v.Metadata)
+	default:
+		return types.StringDefault(defaultValue, metadata)
+	}
+}
+
+func (v Value) AsBoolValue(defaultValue bool, metadata types.Metadata) types.BoolValue {
+	switch v.kind {
+	case KindBoolean:
+		return types.Bool(v.AsBool(), v.Metadata)
+	case KindString:
+		if b, err := strconv.ParseBool(v.AsString()); err == nil {
+			return types.Bool(b, v.Metadata)
+		}
+		return types.BoolUnresolvable(v.Metadata)
+	case KindUnresolvable:
+		return types.BoolUnresolvable(v.Metadata)
+	default:
+		return types.BoolDefault(defaultValue, metadata)
+	}
+}
+
+func (v Value) AsIntValue(defaultValue int, metadata types.Metadata) types.IntValue {
+	switch v.kind {
+	case KindNumber:
+		return types.Int(v.AsInt(), v.Metadata)
+	case KindString:
+		if i, err := strconv.Atoi(v.AsString()); err == nil {
+			return types.Int(i, v.Metadata)
+		}
+		return types.IntUnresolvable(v.Metadata)
+	case KindUnresolvable:
+		return types.IntUnresolvable(v.Metadata)
+	default:
+		return types.IntDefault(defaultValue, metadata)
+	}
+}
+
+// AsTimeValue parses the value as an RFC3339 timestamp, or a unix timestamp in seconds (as used for key expiry)
+func (v Value) AsTimeValue(metadata types.Metadata) types.TimeValue {
+	switch v.kind {
+	case KindNumber:
+		return types.Time(time.Unix(int64(v.AsInt()), 0), v.Metadata)
+	case KindString:
+		if t, err := time.Parse(time.RFC3339, v.AsString()); err == nil {
+			return types.Time(t, v.Metadata)
+		}
+		return types.TimeUnresolvable(v.Metadata)
+	case KindUnresolvable:
+		return types.TimeUnresolvable(v.Metadata)
+	default:
+		return types.TimeDefault(time.Time{}, metadata)
+	}
+}
+
+// AsStringValuesList returns each item in an array value as a string value
+func (v Value) AsStringValuesList(defaultValue string) []types.StringValue {
+	var values []types.StringValue
+	for _, item := range v.AsList() {
+		values = append(values, item.AsStringValue(defaultValue, item.Metadata))
+	}
+	return values
+}
+
+// WithMetadata returns a copy of the value with the given metadata
+func (v Value) WithMetadata(metadata types.Metadata) Value {
+	v.Metadata = metadata
+	return v
+}
+
+func toString(raw interface{}) string {
+	switch typed := raw.(type) {
+	case string:
+		return typed
+	case bool:
+		return strconv.FormatBool(typed)
+	case int64:
+		return strconv.FormatInt(typed, 10)
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
@@ -3,6 +3,7 @@ package cloudformation
 import (
 	"io"
 
+	"github.com/aquasecurity/defsec/pkg/ignore"
 	"github.com/aquasecurity/defsec/pkg/scanners/cloudformation/parser"
 )
 
@@ -75,3 +76,60 @@ func OptionWithParser(parser *parser.Parser) Option {
 		s.parser = parser
 	}
 }
+
+// OptionWithParameterFiles - load parameter values from CLI parameter files or CodePipeline template configuration files
+func OptionWithParameterFiles(paths ...string) Option {
+	return func(s *Scanner) {
+		s.parserOptions = append(s.parserOptions, parser.OptionWithParameterFiles(paths...))
+	}
+}
+
+// OptionWithParameters - provide parameter values as a comma separated list of key=value pairs
+func OptionWithParameters(parameters string) Option {
+	return func(s *Scanner) {
+		s.parserOptions = append(s.parserOptions, parser.ProvidedParametersOption(parameters))
+	}
+}
+
+// OptionWithRegion - the region the templates will be deployed to, used for AWS::Region and Fn::GetAZs
+func OptionWithRegion(region string) Option {
+	return func(s *Scanner) {
+		s.parserOptions = append(s.parserOptions, parser.OptionWithRegion(region))
+	}
+}
+
+// OptionWithAccountID - the account the templates will be deployed to, used for AWS::AccountId
+func OptionWithAccountID(accountID string) Option {
+	return func(s *Scanner) {
+		s.parserOptions = append(s.parserOptions, parser.OptionWithAccountID(accountID))
+	}
+}
+
+// OptionWithPartition - the partition the templates will be deployed to, used for AWS::Partition
+func OptionWithPartition(partition string) Option {
+	return func(s *Scanner) {
+		s.parserOptions = append(s.parserOptions, parser.OptionWithPartition(partition))
+	}
+}
+
+// OptionWithStackName - the name of the stack, used for AWS::StackName
+func OptionWithStackName(stackName string) Option {
+	return func(s *Scanner) {
+		s.parserOptions = append(s.parserOptions, parser.OptionWithStackName(stackName))
+	}
+}
+
+// OptionWithSuppressions - ignore results matching entries of a suppression file, e.g. .defsecignore
+func OptionWithSuppressions(suppressions ignore.Suppressions) Option {
+	return func(s *Scanner) {
+		s.suppressions = suppressions
+	}
+}
+
+// OptionReportStaleIgnores - add a result for each inline ignore directive which is expired, refers to an unknown rule
+// or did not ignore anything
+func OptionReportStaleIgnores(report bool) Option {
+	return func(s *Scanner) {
+		s.reportStale = report
+	}
+}
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/ignore"
 )
 
 type SourceFormat string
@@ -19,8 +20,22 @@ type FileContext struct {
 	SourceFormat SourceFormat
 	Parameters   map[string]*Parameter  `json:"Parameters" yaml:"Parameters"`
 	Resources    map[string]*Resource   `json:"Resources" yaml:"Resources"`
-	Globals      map[string]*Resource   `json:"Globals" yaml:"Globals"`
+	Globals      map[string]*Property   `json:"Globals" yaml:"Globals"`
+	Transform    *Property              `json:"Transform,omitempty" yaml:"Transform"`
 	Mappings     map[string]interface{} `json:"Mappings,omitempty" yaml:"Mappings"`
+	Conditions   map[string]*Property   `json:"Conditions,omitempty" yaml:"Conditions"`
+	Outputs      map[string]*Property   `json:"Outputs,omitempty" yaml:"Outputs"`
+	Ignores      ignore.Directives      `json:"-" yaml:"-"`
+
+	conditionResults map[string]conditionResult
+	// stacks are the parsed templates of nested stacks, by the logical ID of their AWS::CloudFormation::Stack resource
+	stacks map[string]*FileContext
+	// serverless are the SAM resources which have been replaced by the resources generated by the SAM transform
+	serverless map[string]*Resource
+	// pseudoParameters are the values of AWS pseudo parameters such as AWS::Region, by name
+	pseudoParameters map[string]interface{}
+	// exports are the values of exported outputs from every template in the scan, by export name
+	exports map[string]*Property
 }
 
 func (t *FileContext) GetResourceByLogicalID(name string) *Resource {
@@ -45,6 +60,21 @@ func (t *FileContext) GetResourcesByType(names ...string) []*Resource {
 	return resources
 }
 
+// GetSAMResourcesByType returns the resources of the given types including the SAM resources which have been replaced by
+// the SAM transform. It is only intended for the SAM adapter, as the resources generated from them are already
+// returned by GetResourcesByType.
+func (t *FileContext) GetSAMResourcesByType(names ...string) []*Resource {
+	resources := t.GetResourcesByType(names...)
+	for _, r := range t.serverless {
+		for _, name := range names {
+			if name == r.Type() {
+				resources = append(resources, r)
+			}
+		}
+	}
+	return resources
+}
+
 func (t *FileContext) Metadata() types.Metadata {
 	rng := types.NewRange(t.filepath, 1, len(t.lines), "", nil)
 
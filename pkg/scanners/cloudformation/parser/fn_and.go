@@ -0,0 +1,33 @@
+package parser
+
+import (
+	"github.com/aquasecurity/defsec/pkg/scanners/cloudformation/cftypes"
+)
+
+func ResolveAnd(property *Property) (resolved *Property, success bool) {
+	if !property.isFunction() {
+		return property, true
+	}
+
+	conditions := conditionList(property, "Fn::And")
+	if len(conditions) < 2 {
+		return abortIntrinsic(property, "Fn::And should have at least 2 conditions, returning original Property")
+	}
+
+	known := true
+	for _, condition := range conditions {
+		value, ok := resolveConditionValue(condition)
+		if !ok {
+			known = false
+			continue
+		}
+		if !value {
+			// false regardless of any conditions which could not be evaluated
+			return property.deriveResolved(cftypes.Bool, false), true
+		}
+	}
+	if !known {
+		return abortIntrinsic(property, "Fn::And could not be evaluated, returning original Property")
+	}
+	return property.deriveResolved(cftypes.Bool, true), true
+}
@@ -10,10 +10,20 @@ import (
 )
 
 func GetAzs(property *Property) (*Property, bool) {
+	if !property.isFunction() {
+		return property, true
+	}
+
+	// an empty region means the region the stack is deployed to
+	region := property.pseudoRegion()
+	if regionProp, ok := property.AsMap()["Fn::GetAZs"].resolveValue(); ok && regionProp.IsString() && regionProp.AsString() != "" {
+		region = regionProp.AsString()
+	}
+
 	return property.deriveResolved(cftypes.List, []*Property{
-		property.deriveResolved(cftypes.String, "us-east-1a"),
-		property.deriveResolved(cftypes.String, "us-east-1a"),
-		property.deriveResolved(cftypes.String, "us-east-1a"),
+		property.deriveResolved(cftypes.String, region+"a"),
+		property.deriveResolved(cftypes.String, region+"b"),
+		property.deriveResolved(cftypes.String, region+"c"),
 	}), true
 }
 
@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"github.com/aquasecurity/defsec/pkg/scanners/cloudformation/cftypes"
+)
+
+func ResolveCondition(property *Property) (resolved *Property, success bool) {
+	if !property.isFunction() {
+		return property, true
+	}
+
+	refProp := property.AsMap()["Condition"]
+	if refProp.IsNotString() {
+		return abortIntrinsic(property, "Condition should be the name of a condition, returning original Property")
+	}
+
+	value, ok := property.ctx.evaluateCondition(refProp.AsString())
+	if !ok {
+		return abortIntrinsic(property, "condition [%s] could not be evaluated, returning original Property", refProp.AsString())
+	}
+	return property.deriveResolved(cftypes.Bool, value), true
+}
+
+// evaluateCondition returns the value of the named condition from the Conditions section of the template,
+// and false for the second return value if it cannot be evaluated, e.g. because it refers to a parameter
+// with no default value.
+func (t *FileContext) evaluateCondition(name string) (bool, bool) {
+	if t == nil {
+		return false, false
+	}
+	if result, ok := t.conditionResults[name]; ok {
+		return result.value, result.known
+	}
+	condition, ok := t.Conditions[name]
+	if !ok || condition == nil {
+		return false, false
+	}
+	if t.conditionResults == nil {
+		t.conditionResults = make(map[string]conditionResult)
+	}
+	// guards against conditions which refer to themselves
+	t.conditionResults[name] = conditionResult{}
+
+	value, known := resolveConditionValue(condition)
+	t.conditionResults[name] = conditionResult{value: value, known: known}
+	return value, known
+}
+
+type conditionResult struct {
+	value bool
+	known bool
+}
+
+// resolveConditionValue resolves a condition function, e.g. Fn::Equals, to a boolean
+func resolveConditionValue(property *Property) (bool, bool) {
+	resolved, ok := property.resolveValue()
+	if !ok || resolved.IsNil() || resolved.isFunction() {
+		return false, false
+	}
+	if resolved.Type() == cftypes.Bool {
+		return resolved.AsBool(), true
+	}
+	if resolved.IsConvertableTo(cftypes.Bool) {
+		return resolved.ConvertTo(cftypes.Bool).AsBool(), true
+	}
+	return false, false
+}
+
+// conditionList returns the conditions passed to Fn::And, Fn::Or or Fn::Not
+func conditionList(property *Property, name string) []*Property {
+	value := property.AsMap()[name]
+	if value.IsNotList() {
+		return nil
+	}
+	return value.AsList()
+}
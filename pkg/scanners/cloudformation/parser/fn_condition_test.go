@@ -0,0 +1,119 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/cloudformation/cftypes"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newConditionFunction(name string, conditions ...*Property) *Property {
+	return &Property{
+		ctx: &FileContext{
+			Conditions: map[string]*Property{
+				"True":  newBoolCondition(true),
+				"False": newBoolCondition(false),
+			},
+		},
+		Inner: PropertyInner{
+			Type: cftypes.Map,
+			Value: map[string]*Property{
+				name: {
+					Inner: PropertyInner{
+						Type:  cftypes.List,
+						Value: conditions,
+					},
+				},
+			},
+		},
+	}
+}
+
+func newConditionReference(name string) *Property {
+	return &Property{
+		ctx: &FileContext{
+			Conditions: map[string]*Property{
+				"True":  newBoolCondition(true),
+				"False": newBoolCondition(false),
+			},
+		},
+		Inner: PropertyInner{
+			Type: cftypes.Map,
+			Value: map[string]*Property{
+				"Condition": newStringProperty(name),
+			},
+		},
+	}
+}
+
+func Test_resolve_condition_functions(t *testing.T) {
+	tests := []struct {
+		name     string
+		property *Property
+		known    bool
+		expected bool
+	}{
+		{
+			name:     "condition reference",
+			property: newConditionReference("True"),
+			known:    true,
+			expected: true,
+		},
+		{
+			name:     "missing condition reference",
+			property: newConditionReference("Missing"),
+		},
+		{
+			name:     "and is true",
+			property: newConditionFunction("Fn::And", newConditionReference("True"), newBoolCondition(true)),
+			known:    true,
+			expected: true,
+		},
+		{
+			name:     "and is false",
+			property: newConditionFunction("Fn::And", newConditionReference("True"), newConditionReference("False")),
+			known:    true,
+		},
+		{
+			name:     "and is false with unknown condition",
+			property: newConditionFunction("Fn::And", newConditionReference("Missing"), newConditionReference("False")),
+			known:    true,
+		},
+		{
+			name:     "and is unknown",
+			property: newConditionFunction("Fn::And", newConditionReference("Missing"), newConditionReference("True")),
+		},
+		{
+			name:     "or is true with unknown condition",
+			property: newConditionFunction("Fn::Or", newConditionReference("Missing"), newConditionReference("True")),
+			known:    true,
+			expected: true,
+		},
+		{
+			name:     "or is false",
+			property: newConditionFunction("Fn::Or", newConditionReference("False"), newBoolCondition(false)),
+			known:    true,
+		},
+		{
+			name:     "not",
+			property: newConditionFunction("Fn::Not", newConditionReference("False")),
+			known:    true,
+			expected: true,
+		},
+		{
+			name:     "not with too many conditions",
+			property: newConditionFunction("Fn::Not", newConditionReference("False"), newConditionReference("True")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resolved, success := ResolveIntrinsicFunc(test.property)
+			assert.Equal(t, test.known, success)
+			if test.known {
+				assert.Equal(t, test.expected, resolved.IsTrue())
+			}
+		})
+	}
+}
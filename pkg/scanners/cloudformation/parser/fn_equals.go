@@ -15,8 +15,11 @@ func ResolveEquals(property *Property) (resolved *Property, success bool) {
 		return abortIntrinsic(property, "Fn::Equals should have exactly 2 values, returning original Property")
 	}
 
-	propA, _ := refValue[0].resolveValue()
-	propB, _ := refValue[1].resolveValue()
+	propA, okA := refValue[0].resolveValue()
+	propB, okB := refValue[1].resolveValue()
+	if !okA || !okB || propA.IsNil() || propB.IsNil() || propA.isFunction() || propB.isFunction() {
+		return abortIntrinsic(property, "Fn::Equals values could not be resolved, returning original Property")
+	}
 	return property.deriveResolved(cftypes.Bool, propA.EqualTo(propB.RawValue())), true
 
 }
@@ -16,7 +16,8 @@ func ResolveGetAtt(property *Property) (resolved *Property, success bool) {
 	var refValue []string
 
 	if refValueProp.IsString() {
-		refValue = strings.Split(refValueProp.AsString(), ".")
+		// the attribute may itself contain dots, e.g. NestedStack.Outputs.BucketName
+		refValue = strings.SplitN(refValueProp.AsString(), ".", 2)
 	}
 
 	if refValueProp.IsList() {
@@ -37,6 +38,12 @@ func ResolveGetAtt(property *Property) (resolved *Property, success bool) {
 		return property.deriveResolved(cftypes.String, ""), true
 	}
 
+	if referencedResource.Type() == nestedStackType && strings.HasPrefix(attribute, "Outputs.") {
+		if output, ok := property.ctx.stackOutput(logicalId, strings.TrimPrefix(attribute, "Outputs.")); ok {
+			return property.deriveResolved(output.Type(), output.RawValue()), true
+		}
+	}
+
 	referencedProperty := referencedResource.GetProperty(attribute)
 	if referencedProperty.IsNil() {
 		return property.deriveResolved(cftypes.String, referencedResource.ID()), true
@@ -0,0 +1,43 @@
+package parser
+
+func ResolveIf(property *Property) (resolved *Property, success bool) {
+	if !property.isFunction() {
+		return property, true
+	}
+
+	refValue := property.AsMap()["Fn::If"].AsList()
+
+	if len(refValue) != 3 {
+		return abortIntrinsic(property, "Fn::If should have exactly 3 values, returning original Property")
+	}
+
+	conditionName := refValue[0]
+	if conditionName.IsNotString() {
+		return abortIntrinsic(property, "Fn::If condition should be the name of a condition, returning original Property")
+	}
+
+	value, ok := property.ctx.evaluateCondition(conditionName.AsString())
+	if !ok {
+		return abortIntrinsic(property, "condition [%s] could not be evaluated, returning original Property", conditionName.AsString())
+	}
+
+	branch := refValue[2]
+	if value {
+		branch = refValue[1]
+	}
+
+	if isNoValue(branch) {
+		// the property is removed, as if it was never set
+		return property.deriveResolved(branch.Type(), nil), true
+	}
+	return branch.resolveValue()
+}
+
+// isNoValue returns true for {"Ref": "AWS::NoValue"}
+func isNoValue(property *Property) bool {
+	if !property.isFunction() {
+		return false
+	}
+	ref, ok := property.AsMap()["Ref"]
+	return ok && ref.IsString() && ref.AsString() == "AWS::NoValue"
+}
@@ -0,0 +1,103 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/defsec/internal/types"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/cloudformation/cftypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newIfProperty(ctx *FileContext, condition string, whenTrue *Property, whenFalse *Property) *Property {
+	return &Property{
+		ctx:  ctx,
+		name: "BucketName",
+		rng:  types.NewRange("testfile", 1, 1, "", nil),
+		Inner: PropertyInner{
+			Type: cftypes.Map,
+			Value: map[string]*Property{
+				"Fn::If": {
+					Inner: PropertyInner{
+						Type: cftypes.List,
+						Value: []*Property{
+							{
+								Inner: PropertyInner{
+									Type:  cftypes.String,
+									Value: condition,
+								},
+							},
+							whenTrue,
+							whenFalse,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func newBoolCondition(value bool) *Property {
+	return &Property{
+		Inner: PropertyInner{
+			Type:  cftypes.Bool,
+			Value: value,
+		},
+	}
+}
+
+func newStringProperty(value string) *Property {
+	return &Property{
+		Inner: PropertyInner{
+			Type:  cftypes.String,
+			Value: value,
+		},
+	}
+}
+
+func Test_resolve_if_value(t *testing.T) {
+	ctx := &FileContext{
+		Conditions: map[string]*Property{
+			"IsProd":  newBoolCondition(true),
+			"IsStage": newBoolCondition(false),
+		},
+	}
+
+	resolvedProperty, success := ResolveIntrinsicFunc(newIfProperty(ctx, "IsProd", newStringProperty("prod"), newStringProperty("dev")))
+	require.True(t, success)
+	assert.Equal(t, "prod", resolvedProperty.AsString())
+
+	resolvedProperty, success = ResolveIntrinsicFunc(newIfProperty(ctx, "IsStage", newStringProperty("stage"), newStringProperty("dev")))
+	require.True(t, success)
+	assert.Equal(t, "dev", resolvedProperty.AsString())
+}
+
+func Test_resolve_if_value_with_no_value(t *testing.T) {
+	ctx := &FileContext{
+		Conditions: map[string]*Property{
+			"IsProd": newBoolCondition(false),
+		},
+	}
+	noValue := &Property{
+		Inner: PropertyInner{
+			Type: cftypes.Map,
+			Value: map[string]*Property{
+				"Ref": newStringProperty("AWS::NoValue"),
+			},
+		},
+	}
+
+	resolvedProperty, success := ResolveIntrinsicFunc(newIfProperty(ctx, "IsProd", newStringProperty("prod"), noValue))
+	require.True(t, success)
+	assert.True(t, resolvedProperty.IsNil())
+}
+
+func Test_resolve_if_value_with_unknown_condition(t *testing.T) {
+	property := newIfProperty(&FileContext{}, "Missing", newStringProperty("prod"), newStringProperty("dev"))
+
+	resolvedProperty, success := ResolveIntrinsicFunc(property)
+	require.False(t, success)
+	assert.Equal(t, property, resolvedProperty)
+}
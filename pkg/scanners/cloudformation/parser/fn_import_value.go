@@ -0,0 +1,27 @@
+package parser
+
+func ResolveImportValue(property *Property) (resolved *Property, success bool) {
+	if !property.isFunction() {
+		return property, true
+	}
+
+	exportName, ok := property.AsMap()["Fn::ImportValue"].resolveValue()
+	if !ok || exportName.IsNotString() {
+		return abortIntrinsic(property, "Fn::ImportValue should be the name of an export, returning original Property")
+	}
+
+	if property.ctx == nil {
+		return abortIntrinsic(property, "no context to resolve Fn::ImportValue, returning original Property")
+	}
+
+	export, ok := property.ctx.exports[exportName.AsString()]
+	if !ok {
+		return abortIntrinsic(property, "export [%s] was not found, returning original Property", exportName.AsString())
+	}
+
+	value, ok := export.resolveValue()
+	if !ok || value.IsNil() || value.isFunction() {
+		return abortIntrinsic(property, "export [%s] could not be resolved, returning original Property", exportName.AsString())
+	}
+	return property.deriveResolved(value.Type(), value.RawValue()), true
+}
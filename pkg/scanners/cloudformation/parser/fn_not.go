@@ -0,0 +1,22 @@
+package parser
+
+import (
+	"github.com/aquasecurity/defsec/pkg/scanners/cloudformation/cftypes"
+)
+
+func ResolveNot(property *Property) (resolved *Property, success bool) {
+	if !property.isFunction() {
+		return property, true
+	}
+
+	conditions := conditionList(property, "Fn::Not")
+	if len(conditions) != 1 {
+		return abortIntrinsic(property, "Fn::Not should have exactly 1 condition, returning original Property")
+	}
+
+	value, ok := resolveConditionValue(conditions[0])
+	if !ok {
+		return abortIntrinsic(property, "Fn::Not could not be evaluated, returning original Property")
+	}
+	return property.deriveResolved(cftypes.Bool, !value), true
+}
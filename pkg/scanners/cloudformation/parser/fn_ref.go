@@ -15,7 +15,14 @@ func ResolveReference(property *Property) (resolved *Property, success bool) {
 	}
 	refValue := refProp.AsString()
 
-	if pseudo, ok := pseudoParameters[refValue]; ok {
+	if pseudo, ok := property.pseudoParameterValues()[refValue]; ok {
+		if values, ok := pseudo.([]string); ok {
+			var list []*Property
+			for _, value := range values {
+				list = append(list, property.deriveResolved(cftypes.String, value))
+			}
+			return property.deriveResolved(cftypes.List, list), true
+		}
 		return property.deriveResolved(cftypes.String, pseudo.(string)), true
 	}
 
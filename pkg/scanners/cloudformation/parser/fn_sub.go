@@ -57,13 +57,17 @@ func resolveMapSub(refValue *Property, original *Property) (*Property, bool) {
 		workingString = strings.ReplaceAll(workingString, fmt.Sprintf("${%s}", k), replacement)
 	}
 
+	for k, v := range original.pseudoParameterValues() {
+		workingString = strings.ReplaceAll(workingString, fmt.Sprintf("${%s}", k), fmt.Sprintf("%v", v))
+	}
+
 	return original.deriveResolved(cftypes.String, workingString), true
 }
 
 func resolveStringSub(refValue *Property, original *Property) *Property {
 	workingString := refValue.AsString()
 
-	for k, v := range pseudoParameters {
+	for k, v := range original.pseudoParameterValues() {
 		workingString = strings.ReplaceAll(workingString, fmt.Sprintf("${%s}", k), fmt.Sprintf("%v", v))
 	}
 
@@ -22,8 +22,12 @@ func init() {
 		"Fn::GetAtt":      ResolveGetAtt,
 		"Fn::GetAZs":      GetAzs,
 		"Fn::Cidr":        GetCidr,
-		"Fn::ImportValue": PassthroughResolution,
-		// "Fn::If":        PassthroughResolution,
+		"Fn::ImportValue": ResolveImportValue,
+		"Fn::If":          ResolveIf,
+		"Fn::And":         ResolveAnd,
+		"Fn::Or":          ResolveOr,
+		"Fn::Not":         ResolveNot,
+		"Condition":       ResolveCondition,
 	}
 }
 
@@ -36,10 +40,7 @@ func IsIntrinsicFunc(node *yaml.Node) bool {
 		return false
 	}
 
-	nodeTag := strings.TrimPrefix(node.Tag, "!")
-	if nodeTag != "Ref" {
-		nodeTag = fmt.Sprintf("Fn::%s", nodeTag)
-	}
+	nodeTag := getIntrinsicTag(node.Tag)
 	for tag := range intrinsicFuncs {
 
 		if nodeTag == tag {
@@ -78,7 +79,7 @@ func ResolveIntrinsicFunc(property *Property) (*Property, bool) {
 func getIntrinsicTag(tag string) string {
 	tag = strings.TrimPrefix(tag, "!")
 	switch tag {
-	case "Ref", "Contains":
+	case "Ref", "Contains", "Condition":
 		return tag
 	default:
 		return fmt.Sprintf("Fn::%s", tag)
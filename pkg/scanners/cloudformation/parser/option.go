@@ -43,3 +43,40 @@ func ProvidedParametersOption(parameters string) Option {
 		p.parameters = params
 	}
 }
+
+// OptionWithParameterFiles loads parameter values from files in the scanned filesystem. Both the CLI format, e.g.
+// [{"ParameterKey": "Environment", "ParameterValue": "prod"}], and the CodePipeline template configuration format,
+// e.g. {"Parameters": {"Environment": "prod"}}, are supported. Values passed with ProvidedParametersOption take precedence.
+func OptionWithParameterFiles(paths ...string) Option {
+	return func(p *Parser) {
+		p.parameterFiles = paths
+	}
+}
+
+// OptionWithRegion sets the value of AWS::Region, which is also used by Fn::GetAZs
+func OptionWithRegion(region string) Option {
+	return func(p *Parser) {
+		p.pseudo.region = region
+	}
+}
+
+// OptionWithAccountID sets the value of AWS::AccountId
+func OptionWithAccountID(accountID string) Option {
+	return func(p *Parser) {
+		p.pseudo.accountID = accountID
+	}
+}
+
+// OptionWithPartition sets the value of AWS::Partition, which is otherwise derived from the region
+func OptionWithPartition(partition string) Option {
+	return func(p *Parser) {
+		p.pseudo.partition = partition
+	}
+}
+
+// OptionWithStackName sets the value of AWS::StackName
+func OptionWithStackName(stackName string) Option {
+	return func(p *Parser) {
+		p.pseudo.stackName = stackName
+	}
+}
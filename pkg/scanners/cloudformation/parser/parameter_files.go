@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+)
+
+// cliParameter is an entry in a parameter file used with the AWS CLI, e.g. aws cloudformation create-stack --parameters
+type cliParameter struct {
+	ParameterKey     string      `json:"ParameterKey"`
+	ParameterValue   interface{} `json:"ParameterValue"`
+	UsePreviousValue bool        `json:"UsePreviousValue"`
+}
+
+// templateConfiguration is a CodePipeline template configuration file
+type templateConfiguration struct {
+	Parameters map[string]interface{} `json:"Parameters"`
+}
+
+// parameterValues combines the values from any parameter files with those provided directly
+func (p *Parser) parameterValues(target fs.FS) (map[string]Parameter, error) {
+	if len(p.parameterFiles) == 0 {
+		return p.parameters, nil
+	}
+
+	combined := make(map[string]Parameter)
+	for _, path := range p.parameterFiles {
+		values, err := loadParameterFile(target, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load parameters from %s: %w", path, err)
+		}
+		p.debug("Added %d parameters from %s.", len(values), path)
+		for name, value := range values {
+			combined[name] = newProvidedParameter(value)
+		}
+	}
+
+	for name, parameter := range p.parameters {
+		combined[name] = parameter
+	}
+
+	return combined, nil
+}
+
+func loadParameterFile(target fs.FS, path string) (map[string]string, error) {
+	content, err := fs.ReadFile(target, filepath.ToSlash(path))
+	if err != nil {
+		return nil, err
+	}
+
+	var raw interface{}
+	if err := json.Unmarshal(content, &raw); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+
+	switch raw.(type) {
+	case []interface{}:
+		var parameters []cliParameter
+		if err := json.Unmarshal(content, &parameters); err != nil {
+			return nil, err
+		}
+		for _, parameter := range parameters {
+			// previous values are only known to the deployed stack
+			if parameter.ParameterKey == "" || parameter.UsePreviousValue || parameter.ParameterValue == nil {
+				continue
+			}
+			values[parameter.ParameterKey] = fmt.Sprintf("%v", parameter.ParameterValue)
+		}
+	case map[string]interface{}:
+		var config templateConfiguration
+		if err := json.Unmarshal(content, &config); err != nil {
+			return nil, err
+		}
+		for name, value := range config.Parameters {
+			values[name] = fmt.Sprintf("%v", value)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported parameter file format")
+	}
+
+	return values, nil
+}
+
+func newProvidedParameter(value string) Parameter {
+	return Parameter{
+		inner: parameterInner{
+			Default: value,
+		},
+	}
+}
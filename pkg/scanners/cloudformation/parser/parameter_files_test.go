@@ -0,0 +1,124 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/defsec/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const parameterisedSource = `---
+Parameters:
+  Environment:
+    Type: String
+    Default: dev
+  Retention:
+    Type: Number
+    Default: 7
+Resources:
+  Logs:
+    Type: AWS::Logs::LogGroup
+    Properties:
+      LogGroupName: !Ref Environment
+      RetentionInDays: !Ref Retention
+`
+
+func Test_parameter_files(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		provided    string
+		environment string
+		retention   interface{}
+	}{
+		{
+			name: "cli format",
+			file: `[
+  {"ParameterKey": "Environment", "ParameterValue": "prod"},
+  {"ParameterKey": "Retention", "UsePreviousValue": true}
+]`,
+			environment: "prod",
+			retention:   7,
+		},
+		{
+			name: "template configuration",
+			file: `{
+  "Parameters": {"Environment": "staging", "Retention": "30"},
+  "Tags": {"Team": "platform"}
+}`,
+			environment: "staging",
+			retention:   "30",
+		},
+		{
+			name:        "provided parameters take precedence",
+			file:        `[{"ParameterKey": "Environment", "ParameterValue": "prod"}]`,
+			provided:    "Environment=test",
+			environment: "test",
+			retention:   7,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fs := testutil.CreateFS(t, map[string]string{
+				"main.yaml":        parameterisedSource,
+				"params/prod.json": test.file,
+			})
+			options := []Option{OptionWithParameterFiles("params/prod.json")}
+			if test.provided != "" {
+				options = append(options, ProvidedParametersOption(test.provided))
+			}
+			contexts, err := New(options...).ParseFS(context.TODO(), fs, ".")
+			require.NoError(t, err)
+			require.Len(t, contexts, 1)
+
+			assert.Equal(t, test.environment, contexts[0].Parameters["Environment"].Default())
+			assert.Equal(t, test.retention, contexts[0].Parameters["Retention"].Default())
+		})
+	}
+}
+
+func Test_parameter_file_missing(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"main.yaml": parameterisedSource,
+	})
+	_, err := New(OptionWithParameterFiles("missing.json")).ParseFS(context.TODO(), fs, ".")
+	assert.Error(t, err)
+}
+
+func Test_pseudo_parameter_options(t *testing.T) {
+	source := `---
+Resources:
+  Subnet:
+    Type: AWS::EC2::Subnet
+    Properties:
+      AvailabilityZone: !Select [1, !GetAZs ""]
+      Tags:
+        - Key: Arn
+          Value: !Sub "arn:${AWS::Partition}:s3:::${AWS::StackName}-${AWS::AccountId}-${AWS::Region}"
+        - Key: Region
+          Value: !Ref AWS::Region
+        - Key: Explicit
+          Value: !Select [0, !GetAZs us-west-2]
+`
+	contexts, err := parseFile(t, source, "main.yaml",
+		OptionWithRegion("cn-north-1"),
+		OptionWithAccountID("111122223333"),
+		OptionWithStackName("payments"),
+	)
+	require.NoError(t, err)
+	require.Len(t, contexts, 1)
+
+	subnet := contexts[0].GetResourceByLogicalID("Subnet")
+	require.NotNil(t, subnet)
+
+	assert.Equal(t, "cn-north-1b", subnet.GetStringProperty("AvailabilityZone").Value())
+
+	tags := subnet.GetProperty("Tags").AsList()
+	require.Len(t, tags, 3)
+	assert.Equal(t, "arn:aws-cn:s3:::payments-111122223333-cn-north-1", tags[0].GetStringProperty("Value").Value())
+	assert.Equal(t, "cn-north-1", tags[1].GetStringProperty("Value").Value())
+	assert.Equal(t, "us-west-2a", tags[2].GetStringProperty("Value").Value())
+}
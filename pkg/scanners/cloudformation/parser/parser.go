@@ -10,18 +10,24 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/aquasecurity/defsec/pkg/ignore"
+
 	"github.com/liamg/jfather"
 	"gopkg.in/yaml.v3"
 )
 
 type Parser struct {
-	parameters   map[string]Parameter
-	debugWriter  io.Writer
-	skipRequired bool
+	parameters     map[string]Parameter
+	parameterFiles []string
+	pseudo         pseudoParameterConfig
+	debugWriter    io.Writer
+	skipRequired   bool
 }
 
 func New(options ...Option) *Parser {
-	p := &Parser{}
+	p := &Parser{
+		pseudo: defaultPseudoParameterConfig(),
+	}
 	for _, option := range options {
 		option(p)
 	}
@@ -37,6 +43,11 @@ func (p *Parser) debug(format string, args ...interface{}) {
 }
 
 func (p *Parser) ParseFS(ctx context.Context, target fs.FS, dir string) (FileContexts, error) {
+	parameters, err := p.parameterValues(target)
+	if err != nil {
+		return nil, err
+	}
+
 	var contexts FileContexts
 	if err := fs.WalkDir(target, filepath.ToSlash(dir), func(path string, entry fs.DirEntry, err error) error {
 		select {
@@ -56,7 +67,7 @@ func (p *Parser) ParseFS(ctx context.Context, target fs.FS, dir string) (FileCon
 			return nil
 		}
 
-		c, err := p.ParseFile(ctx, target, path)
+		c, err := p.parseFile(ctx, target, path, parameters)
 		if err != nil {
 			return err
 		}
@@ -65,7 +76,7 @@ func (p *Parser) ParseFS(ctx context.Context, target fs.FS, dir string) (FileCon
 	}); err != nil {
 		return nil, err
 	}
-	return contexts, nil
+	return p.resolveStacks(ctx, target, contexts)
 }
 
 func (p *Parser) Required(fs fs.FS, path string) bool {
@@ -105,6 +116,14 @@ func (p *Parser) Required(fs fs.FS, path string) bool {
 }
 
 func (p *Parser) ParseFile(ctx context.Context, fs fs.FS, path string) (context *FileContext, err error) {
+	parameters, err := p.parameterValues(fs)
+	if err != nil {
+		return nil, err
+	}
+	return p.parseFile(ctx, fs, path, parameters)
+}
+
+func (p *Parser) parseFile(ctx context.Context, fs fs.FS, path string, parameters map[string]Parameter) (context *FileContext, err error) {
 
 	defer func() {
 		if e := recover(); e != nil {
@@ -155,18 +174,69 @@ func (p *Parser) ParseFile(ctx context.Context, fs fs.FS, path string) (context
 	context.lines = lines
 	context.SourceFormat = sourceFmt
 	context.filepath = path
+	context.pseudoParameters = p.pseudo.values()
+	context.Ignores = ignore.Parse(content, path)
 
 	p.debug("Context loaded from source %s", path)
 
+	for name, condition := range context.Conditions {
+		if condition == nil {
+			continue
+		}
+		condition.setName(name)
+		condition.setFileAndParentRange(path, condition.Range())
+		condition.setContext(context)
+	}
+
 	for name, r := range context.Resources {
 		r.ConfigureResource(name, path, context)
 	}
 
-	if p.parameters != nil {
-		for name, passedParameter := range p.parameters {
-			context.Parameters[name].UpdateDefault(passedParameter.Default())
+	if context.usesServerlessTransform() {
+		p.expandServerless(context)
+	}
+
+	for name, output := range context.Outputs {
+		if output == nil {
+			continue
+		}
+		output.setName(name)
+		output.setFileAndParentRange(path, output.Range())
+		output.setContext(context)
+	}
+
+	if parameters != nil {
+		for name, passedParameter := range parameters {
+			parameter, ok := context.Parameters[name]
+			if !ok {
+				p.debug("parameter '%s' is not defined in %s, ignoring", name, path)
+				continue
+			}
+			parameter.UpdateDefault(passedParameter.Default())
 		}
 	}
 
+	p.removeConditionalResources(context)
+
 	return context, nil
 }
+
+// removeConditionalResources removes any resources which will not be created because their condition is false.
+// Resources with a condition that cannot be evaluated, e.g. one which depends on a parameter with no default, are kept.
+func (p *Parser) removeConditionalResources(context *FileContext) {
+	for name, resource := range context.Resources {
+		condition := resource.Inner.Condition
+		if condition == "" {
+			continue
+		}
+		if value, known := context.evaluateCondition(condition); known && !value {
+			p.debug("resource '%s' will not be created as condition '%s' is false, skipping", name, condition)
+			delete(context.Resources, name)
+		}
+	}
+	for name, resource := range context.serverless {
+		if value, known := context.evaluateCondition(resource.Inner.Condition); resource.Inner.Condition != "" && known && !value {
+			delete(context.serverless, name)
+		}
+	}
+}
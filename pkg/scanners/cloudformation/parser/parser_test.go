@@ -10,13 +10,13 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func parseFile(t *testing.T, source string, name string) (FileContexts, error) {
+func parseFile(t *testing.T, source string, name string, options ...Option) (FileContexts, error) {
 	tmp, err := os.MkdirTemp(os.TempDir(), "defsec")
 	require.NoError(t, err)
 	defer func() { _ = os.RemoveAll(tmp) }()
 	require.NoError(t, os.WriteFile(filepath.Join(tmp, name), []byte(source), 0600))
 	fs := os.DirFS(tmp)
-	return New().ParseFS(context.TODO(), fs, ".")
+	return New(options...).ParseFS(context.TODO(), fs, ".")
 }
 
 func Test_parse_yaml(t *testing.T) {
@@ -176,3 +176,118 @@ func createTestFileContext(t *testing.T, source string) *FileContext {
 	require.Len(t, contexts, 1)
 	return contexts[0]
 }
+
+const conditionalSource = `---
+Parameters:
+  Environment:
+    Type: String
+    Default: dev
+  Owner:
+    Type: String
+Conditions:
+  IsProd: !Equals [!Ref Environment, prod]
+  IsNotProd: !Not [!Condition IsProd]
+  IsProdOrStaging: !Or [!Condition IsProd, !Equals [!Ref Environment, staging]]
+  IsProdWithOwner: !And [!Condition IsProd, !Equals [!Ref Owner, platform]]
+  HasOwner: !Not [!Equals [!Ref Owner, ""]]
+Resources:
+  ProdBucket:
+    Type: AWS::S3::Bucket
+    Condition: IsProd
+    Properties:
+      BucketName: prod-bucket
+  StagingBucket:
+    Type: AWS::S3::Bucket
+    Condition: IsProdOrStaging
+  OwnedBucket:
+    Type: AWS::S3::Bucket
+    Condition: HasOwner
+  Bucket:
+    Type: AWS::S3::Bucket
+    Properties:
+      BucketName: !If [IsProd, prod-logs, dev-logs]
+      VersioningConfiguration: !If
+        - IsNotProd
+        - !Ref AWS::NoValue
+        - Status: Enabled
+      Tags:
+        - Key: owned
+          Value: !If [IsProdWithOwner, "yes", "no"]
+`
+
+func Test_parse_yaml_with_conditions(t *testing.T) {
+	ctx := createTestFileContext(t, conditionalSource)
+
+	assert.Nil(t, ctx.GetResourceByLogicalID("ProdBucket"))
+	assert.Nil(t, ctx.GetResourceByLogicalID("StagingBucket"))
+	// the condition depends on a parameter without a default, so the resource is kept
+	assert.NotNil(t, ctx.GetResourceByLogicalID("OwnedBucket"))
+
+	bucket := ctx.GetResourceByLogicalID("Bucket")
+	require.NotNil(t, bucket)
+	assert.Equal(t, "dev-logs", bucket.GetStringProperty("BucketName").Value())
+	assert.True(t, bucket.GetProperty("VersioningConfiguration").IsNil())
+
+	tags := bucket.GetProperty("Tags").AsList()
+	require.Len(t, tags, 1)
+	assert.Equal(t, "no", tags[0].GetStringProperty("Value").Value())
+}
+
+func Test_parse_yaml_with_conditions_and_provided_parameters(t *testing.T) {
+	files, err := parseFile(t, conditionalSource, "cf.yaml", ProvidedParametersOption("Environment=prod,Unknown=value"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	ctx := files[0]
+
+	assert.NotNil(t, ctx.GetResourceByLogicalID("ProdBucket"))
+	assert.NotNil(t, ctx.GetResourceByLogicalID("StagingBucket"))
+
+	bucket := ctx.GetResourceByLogicalID("Bucket")
+	require.NotNil(t, bucket)
+	assert.Equal(t, "prod-logs", bucket.GetStringProperty("BucketName").Value())
+	assert.Equal(t, "Enabled", bucket.GetStringProperty("VersioningConfiguration.Status").Value())
+
+	// the owner is still unknown, so the value cannot be resolved
+	tags := bucket.GetProperty("Tags").AsList()
+	require.Len(t, tags, 1)
+	assert.Equal(t, "", tags[0].GetStringProperty("Value").Value())
+}
+
+func Test_parse_json_with_conditions(t *testing.T) {
+	source := `{
+  "Parameters": {
+    "EnableLogging": {
+      "Type": "String",
+      "AllowedValues": ["true", "false"],
+      "Default": "false"
+    }
+  },
+  "Conditions": {
+    "LoggingEnabled": {"Fn::Equals": [{"Ref": "EnableLogging"}, "true"]}
+  },
+  "Resources": {
+    "LogBucket": {
+      "Type": "AWS::S3::Bucket",
+      "Condition": "LoggingEnabled"
+    },
+    "Bucket": {
+      "Type": "AWS::S3::Bucket",
+      "Properties": {
+        "LoggingConfiguration": {
+          "Fn::If": ["LoggingEnabled", {"DestinationBucketName": {"Ref": "LogBucket"}}, {"Ref": "AWS::NoValue"}]
+        }
+      }
+    }
+  }
+}`
+
+	files, err := parseFile(t, source, "cf.json")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	ctx := files[0]
+
+	assert.Nil(t, ctx.GetResourceByLogicalID("LogBucket"))
+	bucket := ctx.GetResourceByLogicalID("Bucket")
+	require.NotNil(t, bucket)
+	assert.True(t, bucket.GetProperty("LoggingConfiguration").IsNil())
+}
@@ -27,6 +27,8 @@ type Property struct {
 	parentRange types.Range
 	Inner       PropertyInner
 	logicalId   string
+	// generated properties were not read from the source, e.g. those created by the SAM transform
+	generated bool
 }
 
 type PropertyInner struct {
@@ -53,17 +55,20 @@ func (p *Property) setName(name string) {
 func (p *Property) setContext(ctx *FileContext) {
 	p.ctx = ctx
 
-	if p.IsMap() {
+	// the raw type is used here as resolving functions may require the context of other properties
+	switch p.Type() {
+	case cftypes.Map:
 		for _, subProp := range p.AsMap() {
 			if subProp == nil {
 				continue
 			}
 			subProp.setContext(ctx)
 		}
-	}
-
-	if p.IsList() {
+	case cftypes.List:
 		for _, subProp := range p.AsList() {
+			if subProp == nil {
+				continue
+			}
 			subProp.setContext(ctx)
 		}
 	}
@@ -126,7 +131,9 @@ func (p *Property) isFunction() bool {
 	if p == nil {
 		return false
 	}
-	if p.Type() == cftypes.Map {
+	// intrinsic functions are maps with a single key, e.g. {"Fn::If": [...]}, so maps such as an IAM
+	// statement which happen to include a "Condition" key are not mistaken for functions
+	if p.Type() == cftypes.Map && len(p.AsMap()) == 1 {
 		for n := range p.AsMap() {
 			return IsIntrinsic(n)
 		}
@@ -217,6 +224,13 @@ func (p *Property) GetProperty(path string) *Property {
 	first := pathParts[0]
 	var property *Property
 
+	// e.g. a map chosen by Fn::If
+	if p.isFunction() {
+		if resolved, ok := p.resolveValue(); ok && !resolved.isFunction() {
+			return resolved.GetProperty(path)
+		}
+	}
+
 	if p.IsNotMap() {
 		return nil
 	}
@@ -248,6 +262,7 @@ func (p *Property) deriveResolved(propType cftypes.CfType, propValue interface{}
 		rng:         p.rng,
 		parentRange: p.parentRange,
 		logicalId:   p.logicalId,
+		generated:   p.generated,
 		Inner: PropertyInner{
 			Type:  propType,
 			Value: propValue,
@@ -329,6 +344,13 @@ func (p *Property) SetLogicalResource(id string) {
 }
 
 func (p *Property) GetJsonBytes(squashList ...bool) []byte {
+	if p.generated {
+		policyJson, err := json.Marshal(p.jsonValue())
+		if err != nil {
+			return nil
+		}
+		return policyJson
+	}
 	lines, err := p.AsRawStrings()
 	if err != nil {
 		return nil
@@ -356,6 +378,26 @@ func (p *Property) GetJsonBytes(squashList ...bool) []byte {
 	return policyJson
 }
 
+// jsonValue returns the resolved value of the property as it would be unmarshalled from JSON
+func (p *Property) jsonValue() interface{} {
+	resolved, _ := p.resolveValue()
+	switch v := resolved.Inner.Value.(type) {
+	case map[string]*Property:
+		values := make(map[string]interface{}, len(v))
+		for name, item := range v {
+			values[name] = item.jsonValue()
+		}
+		return values
+	case []*Property:
+		values := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			values = append(values, item.jsonValue())
+		}
+		return values
+	}
+	return resolved.Inner.Value
+}
+
 func (p *Property) GetJsonBytesAsString(squashList ...bool) string {
 	return string(p.GetJsonBytes(squashList...))
 }
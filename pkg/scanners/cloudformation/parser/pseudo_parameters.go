@@ -1,11 +1,75 @@
 package parser
 
-var pseudoParameters = map[string]interface{}{
-	"AWS::AccountId":        "123456789012",
-	"AWS::NotificationARNs": []string{"notification::arn::1", "notification::arn::2"},
-	"AWS::NoValue":          "",
-	"AWS::Partition":        "aws",
-	"AWS::Region":           "eu-west-1",
-	"AWS::StackId":          "arn:aws:cloudformation:eu-west-1:stack/ID",
-	"AWS::StackName":        "cfsec-test-stack",
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	defaultAccountID = "123456789012"
+	defaultRegion    = "eu-west-1"
+	defaultStackName = "cfsec-test-stack"
+)
+
+// pseudoParameterConfig holds the values used for AWS pseudo parameters such as AWS::Region, which are only known
+// when a stack is deployed
+type pseudoParameterConfig struct {
+	accountID string
+	region    string
+	partition string
+	stackName string
+}
+
+func defaultPseudoParameterConfig() pseudoParameterConfig {
+	return pseudoParameterConfig{
+		accountID: defaultAccountID,
+		region:    defaultRegion,
+		stackName: defaultStackName,
+	}
+}
+
+func (c pseudoParameterConfig) values() map[string]interface{} {
+	partition := c.partition
+	if partition == "" {
+		partition = partitionForRegion(c.region)
+	}
+	urlSuffix := "amazonaws.com"
+	if partition == "aws-cn" {
+		urlSuffix = "amazonaws.com.cn"
+	}
+	return map[string]interface{}{
+		"AWS::AccountId":        c.accountID,
+		"AWS::NotificationARNs": []string{"notification::arn::1", "notification::arn::2"},
+		"AWS::NoValue":          "",
+		"AWS::Partition":        partition,
+		"AWS::Region":           c.region,
+		"AWS::StackId":          fmt.Sprintf("arn:%s:cloudformation:%s:%s:stack/%s/ID", partition, c.region, c.accountID, c.stackName),
+		"AWS::StackName":        c.stackName,
+		"AWS::URLSuffix":        urlSuffix,
+	}
+}
+
+func partitionForRegion(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return "aws-cn"
+	case strings.HasPrefix(region, "us-gov-"):
+		return "aws-us-gov"
+	default:
+		return "aws"
+	}
+}
+
+// pseudoParameterValues returns the pseudo parameters of the template the property belongs to
+func (p *Property) pseudoParameterValues() map[string]interface{} {
+	if p.ctx == nil || p.ctx.pseudoParameters == nil {
+		return defaultPseudoParameterConfig().values()
+	}
+	return p.ctx.pseudoParameters
+}
+
+// pseudoRegion returns the value of AWS::Region for the template the property belongs to
+func (p *Property) pseudoRegion() string {
+	region, _ := p.pseudoParameterValues()["AWS::Region"].(string)
+	return region
 }
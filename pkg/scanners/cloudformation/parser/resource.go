@@ -19,6 +19,7 @@ type Resource struct {
 
 type ResourceInner struct {
 	Type       string               `json:"Type" yaml:"Type"`
+	Condition  string               `json:"Condition,omitempty" yaml:"Condition"`
 	Properties map[string]*Property `json:"Properties" yaml:"Properties"`
 }
 
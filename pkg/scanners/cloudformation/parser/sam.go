@@ -0,0 +1,311 @@
+package parser
+
+import (
+	"sort"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/cloudformation/cftypes"
+)
+
+const serverlessTransform = "AWS::Serverless-2016-10-31"
+
+// serverlessGlobals maps the sections of the SAM Globals to the resource types they apply to
+var serverlessGlobals = map[string]string{
+	"Function":    "AWS::Serverless::Function",
+	"Api":         "AWS::Serverless::Api",
+	"HttpApi":     "AWS::Serverless::HttpApi",
+	"SimpleTable": "AWS::Serverless::SimpleTable",
+}
+
+// usesServerlessTransform returns true if the template declares the SAM transform
+func (t *FileContext) usesServerlessTransform() bool {
+	if t.Transform.IsString() {
+		return t.Transform.AsString() == serverlessTransform
+	}
+	if t.Transform.IsNotList() {
+		return false
+	}
+	for _, transform := range t.Transform.AsList() {
+		if transform.IsString() && transform.AsString() == serverlessTransform {
+			return true
+		}
+	}
+	return false
+}
+
+// expandServerless replaces SAM resources with the CloudFormation resources the SAM transform generates for them, so
+// that checks for the underlying services also apply to SAM templates. The generated resources use the range of the
+// SAM resource they were created from, and the original SAM resources are only kept for the SAM adapter, see
+// GetSAMResourcesByType. Resources must already be configured, as properties are resolved during expansion.
+func (p *Parser) expandServerless(context *FileContext) {
+	if context.Resources == nil {
+		return
+	}
+
+	ids := sortedResourceIDs(context)
+	for _, id := range ids {
+		if resource := context.Resources[id]; resource != nil && resource.Type() == "AWS::Serverless::Function" {
+			addImplicitApis(context, resource)
+		}
+	}
+
+	for _, id := range sortedResourceIDs(context) {
+		resource := context.Resources[id]
+		if resource == nil {
+			continue
+		}
+		section, ok := globalSection(resource.Type())
+		if !ok {
+			continue
+		}
+		applyGlobals(resource, context.Globals[section])
+		resource.ConfigureResource(id, context.filepath, context)
+
+		p.debug("expanding SAM resource '%s' of type %s", id, resource.Type())
+		switch resource.Type() {
+		case "AWS::Serverless::Function":
+			expandFunction(context, id, resource)
+		case "AWS::Serverless::Api":
+			expandApi(context, id, resource)
+		case "AWS::Serverless::HttpApi":
+			expandHttpApi(context, id, resource)
+		case "AWS::Serverless::SimpleTable":
+			expandSimpleTable(context, id, resource)
+		}
+
+		if context.serverless == nil {
+			context.serverless = make(map[string]*Resource)
+		}
+		context.serverless[id] = resource
+		if context.Resources[id] == resource {
+			delete(context.Resources, id)
+		}
+	}
+}
+
+func globalSection(resourceType string) (string, bool) {
+	for section, t := range serverlessGlobals {
+		if t == resourceType {
+			return section, true
+		}
+	}
+	return "", false
+}
+
+// applyGlobals merges the globals into the properties of the resource. Properties set on the resource take precedence,
+// maps are merged and lists are appended to the global values.
+func applyGlobals(resource *Resource, globals *Property) {
+	if globals.IsNotMap() || globals.isFunction() {
+		return
+	}
+	if resource.Inner.Properties == nil {
+		resource.Inner.Properties = make(map[string]*Property)
+	}
+	for name, global := range globals.AsMap() {
+		resource.Inner.Properties[name] = mergeGlobal(global, resource.Inner.Properties[name])
+	}
+}
+
+func mergeGlobal(global *Property, value *Property) *Property {
+	if global == nil {
+		return value
+	}
+	if value == nil {
+		return global.clone()
+	}
+	switch {
+	case global.Type() == cftypes.Map && value.Type() == cftypes.Map && !global.isFunction() && !value.isFunction():
+		merged := make(map[string]*Property)
+		for name, property := range value.AsMap() {
+			merged[name] = property
+		}
+		for name, property := range global.AsMap() {
+			merged[name] = mergeGlobal(property, merged[name])
+		}
+		value.Inner.Value = merged
+	case global.Type() == cftypes.List && value.Type() == cftypes.List:
+		var merged []*Property
+		for _, property := range global.AsList() {
+			merged = append(merged, property.clone())
+		}
+		value.Inner.Value = append(merged, value.AsList()...)
+	}
+	return value
+}
+
+// addImplicitApis adds the APIs which SAM creates for function events that do not refer to an API in the template
+func addImplicitApis(context *FileContext, function *Resource) {
+	for _, name := range sortedPropertyNames(function.GetProperty("Events")) {
+		event := function.GetProperty("Events").AsMap()[name]
+		switch stringValue(event.GetProperty("Type")) {
+		case "Api":
+			if event.GetProperty("Properties.RestApiId").IsNil() {
+				addImplicitApi(context, function, "ServerlessRestApi", "AWS::Serverless::Api", map[string]interface{}{
+					"StageName": "Prod",
+				})
+			}
+		case "HttpApi":
+			if event.GetProperty("Properties.ApiId").IsNil() {
+				addImplicitApi(context, function, "ServerlessHttpApi", "AWS::Serverless::HttpApi", map[string]interface{}{})
+			}
+		}
+	}
+}
+
+func addImplicitApi(context *FileContext, function *Resource, id string, resourceType string, properties map[string]interface{}) {
+	if _, exists := context.Resources[id]; exists {
+		return
+	}
+	context.addGeneratedResource(function, id, resourceType, properties)
+}
+
+// addGeneratedResource adds a resource generated from the given source resource, with the range of the source resource.
+// Values are converted to properties with newProperty. Existing resources are not replaced, unless it is the source.
+func (t *FileContext) addGeneratedResource(source *Resource, id string, resourceType string, properties map[string]interface{}) *Resource {
+	if existing, ok := t.Resources[id]; ok && existing != source {
+		return existing
+	}
+	generated := &Resource{
+		rng:     source.rng,
+		comment: source.comment,
+		Inner: ResourceInner{
+			Type:       resourceType,
+			Condition:  source.Inner.Condition,
+			Properties: make(map[string]*Property),
+		},
+	}
+	for name, value := range properties {
+		if property := newProperty(source, value); property != nil {
+			generated.Inner.Properties[name] = property
+		}
+	}
+	t.Resources[id] = generated
+	generated.ConfigureResource(id, t.filepath, t)
+	return generated
+}
+
+// copyProperties returns copies of the named properties of the resource which are set
+func copyProperties(resource *Resource, names ...string) map[string]interface{} {
+	properties := make(map[string]interface{})
+	for _, name := range names {
+		if property, ok := resource.Inner.Properties[name]; ok && property != nil {
+			properties[name] = property
+		}
+	}
+	return properties
+}
+
+// newProperty converts a value to a property, using the range of the resource it was generated from. Properties are
+// copied, so the same property can be used for more than one resource. Values of unsupported types are skipped, and
+// nil is returned.
+func newProperty(source *Resource, value interface{}) *Property {
+	property := &Property{
+		rng:       source.rng,
+		generated: true,
+	}
+	switch v := value.(type) {
+	case *Property:
+		if v == nil {
+			return nil
+		}
+		cloned := v.clone()
+		cloned.setGenerated()
+		return cloned
+	case string:
+		property.Inner = PropertyInner{Type: cftypes.String, Value: v}
+	case bool:
+		property.Inner = PropertyInner{Type: cftypes.Bool, Value: v}
+	case int:
+		property.Inner = PropertyInner{Type: cftypes.Int, Value: v}
+	case []interface{}:
+		var items []*Property
+		for _, item := range v {
+			if itemProperty := newProperty(source, item); itemProperty != nil {
+				items = append(items, itemProperty)
+			}
+		}
+		property.Inner = PropertyInner{Type: cftypes.List, Value: items}
+	case map[string]interface{}:
+		items := make(map[string]*Property)
+		for name, item := range v {
+			if itemProperty := newProperty(source, item); itemProperty != nil {
+				items[name] = itemProperty
+			}
+		}
+		property.Inner = PropertyInner{Type: cftypes.Map, Value: items}
+	default:
+		return nil
+	}
+	return property
+}
+
+func (p *Property) clone() *Property {
+	if p == nil {
+		return nil
+	}
+	cloned := *p
+	switch v := p.Inner.Value.(type) {
+	case map[string]*Property:
+		items := make(map[string]*Property, len(v))
+		for name, item := range v {
+			items[name] = item.clone()
+		}
+		cloned.Inner.Value = items
+	case []*Property:
+		items := make([]*Property, 0, len(v))
+		for _, item := range v {
+			items = append(items, item.clone())
+		}
+		cloned.Inner.Value = items
+	}
+	return &cloned
+}
+
+func (p *Property) setGenerated() {
+	p.generated = true
+	switch v := p.Inner.Value.(type) {
+	case map[string]*Property:
+		for _, item := range v {
+			if item != nil {
+				item.setGenerated()
+			}
+		}
+	case []*Property:
+		for _, item := range v {
+			if item != nil {
+				item.setGenerated()
+			}
+		}
+	}
+}
+
+func sortedPropertyNames(property *Property) []string {
+	if property.IsNotMap() || property.isFunction() {
+		return nil
+	}
+	names := make([]string, 0, len(property.AsMap()))
+	for name := range property.AsMap() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// stringValue returns the resolved string value of the property, or an empty string if it is not a string
+func stringValue(property *Property) string {
+	if property.IsString() {
+		return property.AsString()
+	}
+	return ""
+}
+
+func ref(id string) map[string]interface{} {
+	return map[string]interface{}{"Ref": id}
+}
+
+func getAtt(id string, attribute string) map[string]interface{} {
+	return map[string]interface{}{"Fn::GetAtt": []interface{}{id, attribute}}
+}
+
+func sub(format string, variables map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"Fn::Sub": []interface{}{format, variables}}
+}
@@ -0,0 +1,64 @@
+package parser
+
+// expandApi generates the REST API and the stage for an AWS::Serverless::Api
+func expandApi(context *FileContext, id string, resource *Resource) {
+	context.addGeneratedResource(resource, id, "AWS::ApiGateway::RestApi", copyProperties(resource,
+		"Name", "Description", "EndpointConfiguration", "BinaryMediaTypes", "MinimumCompressionSize",
+		"DisableExecuteApiEndpoint", "Tags",
+	))
+
+	stage := copyProperties(resource,
+		"StageName", "TracingEnabled", "AccessLogSetting", "MethodSettings", "CacheClusterEnabled",
+		"CacheClusterSize", "Variables", "CanarySetting", "Tags",
+	)
+	stage["RestApiId"] = ref(id)
+	stageName := stringValue(resource.GetProperty("StageName"))
+	context.addGeneratedResource(resource, id+stageName+"Stage", "AWS::ApiGateway::Stage", stage)
+}
+
+// expandHttpApi generates the HTTP API and the stage for an AWS::Serverless::HttpApi
+func expandHttpApi(context *FileContext, id string, resource *Resource) {
+	api := copyProperties(resource, "Name", "Description", "CorsConfiguration", "DisableExecuteApiEndpoint", "Tags")
+	api["ProtocolType"] = "HTTP"
+	context.addGeneratedResource(resource, id, "AWS::ApiGatewayV2::Api", api)
+
+	stage := copyProperties(resource, "AccessLogSettings", "DefaultRouteSettings", "RouteSettings", "StageVariables")
+	stage["ApiId"] = ref(id)
+	stage["AutoDeploy"] = true
+	stageID := id + "ApiGatewayDefaultStage"
+	if stageName := resource.Inner.Properties["StageName"]; stageName != nil {
+		stage["StageName"] = stageName
+		stageID = id + stringValue(resource.GetProperty("StageName")) + "Stage"
+	} else {
+		stage["StageName"] = "$default"
+	}
+	context.addGeneratedResource(resource, stageID, "AWS::ApiGatewayV2::Stage", stage)
+}
+
+// expandSimpleTable generates the DynamoDB table for an AWS::Serverless::SimpleTable
+func expandSimpleTable(context *FileContext, id string, resource *Resource) {
+	table := copyProperties(resource, "TableName", "SSESpecification", "ProvisionedThroughput", "Tags")
+
+	primaryKey := resource.GetProperty("PrimaryKey")
+	name, keyType := "id", "S"
+	if primaryKey.IsMap() {
+		if value := stringValue(primaryKey.GetProperty("Name")); value != "" {
+			name = value
+		}
+		if value := stringValue(primaryKey.GetProperty("Type")); value == "Number" {
+			keyType = "N"
+		} else if value == "Binary" {
+			keyType = "B"
+		}
+	}
+	table["AttributeDefinitions"] = []interface{}{
+		map[string]interface{}{"AttributeName": name, "AttributeType": keyType},
+	}
+	table["KeySchema"] = []interface{}{
+		map[string]interface{}{"AttributeName": name, "KeyType": "HASH"},
+	}
+	if _, ok := table["ProvisionedThroughput"]; !ok {
+		table["BillingMode"] = "PAY_PER_REQUEST"
+	}
+	context.addGeneratedResource(resource, id, "AWS::DynamoDB::Table", table)
+}
@@ -0,0 +1,186 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+const lambdaBasicExecutionRole = "arn:aws:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole"
+
+// eventSourcePolicies are the managed policies SAM adds to the role of a function for each type of event source
+var eventSourcePolicies = map[string]string{
+	"SQS":      "arn:aws:iam::aws:policy/service-role/AWSLambdaSQSQueueExecutionRole",
+	"Kinesis":  "arn:aws:iam::aws:policy/service-role/AWSLambdaKinesisExecutionRole",
+	"DynamoDB": "arn:aws:iam::aws:policy/service-role/AWSLambdaDynamoDBExecutionRole",
+}
+
+// expandFunction generates the function, its execution role, and the permissions and event source mappings for its events
+func expandFunction(context *FileContext, id string, resource *Resource) {
+	properties := copyProperties(resource,
+		"FunctionName", "Description", "Runtime", "Handler", "MemorySize", "Timeout", "Environment", "VpcConfig",
+		"KmsKeyArn", "Layers", "ReservedConcurrentExecutions", "Architectures", "DeadLetterConfig", "Tags",
+		"ImageConfig", "PackageType", "EphemeralStorage", "FileSystemConfigs", "CodeSigningConfigArn",
+	)
+	if tracing := resource.GetProperty("Tracing"); tracing.IsNotNil() {
+		properties["TracingConfig"] = map[string]interface{}{"Mode": resource.Inner.Properties["Tracing"]}
+	}
+	if role := resource.Inner.Properties["Role"]; role != nil {
+		properties["Role"] = role
+	} else {
+		roleID := id + "Role"
+		expandFunctionRole(context, roleID, resource)
+		properties["Role"] = getAtt(roleID, "Arn")
+	}
+
+	context.addGeneratedResource(resource, id, "AWS::Lambda::Function", properties)
+
+	events := resource.GetProperty("Events")
+	for _, name := range sortedPropertyNames(events) {
+		expandEvent(context, id, resource, name, events.AsMap()[name])
+	}
+}
+
+func expandFunctionRole(context *FileContext, id string, resource *Resource) {
+	managedPolicies := []interface{}{lambdaBasicExecutionRole}
+	if stringValue(resource.GetProperty("Tracing")) == "Active" {
+		managedPolicies = append(managedPolicies, "arn:aws:iam::aws:policy/AWSXrayWriteOnlyAccess")
+	}
+	events := resource.GetProperty("Events")
+	for _, name := range sortedPropertyNames(events) {
+		if policy, ok := eventSourcePolicies[stringValue(events.AsMap()[name].GetProperty("Type"))]; ok {
+			managedPolicies = append(managedPolicies, policy)
+		}
+	}
+
+	var inlinePolicies []interface{}
+	policies := resource.Inner.Properties["Policies"]
+	items := []*Property{policies}
+	if policies.IsList() {
+		items = policies.AsList()
+	}
+	for _, policy := range items {
+		switch {
+		case policy.IsNil():
+			continue
+		case policy.IsString():
+			managedPolicies = append(managedPolicies, managedPolicyArn(policy.AsString()))
+		case policy.IsMap() && policy.GetProperty("Statement").IsNotNil():
+			inlinePolicies = append(inlinePolicies, map[string]interface{}{
+				"PolicyName":     fmt.Sprintf("%sPolicy%d", id, len(inlinePolicies)),
+				"PolicyDocument": policy,
+			})
+		case policy.IsMap():
+			if document, ok := policyTemplateDocument(policy); ok {
+				inlinePolicies = append(inlinePolicies, map[string]interface{}{
+					"PolicyName":     fmt.Sprintf("%sPolicy%d", id, len(inlinePolicies)),
+					"PolicyDocument": document,
+				})
+			}
+		}
+	}
+
+	properties := map[string]interface{}{
+		"AssumeRolePolicyDocument": map[string]interface{}{
+			"Version": "2012-10-17",
+			"Statement": []interface{}{
+				map[string]interface{}{
+					"Effect":    "Allow",
+					"Action":    []interface{}{"sts:AssumeRole"},
+					"Principal": map[string]interface{}{"Service": []interface{}{"lambda.amazonaws.com"}},
+				},
+			},
+		},
+		"ManagedPolicyArns": managedPolicies,
+	}
+	if len(inlinePolicies) > 0 {
+		properties["Policies"] = inlinePolicies
+	}
+	if boundary := resource.Inner.Properties["PermissionsBoundary"]; boundary != nil {
+		properties["PermissionsBoundary"] = boundary
+	}
+	context.addGeneratedResource(resource, id, "AWS::IAM::Role", properties)
+}
+
+// managedPolicyArn returns the ARN of a managed policy, which SAM allows to be given by name for AWS managed policies
+func managedPolicyArn(policy string) string {
+	if strings.HasPrefix(policy, "arn:") {
+		return policy
+	}
+	return "arn:aws:iam::aws:policy/" + policy
+}
+
+func expandEvent(context *FileContext, functionID string, resource *Resource, name string, event *Property) {
+	eventType := stringValue(event.GetProperty("Type"))
+	eventProperties := event.GetProperty("Properties")
+	permissionID := functionID + name + "Permission"
+
+	switch eventType {
+	case "Api", "HttpApi":
+		apiProperty, implicitApi := "RestApiId", "ServerlessRestApi"
+		if eventType == "HttpApi" {
+			apiProperty, implicitApi = "ApiId", "ServerlessHttpApi"
+		}
+		var api interface{} = ref(implicitApi)
+		if eventProperties.GetProperty(apiProperty).IsNotNil() {
+			api = eventProperties.AsMap()[apiProperty]
+		}
+		addPermission(context, resource, permissionID, functionID, "apigateway.amazonaws.com",
+			sub("arn:aws:execute-api:${AWS::Region}:${AWS::AccountId}:${api}/*", map[string]interface{}{"api": api}))
+	case "SNS":
+		addPermission(context, resource, permissionID, functionID, "sns.amazonaws.com", eventProperties.GetProperty("Topic"))
+		context.addGeneratedResource(resource, functionID+name, "AWS::SNS::Subscription", map[string]interface{}{
+			"Endpoint": getAtt(functionID, "Arn"),
+			"Protocol": "lambda",
+			"TopicArn": eventProperties.GetProperty("Topic"),
+		})
+	case "S3":
+		addPermission(context, resource, permissionID, functionID, "s3.amazonaws.com", nil)
+	case "Schedule", "EventBridgeRule", "CloudWatchEvent":
+		addPermission(context, resource, permissionID, functionID, "events.amazonaws.com", getAtt(functionID+name, "Arn"))
+	case "SQS", "Kinesis", "DynamoDB":
+		properties := map[string]interface{}{
+			"FunctionName": ref(functionID),
+		}
+		for key, value := range copyEventProperties(eventProperties, "BatchSize", "Enabled", "StartingPosition",
+			"MaximumBatchingWindowInSeconds", "FilterCriteria", "FunctionResponseTypes") {
+			properties[key] = value
+		}
+		source := "Stream"
+		if eventType == "SQS" {
+			source = "Queue"
+		}
+		if arn := eventProperties.GetProperty(source); arn.IsNotNil() {
+			properties["EventSourceArn"] = eventProperties.AsMap()[source]
+		}
+		context.addGeneratedResource(resource, functionID+name, "AWS::Lambda::EventSourceMapping", properties)
+	}
+}
+
+func addPermission(context *FileContext, resource *Resource, id string, functionID string, principal string, sourceArn interface{}) {
+	properties := map[string]interface{}{
+		"Action":       "lambda:InvokeFunction",
+		"FunctionName": ref(functionID),
+		"Principal":    principal,
+	}
+	if property, ok := sourceArn.(*Property); ok {
+		if property.IsNotNil() {
+			properties["SourceArn"] = property
+		}
+	} else if sourceArn != nil {
+		properties["SourceArn"] = sourceArn
+	}
+	context.addGeneratedResource(resource, id, "AWS::Lambda::Permission", properties)
+}
+
+func copyEventProperties(properties *Property, names ...string) map[string]interface{} {
+	copied := make(map[string]interface{})
+	if properties.IsNotMap() {
+		return copied
+	}
+	for _, name := range names {
+		if property, ok := properties.AsMap()[name]; ok && property != nil {
+			copied[name] = property
+		}
+	}
+	return copied
+}
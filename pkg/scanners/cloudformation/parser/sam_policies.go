@@ -0,0 +1,132 @@
+package parser
+
+// policyTemplate is one of the SAM policy templates, which expand to a policy document from the given parameters
+type policyTemplate struct {
+	parameters []string
+	actions    []interface{}
+	resources  []string
+}
+
+var s3ReadActions = []interface{}{
+	"s3:GetObject", "s3:ListBucket", "s3:GetBucketLocation", "s3:GetObjectVersion", "s3:GetLifecycleConfiguration",
+}
+
+var s3WriteActions = []interface{}{
+	"s3:PutObject", "s3:PutObjectAcl", "s3:PutLifecycleConfiguration",
+}
+
+var dynamoDBReadActions = []interface{}{
+	"dynamodb:GetItem", "dynamodb:Scan", "dynamodb:Query", "dynamodb:BatchGetItem", "dynamodb:DescribeTable",
+}
+
+var dynamoDBTableResources = []string{
+	"arn:aws:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${TableName}",
+	"arn:aws:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${TableName}/index/*",
+}
+
+var s3BucketResources = []string{
+	"arn:aws:s3:::${BucketName}",
+	"arn:aws:s3:::${BucketName}/*",
+}
+
+// policyTemplates are the commonly used SAM policy templates, see
+// https://docs.aws.amazon.com/serverless-application-model/latest/developerguide/serverless-policy-template-list.html
+var policyTemplates = map[string]policyTemplate{
+	"S3ReadPolicy": {
+		parameters: []string{"BucketName"},
+		actions:    s3ReadActions,
+		resources:  s3BucketResources,
+	},
+	"S3WritePolicy": {
+		parameters: []string{"BucketName"},
+		actions:    s3WriteActions,
+		resources:  s3BucketResources,
+	},
+	"S3CrudPolicy": {
+		parameters: []string{"BucketName"},
+		actions:    append(append(append([]interface{}{}, s3ReadActions...), s3WriteActions...), "s3:DeleteObject"),
+		resources:  s3BucketResources,
+	},
+	"SQSPollerPolicy": {
+		parameters: []string{"QueueName"},
+		actions: []interface{}{
+			"sqs:ChangeMessageVisibility", "sqs:ChangeMessageVisibilityBatch", "sqs:DeleteMessage",
+			"sqs:DeleteMessageBatch", "sqs:GetQueueAttributes", "sqs:ReceiveMessage",
+		},
+		resources: []string{"arn:aws:sqs:${AWS::Region}:${AWS::AccountId}:${QueueName}"},
+	},
+	"SQSSendMessagePolicy": {
+		parameters: []string{"QueueName"},
+		actions:    []interface{}{"sqs:SendMessage*"},
+		resources:  []string{"arn:aws:sqs:${AWS::Region}:${AWS::AccountId}:${QueueName}"},
+	},
+	"DynamoDBReadPolicy": {
+		parameters: []string{"TableName"},
+		actions:    dynamoDBReadActions,
+		resources:  dynamoDBTableResources,
+	},
+	"DynamoDBCrudPolicy": {
+		parameters: []string{"TableName"},
+		actions: append(append([]interface{}{}, dynamoDBReadActions...),
+			"dynamodb:PutItem", "dynamodb:UpdateItem", "dynamodb:DeleteItem", "dynamodb:BatchWriteItem", "dynamodb:ConditionCheckItem"),
+		resources: dynamoDBTableResources,
+	},
+	"LambdaInvokePolicy": {
+		parameters: []string{"FunctionName"},
+		actions:    []interface{}{"lambda:InvokeFunction"},
+		resources:  []string{"arn:aws:lambda:${AWS::Region}:${AWS::AccountId}:function:${FunctionName}*"},
+	},
+	"SNSPublishMessagePolicy": {
+		parameters: []string{"TopicName"},
+		actions:    []interface{}{"sns:Publish"},
+		resources:  []string{"arn:aws:sns:${AWS::Region}:${AWS::AccountId}:${TopicName}"},
+	},
+	"KMSDecryptPolicy": {
+		parameters: []string{"KeyId"},
+		actions:    []interface{}{"kms:Decrypt"},
+		resources:  []string{"arn:aws:kms:${AWS::Region}:${AWS::AccountId}:key/${KeyId}"},
+	},
+	"AWSSecretsManagerGetSecretValuePolicy": {
+		parameters: []string{"SecretArn"},
+		actions:    []interface{}{"secretsmanager:GetSecretValue"},
+		resources:  []string{"${SecretArn}"},
+	},
+	"CloudWatchPutMetricPolicy": {
+		actions:   []interface{}{"cloudwatch:PutMetricData"},
+		resources: []string{"*"},
+	},
+}
+
+// policyTemplateDocument returns the policy document for a SAM policy template such as {"S3ReadPolicy": {"BucketName": "x"}}
+func policyTemplateDocument(policy *Property) (map[string]interface{}, bool) {
+	if len(policy.AsMap()) != 1 {
+		return nil, false
+	}
+	for name, parameters := range policy.AsMap() {
+		template, ok := policyTemplates[name]
+		if !ok {
+			return nil, false
+		}
+		variables := make(map[string]interface{})
+		for _, parameter := range template.parameters {
+			if value := parameters.GetProperty(parameter); value.IsNotNil() {
+				variables[parameter] = parameters.AsMap()[parameter]
+			}
+		}
+		var resources []interface{}
+		for _, resource := range template.resources {
+			resources = append(resources, sub(resource, variables))
+		}
+		return map[string]interface{}{
+			"Version": "2012-10-17",
+			"Statement": []interface{}{
+				map[string]interface{}{
+					"Effect":   "Allow",
+					"Action":   template.actions,
+					"Resource": resources,
+				},
+			},
+		}, true
+	}
+	return nil, false
+}
@@ -0,0 +1,163 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/defsec/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const samTemplate = `---
+Transform: AWS::Serverless-2016-10-31
+Globals:
+  Function:
+    Runtime: python3.9
+    Tracing: Active
+    Environment:
+      Variables:
+        STAGE: prod
+  Api:
+    TracingEnabled: true
+Resources:
+  Queue:
+    Type: AWS::SQS::Queue
+  Handler:
+    Type: AWS::Serverless::Function
+    Properties:
+      Handler: app.handler
+      Environment:
+        Variables:
+          TABLE: orders
+      Policies:
+        - AWSLambdaExecute
+        - S3ReadPolicy:
+            BucketName: data
+        - Statement:
+            - Effect: Allow
+              Action: "*"
+              Resource: "*"
+      Events:
+        Get:
+          Type: Api
+          Properties:
+            Path: /
+            Method: get
+        Messages:
+          Type: SQS
+          Properties:
+            Queue: !GetAtt Queue.Arn
+            BatchSize: 10
+  Http:
+    Type: AWS::Serverless::HttpApi
+    Properties:
+      StageName: live
+`
+
+func Test_parse_sam_template(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"template.yaml": samTemplate,
+	})
+
+	ctx, err := New().ParseFile(context.TODO(), fs, "template.yaml")
+	require.NoError(t, err)
+
+	function := ctx.GetResourceByLogicalID("Handler")
+	require.NotNil(t, function)
+	assert.Equal(t, "AWS::Lambda::Function", function.Type())
+	assert.Equal(t, "python3.9", function.GetStringProperty("Runtime").Value())
+	assert.Equal(t, "Active", function.GetStringProperty("TracingConfig.Mode").Value())
+	assert.Equal(t, "prod", function.GetStringProperty("Environment.Variables.STAGE").Value())
+	assert.Equal(t, "orders", function.GetStringProperty("Environment.Variables.TABLE").Value())
+	assert.Equal(t, "HandlerRole", function.GetStringProperty("Role").Value())
+
+	// generated resources point back at the SAM resource
+	assert.Equal(t, 15, function.Range().GetStartLine())
+
+	role := ctx.GetResourceByLogicalID("HandlerRole")
+	require.NotNil(t, role)
+	assert.Equal(t, "AWS::IAM::Role", role.Type())
+	assert.Equal(t, function.Range(), role.Range())
+	var managed []string
+	for _, arn := range role.GetProperty("ManagedPolicyArns").AsList() {
+		managed = append(managed, arn.AsString())
+	}
+	assert.Equal(t, []string{
+		"arn:aws:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole",
+		"arn:aws:iam::aws:policy/AWSXrayWriteOnlyAccess",
+		"arn:aws:iam::aws:policy/service-role/AWSLambdaSQSQueueExecutionRole",
+		"arn:aws:iam::aws:policy/AWSLambdaExecute",
+	}, managed)
+
+	policies := role.GetProperty("Policies").AsList()
+	require.Len(t, policies, 2)
+	statements := policies[0].GetProperty("PolicyDocument.Statement").AsList()
+	require.Len(t, statements, 1)
+	resources := statements[0].GetProperty("Resource").AsList()
+	require.Len(t, resources, 2)
+	assert.Equal(t, "arn:aws:s3:::data", resources[0].AsString())
+
+	permission := ctx.GetResourceByLogicalID("HandlerGetPermission")
+	require.NotNil(t, permission)
+	assert.Equal(t, "AWS::Lambda::Permission", permission.Type())
+	assert.Equal(t, "Handler", permission.GetStringProperty("FunctionName").Value())
+	assert.Equal(t, "arn:aws:execute-api:eu-west-1:123456789012:ServerlessRestApi/*", permission.GetStringProperty("SourceArn").Value())
+
+	mapping := ctx.GetResourceByLogicalID("HandlerMessages")
+	require.NotNil(t, mapping)
+	assert.Equal(t, "AWS::Lambda::EventSourceMapping", mapping.Type())
+	assert.Equal(t, 10, mapping.GetIntProperty("BatchSize").Value())
+
+	api := ctx.GetResourceByLogicalID("ServerlessRestApi")
+	require.NotNil(t, api)
+	assert.Equal(t, "AWS::ApiGateway::RestApi", api.Type())
+	stage := ctx.GetResourceByLogicalID("ServerlessRestApiProdStage")
+	require.NotNil(t, stage)
+	assert.Equal(t, "ServerlessRestApi", stage.GetStringProperty("RestApiId").Value())
+	assert.True(t, stage.GetBoolProperty("TracingEnabled").IsTrue())
+
+	httpStage := ctx.GetResourceByLogicalID("HttpliveStage")
+	require.NotNil(t, httpStage)
+	assert.Equal(t, "AWS::ApiGatewayV2::Stage", httpStage.Type())
+	assert.Equal(t, "live", httpStage.GetStringProperty("StageName").Value())
+
+	// the SAM resources are only available to the SAM adapter
+	assert.Empty(t, ctx.GetResourcesByType("AWS::Serverless::Function", "AWS::Serverless::Api"))
+	require.Len(t, ctx.GetSAMResourcesByType("AWS::Serverless::Function"), 1)
+	require.Len(t, ctx.GetSAMResourcesByType("AWS::Serverless::Api"), 1)
+}
+
+func Test_parse_sam_template_without_transform(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"template.yaml": `---
+Resources:
+  Handler:
+    Type: AWS::Serverless::Function
+    Properties:
+      Handler: app.handler
+`,
+	})
+
+	ctx, err := New().ParseFile(context.TODO(), fs, "template.yaml")
+	require.NoError(t, err)
+
+	function := ctx.GetResourceByLogicalID("Handler")
+	require.NotNil(t, function)
+	assert.Equal(t, "AWS::Serverless::Function", function.Type())
+	assert.Nil(t, ctx.GetResourceByLogicalID("HandlerRole"))
+}
+
+func Test_newProperty_skips_unsupported_values(t *testing.T) {
+	source := &Resource{}
+
+	assert.Nil(t, newProperty(source, struct{}{}))
+
+	list := newProperty(source, []interface{}{"a", struct{}{}, nil, "b"})
+	require.NotNil(t, list)
+	assert.Len(t, list.AsList(), 2)
+
+	object := newProperty(source, map[string]interface{}{"Name": "a", "Other": struct{}{}})
+	require.NotNil(t, object)
+	assert.Len(t, object.AsMap(), 1)
+}
@@ -0,0 +1,214 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/cloudformation/cftypes"
+)
+
+const nestedStackType = "AWS::CloudFormation::Stack"
+
+// resolveStacks parses the templates of nested stacks with the parameters passed by their parent stacks, in place of the
+// standalone templates, and links exported outputs so that Fn::ImportValue can be resolved across templates.
+func (p *Parser) resolveStacks(ctx context.Context, target fs.FS, contexts FileContexts) (FileContexts, error) {
+	nested := make(map[string]bool)
+	for _, c := range contexts {
+		for _, id := range sortedResourceIDs(c) {
+			if templatePath, ok := p.stackTemplatePath(target, c, c.Resources[id]); ok {
+				nested[templatePath] = true
+			}
+		}
+	}
+
+	var resolved FileContexts
+	visited := make(map[string]bool)
+	for _, c := range contexts {
+		if nested[c.filepath] {
+			continue
+		}
+		visited[c.filepath] = true
+		children, err := p.parseNestedStacks(ctx, target, c, []string{c.filepath}, visited)
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, c)
+		resolved = append(resolved, children...)
+	}
+
+	// templates which are only referenced by each other are scanned on their own
+	for _, c := range contexts {
+		if !visited[c.filepath] {
+			resolved = append(resolved, c)
+		}
+	}
+
+	p.linkExports(resolved)
+	return resolved, nil
+}
+
+func (p *Parser) parseNestedStacks(ctx context.Context, target fs.FS, parent *FileContext, chain []string, visited map[string]bool) (FileContexts, error) {
+	var children FileContexts
+	for _, id := range sortedResourceIDs(parent) {
+		resource := parent.Resources[id]
+		templatePath, ok := p.stackTemplatePath(target, parent, resource)
+		if !ok {
+			continue
+		}
+		if contains(chain, templatePath) {
+			p.debug("nested stack '%s' in %s refers to a template which is already being parsed, skipping", id, parent.filepath)
+			continue
+		}
+
+		p.debug("parsing template %s for nested stack '%s' in %s", templatePath, id, parent.filepath)
+		child, err := p.parseFile(ctx, target, templatePath, stackParameters(resource))
+		if err != nil {
+			return nil, err
+		}
+		visited[templatePath] = true
+		if parent.stacks == nil {
+			parent.stacks = make(map[string]*FileContext)
+		}
+		parent.stacks[id] = child
+		children = append(children, child)
+
+		descendants, err := p.parseNestedStacks(ctx, target, child, append(chain, templatePath), visited)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, descendants...)
+	}
+	return children, nil
+}
+
+// stackTemplatePath returns the path of the template for a nested stack resource, if its TemplateURL refers to a file
+// in the filesystem being scanned. Paths are relative to the parent template.
+func (p *Parser) stackTemplatePath(target fs.FS, parent *FileContext, resource *Resource) (string, bool) {
+	if resource == nil || resource.Type() != nestedStackType {
+		return "", false
+	}
+	templateURL := resource.GetProperty("TemplateURL")
+	if templateURL.IsNotString() {
+		return "", false
+	}
+	location := strings.TrimPrefix(templateURL.AsString(), "file://")
+	if location == "" || strings.Contains(location, "://") {
+		p.debug("template for nested stack '%s' is not a local file, skipping", resource.ID())
+		return "", false
+	}
+	if !path.IsAbs(location) {
+		location = path.Join(path.Dir(parent.filepath), location)
+	}
+	location = strings.TrimPrefix(path.Clean(location), "/")
+	if info, err := fs.Stat(target, location); err != nil || info.IsDir() {
+		p.debug("template %s for nested stack '%s' was not found, skipping", location, resource.ID())
+		return "", false
+	}
+	return location, true
+}
+
+// stackParameters returns the parameters passed to a nested stack which can be resolved in the parent template
+func stackParameters(resource *Resource) map[string]Parameter {
+	passed := resource.GetProperty("Parameters")
+	if passed.IsNotMap() {
+		return nil
+	}
+	parameters := make(map[string]Parameter)
+	for name, property := range passed.AsMap() {
+		value, ok := property.resolveValue()
+		if !ok || value.IsNil() || value.isFunction() {
+			continue
+		}
+		var parameter string
+		switch value.Type() {
+		case cftypes.Map:
+			continue
+		case cftypes.List:
+			var items []string
+			for _, item := range value.AsList() {
+				resolvedItem, _ := item.resolveValue()
+				items = append(items, fmt.Sprintf("%v", resolvedItem.RawValue()))
+			}
+			parameter = strings.Join(items, ",")
+		default:
+			parameter = fmt.Sprintf("%v", value.RawValue())
+		}
+		parameters[name] = Parameter{
+			inner: parameterInner{
+				Default: parameter,
+			},
+		}
+	}
+	return parameters
+}
+
+// linkExports makes the exported outputs of every template available to Fn::ImportValue in all of the templates
+func (p *Parser) linkExports(contexts FileContexts) {
+	exports := make(map[string]*Property)
+	for _, c := range contexts {
+		names := make([]string, 0, len(c.Outputs))
+		for name := range c.Outputs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			output := c.Outputs[name]
+			if condition := output.GetProperty("Condition"); condition.IsString() {
+				if value, known := c.evaluateCondition(condition.AsString()); known && !value {
+					continue
+				}
+			}
+			exportName := output.GetProperty("Export.Name")
+			if exportName.IsNotString() {
+				continue
+			}
+			if _, exists := exports[exportName.AsString()]; exists {
+				p.debug("export '%s' in %s is already defined, ignoring", exportName.AsString(), c.filepath)
+				continue
+			}
+			exports[exportName.AsString()] = output.GetProperty("Value")
+		}
+	}
+	for _, c := range contexts {
+		c.exports = exports
+	}
+}
+
+// stackOutput returns the value of an output from the template of a nested stack
+func (t *FileContext) stackOutput(logicalId string, name string) (*Property, bool) {
+	child, ok := t.stacks[logicalId]
+	if !ok {
+		return nil, false
+	}
+	output, ok := child.Outputs[name]
+	if !ok {
+		return nil, false
+	}
+	value, ok := output.GetProperty("Value").resolveValue()
+	if !ok || value.IsNil() || value.isFunction() {
+		return nil, false
+	}
+	return value, true
+}
+
+func sortedResourceIDs(c *FileContext) []string {
+	ids := make([]string, 0, len(c.Resources))
+	for id := range c.Resources {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/defsec/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parse_nested_stacks(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"main.yaml": `---
+Parameters:
+  Environment:
+    Type: String
+    Default: prod
+Resources:
+  Storage:
+    Type: AWS::CloudFormation::Stack
+    Properties:
+      TemplateURL: ./stacks/storage.yaml
+      Parameters:
+        BucketName: !Join ["-", [!Ref Environment, data]]
+        Versioning: true
+  Remote:
+    Type: AWS::CloudFormation::Stack
+    Properties:
+      TemplateURL: https://s3.amazonaws.com/bucket/remote.yaml
+  Logs:
+    Type: AWS::S3::Bucket
+    Properties:
+      BucketName: !GetAtt Storage.Outputs.BucketName
+`,
+		"stacks/storage.yaml": `---
+Parameters:
+  BucketName:
+    Type: String
+    Default: default-data
+  Versioning:
+    Type: String
+    Default: "false"
+Conditions:
+  Versioned: !Equals [!Ref Versioning, "true"]
+Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+    Properties:
+      BucketName: !Ref BucketName
+      VersioningConfiguration: !If
+        - Versioned
+        - Status: Enabled
+        - !Ref AWS::NoValue
+Outputs:
+  BucketName:
+    Value: !Ref BucketName
+`,
+	})
+
+	contexts, err := New().ParseFS(context.TODO(), fs, ".")
+	require.NoError(t, err)
+	require.Len(t, contexts, 2)
+
+	parent, child := contexts[0], contexts[1]
+	assert.Equal(t, "main.yaml", parent.filepath)
+	assert.Equal(t, "stacks/storage.yaml", child.filepath)
+
+	bucket := child.GetResourceByLogicalID("Bucket")
+	require.NotNil(t, bucket)
+	assert.Equal(t, "prod-data", bucket.GetStringProperty("BucketName").Value())
+	assert.Equal(t, "Enabled", bucket.GetStringProperty("VersioningConfiguration.Status").Value())
+
+	logs := parent.GetResourceByLogicalID("Logs")
+	require.NotNil(t, logs)
+	assert.Equal(t, "prod-data", logs.GetStringProperty("BucketName").Value())
+}
+
+func Test_parse_nested_stack_cycle(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"a.yaml": `---
+Resources:
+  B:
+    Type: AWS::CloudFormation::Stack
+    Properties:
+      TemplateURL: b.yaml
+`,
+		"b.yaml": `---
+Resources:
+  A:
+    Type: AWS::CloudFormation::Stack
+    Properties:
+      TemplateURL: a.yaml
+`,
+	})
+
+	contexts, err := New().ParseFS(context.TODO(), fs, ".")
+	require.NoError(t, err)
+	require.Len(t, contexts, 2)
+	assert.Equal(t, "a.yaml", contexts[0].filepath)
+	assert.Equal(t, "b.yaml", contexts[1].filepath)
+}
+
+func Test_resolve_import_value(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"network.yaml": `---
+Resources:
+  VPC:
+    Type: AWS::EC2::VPC
+    Properties:
+      CidrBlock: 10.0.0.0/16
+Outputs:
+  Cidr:
+    Value: !GetAtt VPC.CidrBlock
+    Export:
+      Name: !Sub "${AWS::StackName}-cidr"
+`,
+		"app.yaml": `---
+Resources:
+  SecurityGroup:
+    Type: AWS::EC2::SecurityGroup
+    Properties:
+      SecurityGroupIngress:
+        - CidrIp: !ImportValue cfsec-test-stack-cidr
+        - CidrIp: !ImportValue missing-export
+`,
+	})
+
+	contexts, err := New().ParseFS(context.TODO(), fs, ".")
+	require.NoError(t, err)
+	require.Len(t, contexts, 2)
+
+	app := contexts[0]
+	require.Equal(t, "app.yaml", app.filepath)
+	group := app.GetResourceByLogicalID("SecurityGroup")
+	require.NotNil(t, group)
+
+	rules := group.GetProperty("SecurityGroupIngress").AsList()
+	require.Len(t, rules, 2)
+	assert.Equal(t, "10.0.0.0/16", rules[0].GetStringProperty("CidrIp").Value())
+	assert.Equal(t, "", rules[1].GetStringProperty("CidrIp").Value())
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/aquasecurity/defsec/internal/types"
 
+	"github.com/aquasecurity/defsec/pkg/ignore"
 	"github.com/aquasecurity/defsec/pkg/scanners/cloudformation/parser"
 
 	"github.com/aquasecurity/defsec/pkg/scan"
@@ -35,7 +36,10 @@ type Scanner struct {
 	dataDirs          []string
 	policyNamespaces  []string
 	parser            *parser.Parser
+	parserOptions     []parser.Option
 	regoScanner       *rego.Scanner
+	suppressions      ignore.Suppressions
+	reportStale       bool
 	sync.Mutex
 }
 
@@ -43,11 +47,13 @@ type Scanner struct {
 func New(options ...Option) *Scanner {
 	s := &Scanner{
 		ignoreCheckErrors: true,
-		parser:            parser.New(),
 	}
 	for _, option := range options {
 		option(s)
 	}
+	if s.parser == nil {
+		s.parser = parser.New(s.parserOptions...)
+	}
 	return s
 }
 
@@ -141,7 +147,10 @@ func (s *Scanner) scanFileContext(ctx context.Context, regoScanner *rego.Scanner
 	if state == nil {
 		return nil, nil
 	}
+	var used ignore.Directives
+	var known []string
 	for _, rule := range rules.GetRegistered() {
+		known = append(known, rule.Rule().IDs()...)
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
@@ -150,8 +159,10 @@ func (s *Scanner) scanFileContext(ctx context.Context, regoScanner *rego.Scanner
 		evalResult := rule.Evaluate(state)
 		if len(evalResult) > 0 {
 			s.debug("Found %d results for %s", len(evalResult), rule.Rule().AVDID)
+			used = append(used, evalResult.ApplyIgnores(cfCtx.Ignores)...)
+			evalResult.ApplySuppressions(s.suppressions)
 			for _, scanResult := range evalResult {
-				if s.isExcluded(scanResult) || isIgnored(scanResult) {
+				if s.isExcluded(scanResult) {
 					scanResult.OverrideStatus(scan.StatusIgnored)
 				}
 
@@ -180,7 +191,13 @@ func (s *Scanner) scanFileContext(ctx context.Context, regoScanner *rego.Scanner
 	if err != nil {
 		return nil, fmt.Errorf("rego scan error: %w", err)
 	}
-	return append(results, regoResults...), nil
+	used = append(used, regoResults.ApplyIgnores(cfCtx.Ignores)...)
+	regoResults.ApplySuppressions(s.suppressions)
+	results = append(results, regoResults...)
+	if s.reportStale {
+		results.AddStaleIgnores(cfCtx.Ignores, used, known...)
+	}
+	return results, nil
 }
 
 func (s *Scanner) isExcluded(result scan.Result) bool {
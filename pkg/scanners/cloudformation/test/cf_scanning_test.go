@@ -6,7 +6,9 @@ import (
 	"os"
 	"testing"
 
+	"github.com/aquasecurity/defsec/pkg/scan"
 	"github.com/aquasecurity/defsec/pkg/scanners/cloudformation"
+	"github.com/aquasecurity/defsec/test/testutil"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -88,3 +90,193 @@ func Test_cloudformation_scanning_with_ignores_has_expected_errors(t *testing.T)
 	assert.Greater(t, len(results.GetFailed()), 0)
 	assert.Greater(t, len(results.GetIgnored()), 0)
 }
+
+func Test_cloudformation_scanning_sam_template(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"template.yaml": `---
+Transform: AWS::Serverless-2016-10-31
+Resources:
+  Handler:
+    Type: AWS::Serverless::Function
+    Properties:
+      Handler: app.handler
+      Runtime: python3.9
+      Policies:
+        - Statement:
+            - Effect: Allow
+              Action: "s3:*"
+              Resource: "*"
+      Events:
+        Get:
+          Type: Api
+          Properties:
+            Path: /
+            Method: get
+`,
+	})
+
+	results, err := cloudformation.New().ScanFS(context.TODO(), fs, ".")
+	require.NoError(t, err)
+
+	failed := make(map[string]int)
+	counts := make(map[string]int)
+	for _, result := range results.GetFailed() {
+		failed[result.Rule().AVDID] = result.Range().GetStartLine()
+		counts[result.Rule().AVDID]++
+	}
+	// the lambda and API gateway checks only see the generated resources, not the SAM resources as well
+	for _, id := range []string{"AVD-AWS-0066", "AVD-AWS-0001", "AVD-AWS-0003"} {
+		assert.Equal(t, 1, counts[id], id)
+	}
+	// lambda, IAM and API gateway checks apply to the resources generated from the function, within its range
+	for _, id := range []string{"AVD-AWS-0066", "AVD-AWS-0057", "AVD-AWS-0001", "AVD-AWS-0003"} {
+		require.Contains(t, failed, id)
+		assert.GreaterOrEqual(t, failed[id], 4, id)
+		assert.LessOrEqual(t, failed[id], 20, id)
+	}
+}
+
+func Test_cloudformation_scanning_kms_key(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"template.yaml": `---
+Resources:
+  Key:
+    Type: AWS::KMS::Key
+    Properties:
+      EnableKeyRotation: false
+      KeyPolicy:
+        Version: "2012-10-17"
+        Statement:
+          - Effect: Allow
+            Principal:
+              AWS: "*"
+            Action: "kms:*"
+            Resource: "*"
+  Alias:
+    Type: AWS::KMS::Alias
+    Properties:
+      AliasName: alias/example
+      TargetKeyId: !Ref Key
+`,
+	})
+
+	results, err := cloudformation.New().ScanFS(context.TODO(), fs, ".")
+	require.NoError(t, err)
+
+	var rotation []int
+	for _, result := range results.GetFailed() {
+		if result.Rule().AVDID == "AVD-AWS-0065" {
+			rotation = append(rotation, result.Range().GetStartLine())
+		}
+	}
+	assert.Equal(t, []int{6}, rotation)
+}
+
+func Test_cloudformation_scanning_with_parameter_file(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"template.yaml": `---
+Parameters:
+  Rotate:
+    Type: String
+    Default: "false"
+Resources:
+  Key:
+    Type: AWS::KMS::Key
+    Properties:
+      EnableKeyRotation: !Ref Rotate
+`,
+		"parameters/prod.json": `[{"ParameterKey": "Rotate", "ParameterValue": "true"}]`,
+	})
+
+	hasRotationFailure := func(results scan.Results) bool {
+		for _, result := range results.GetFailed() {
+			if result.Rule().AVDID == "AVD-AWS-0065" {
+				return true
+			}
+		}
+		return false
+	}
+
+	results, err := cloudformation.New().ScanFS(context.TODO(), fs, ".")
+	require.NoError(t, err)
+	assert.True(t, hasRotationFailure(results))
+
+	results, err = cloudformation.New(cloudformation.OptionWithParameterFiles("parameters/prod.json")).ScanFS(context.TODO(), fs, ".")
+	require.NoError(t, err)
+	assert.False(t, hasRotationFailure(results))
+}
+
+func Test_cloudformation_scanning_inline_ignores(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"template.yaml": `---
+Resources:
+  # defsec:ignore:AVD-AWS-0065 keys for test data are not rotated
+  IgnoredKey:
+    Type: AWS::KMS::Key
+    Properties:
+      EnableKeyRotation: false
+  LegacyIgnoredKey:
+    Type: AWS::KMS::Key
+    Properties:
+      EnableKeyRotation: false # cfsec:ignore:aws-kms-auto-rotate-keys
+  Key:
+    Type: AWS::KMS::Key
+    Properties:
+      EnableKeyRotation: false
+`,
+	})
+
+	results, err := cloudformation.New().ScanFS(context.TODO(), fs, ".")
+	require.NoError(t, err)
+
+	var failed, ignored []int
+	for _, result := range results {
+		if result.Rule().AVDID != "AVD-AWS-0065" {
+			continue
+		}
+		switch result.Status() {
+		case scan.StatusFailed:
+			failed = append(failed, result.Range().GetStartLine())
+		case scan.StatusIgnored:
+			ignored = append(ignored, result.Range().GetStartLine())
+		}
+	}
+	assert.ElementsMatch(t, []int{7, 11}, ignored)
+	assert.Equal(t, []int{15}, failed)
+}
+
+func Test_cloudformation_scanning_stale_ignores(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"template.yaml": `---
+Resources:
+  # defsec:ignore:AVD-AWS-0065
+  IgnoredKey:
+    Type: AWS::KMS::Key
+    Properties:
+      EnableKeyRotation: false
+  # defsec:ignore:AVD-AWS-0065
+  RotatedKey:
+    Type: AWS::KMS::Key
+    Properties:
+      EnableKeyRotation: true
+  # defsec:ignore:aws-kms-no-such-rule
+  Key:
+    Type: AWS::KMS::Key
+    Properties:
+      EnableKeyRotation: false # cfsec:ignore:aws-kms-auto-rotate-keys:exp:2000-01-01
+`,
+	})
+
+	results, err := cloudformation.New(cloudformation.OptionReportStaleIgnores(true)).ScanFS(context.TODO(), fs, ".")
+	require.NoError(t, err)
+
+	stale := make(map[int]string)
+	for _, result := range results.GetStaleIgnores() {
+		stale[result.Range().GetStartLine()] = result.Description()
+	}
+	assert.Equal(t, map[int]string{
+		8:  "Ignore directive for 'AVD-AWS-0065' did not ignore any result.",
+		13: "Ignore directive refers to unknown rule 'aws-kms-no-such-rule'.",
+		17: "Ignore directive for 'aws-kms-auto-rotate-keys' expired on 2000-01-01.",
+	}, stale)
+}
@@ -3,6 +3,7 @@ package dockerfile
 import (
 	"io"
 
+	"github.com/aquasecurity/defsec/pkg/ignore"
 	"github.com/aquasecurity/defsec/pkg/scanners/dockerfile/parser"
 )
 
@@ -51,3 +52,18 @@ func OptionWithParser(parser *parser.Parser) Option {
 		s.parser = parser
 	}
 }
+
+// OptionWithSuppressions - ignore results matching entries of a suppression file, e.g. .defsecignore
+func OptionWithSuppressions(suppressions ignore.Suppressions) Option {
+	return func(s *Scanner) {
+		s.suppressions = suppressions
+	}
+}
+
+// OptionReportStaleIgnores - add a result for each inline ignore directive which is expired, refers to an unknown rule
+// or did not ignore anything
+func OptionReportStaleIgnores(report bool) Option {
+	return func(s *Scanner) {
+		s.reportStale = report
+	}
+}
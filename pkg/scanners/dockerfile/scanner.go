@@ -10,6 +10,7 @@ import (
 
 	"github.com/aquasecurity/defsec/internal/types"
 
+	"github.com/aquasecurity/defsec/pkg/ignore"
 	"github.com/aquasecurity/defsec/pkg/rego"
 	"github.com/aquasecurity/defsec/pkg/scanners/dockerfile/parser"
 
@@ -28,6 +29,8 @@ type Scanner struct {
 	policyNamespaces []string
 	parser           *parser.Parser
 	regoScanner      *rego.Scanner
+	suppressions     ignore.Suppressions
+	reportStale      bool
 	sync.Mutex
 }
 
@@ -120,6 +123,16 @@ func (s *Scanner) scanRego(ctx context.Context, srcFS fs.FS, inputs ...rego.Inpu
 	if err != nil {
 		return nil, err
 	}
+	var paths []string
+	for _, input := range inputs {
+		paths = append(paths, input.Path)
+	}
+	directives := ignore.ParseFS(srcFS, paths...)
+	used := results.ApplyIgnores(directives)
+	results.ApplySuppressions(s.suppressions)
+	if s.reportStale {
+		results.AddStaleIgnores(directives, used)
+	}
 	results.SetSourceAndFilesystem("", srcFS)
 	return results, nil
 }
@@ -73,3 +73,51 @@ deny[res] {
 		Severity:    "CRITICAL",
 		Terraform:   (*scan.EngineMetadata)(nil), CloudFormation: (*scan.EngineMetadata)(nil), CustomChecks: scan.CustomChecks{Terraform: (*scan.TerraformCustomCheck)(nil)}, RegoPackage: "data.appshield.dockerfile.DS006"}, results.GetFailed()[0].Rule())
 }
+
+func Test_InlineIgnores(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"/code/Dockerfile": `FROM ubuntu
+
+# defsec:ignore:DS002 the base image requires root
+USER root
+`,
+		"/code/other/Dockerfile": `FROM ubuntu
+
+# defsec:ignore:DS002:exp:2000-01-01
+USER root
+`,
+		"/rules/rule.rego": `package appshield.dockerfile.DS002
+
+__rego_metadata__ := {
+	"id": "DS002",
+	"avd_id": "AVD-DS-0002",
+	"title": "Image user should not be 'root'",
+	"short_code": "least-privilege-user",
+	"severity": "HIGH",
+}
+
+__rego_input__ := {
+	"combine": false,
+	"selector": [{"type": "dockerfile"}],
+}
+
+deny[res] {
+	res := {
+		"msg": "Specify at least 1 USER command in Dockerfile with non-root user as argument",
+		"startline": 4,
+		"endline": 4,
+	}
+}
+`,
+	})
+
+	results, err := NewScanner(OptionWithPolicyDirs("rules")).ScanFS(context.TODO(), fs, "code")
+	require.NoError(t, err)
+
+	require.Len(t, results.GetIgnored(), 1)
+	assert.Equal(t, "code/Dockerfile", results.GetIgnored()[0].Range().GetFilename())
+
+	// the expired directive no longer applies
+	require.Len(t, results.GetFailed(), 1)
+	assert.Equal(t, "code/other/Dockerfile", results.GetFailed()[0].Range().GetFilename())
+}
@@ -0,0 +1,170 @@
+package helm
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Chart is a Helm chart loaded from a filesystem
+type Chart struct {
+	// Path is the directory containing Chart.yaml
+	Path      string
+	Name      string
+	Metadata  map[string]interface{}
+	Values    map[string]interface{}
+	Templates []Template
+	// Files holds the contents of all other files in the chart, made available to templates as .Files
+	Files map[string][]byte
+	// Dependencies are the unpacked subcharts found in the charts/ directory
+	Dependencies []*Chart
+}
+
+// Template is a file in the templates/ directory of a chart
+type Template struct {
+	// Path is the location of the template in the filesystem
+	Path string
+	// Name is the location of the template relative to the chart directory, e.g. templates/deployment.yaml
+	Name    string
+	Content string
+}
+
+// IsChart returns true if the directory contains a Chart.yaml file
+func IsChart(target fs.FS, dir string) bool {
+	info, err := fs.Stat(target, path.Join(filepath.ToSlash(dir), "Chart.yaml"))
+	return err == nil && !info.IsDir()
+}
+
+// FindCharts returns the directories of all charts in the given directory. Subcharts in the charts/ directory of
+// another chart are rendered as part of their parent, so are not included.
+func FindCharts(target fs.FS, dir string) ([]string, error) {
+	var charts []string
+	if err := fs.WalkDir(target, filepath.ToSlash(dir), func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() || !IsChart(target, p) {
+			return nil
+		}
+		charts = append(charts, p)
+		return fs.SkipDir
+	}); err != nil {
+		return nil, err
+	}
+	sort.Strings(charts)
+	return charts, nil
+}
+
+// LoadChart reads the chart in the given directory, including any unpacked subcharts
+func LoadChart(target fs.FS, dir string) (*Chart, error) {
+	dir = path.Clean(filepath.ToSlash(dir))
+
+	metadata := make(map[string]interface{})
+	if err := readYAML(target, path.Join(dir, "Chart.yaml"), &metadata); err != nil {
+		return nil, err
+	}
+	name, _ := metadata["name"].(string)
+	if name == "" {
+		return nil, fmt.Errorf("chart in '%s' has no name", dir)
+	}
+
+	chart := &Chart{
+		Path:     dir,
+		Name:     name,
+		Metadata: metadata,
+		Values:   make(map[string]interface{}),
+		Files:    make(map[string][]byte),
+	}
+
+	valuesPath := path.Join(dir, "values.yaml")
+	if _, err := fs.Stat(target, valuesPath); err == nil {
+		if err := readYAML(target, valuesPath, &chart.Values); err != nil {
+			return nil, err
+		}
+		if chart.Values == nil {
+			chart.Values = make(map[string]interface{})
+		}
+	}
+
+	if err := fs.WalkDir(target, dir, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel := strings.TrimPrefix(strings.TrimPrefix(p, dir), "/")
+		if entry.IsDir() {
+			if rel == "charts" {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		data, err := fs.ReadFile(target, p)
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(rel, "templates/") {
+			chart.Templates = append(chart.Templates, Template{
+				Path:    p,
+				Name:    rel,
+				Content: string(data),
+			})
+			return nil
+		}
+		chart.Files[rel] = data
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	subcharts, err := fs.ReadDir(target, path.Join(dir, "charts"))
+	if err != nil {
+		// charts/ is optional
+		return chart, nil
+	}
+	for _, entry := range subcharts {
+		subchartDir := path.Join(dir, "charts", entry.Name())
+		if !entry.IsDir() || !IsChart(target, subchartDir) {
+			continue
+		}
+		dependency, err := LoadChart(target, subchartDir)
+		if err != nil {
+			return nil, err
+		}
+		chart.Dependencies = append(chart.Dependencies, dependency)
+	}
+	return chart, nil
+}
+
+// dependencyCondition returns the condition (a path into the parent values) which enables the named dependency
+func (c *Chart) dependencyCondition(name string) string {
+	dependencies, _ := c.Metadata["dependencies"].([]interface{})
+	for _, raw := range dependencies {
+		dependency, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		depName, _ := dependency["name"].(string)
+		alias, _ := dependency["alias"].(string)
+		if depName != name && alias != name {
+			continue
+		}
+		condition, _ := dependency["condition"].(string)
+		return condition
+	}
+	return ""
+}
+
+func readYAML(target fs.FS, p string, output interface{}) error {
+	data, err := fs.ReadFile(target, p)
+	if err != nil {
+		return err
+	}
+	if err := yaml.Unmarshal(data, output); err != nil {
+		return fmt.Errorf("failed to parse '%s': %w", p, err)
+	}
+	return nil
+}
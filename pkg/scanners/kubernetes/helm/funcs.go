@@ -0,0 +1,699 @@
+package helm
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// funcMap returns the commonly used subset of the sprig and Helm template functions. Functions which need a
+// cluster (e.g. lookup) behave as they do for "helm template", and return empty values.
+func funcMap() template.FuncMap {
+	return template.FuncMap{
+		// strings
+		"trim":       strings.TrimSpace,
+		"trimAll":    func(cutset string, s string) string { return strings.Trim(s, cutset) },
+		"trimPrefix": func(prefix string, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix": func(suffix string, s string) string { return strings.TrimSuffix(s, suffix) },
+		"upper":      strings.ToUpper,
+		"lower":      strings.ToLower,
+		"title":      title,
+		"replace":    func(old string, new string, s string) string { return strings.ReplaceAll(s, old, new) },
+		"contains":   func(substr string, s string) bool { return strings.Contains(s, substr) },
+		"hasPrefix":  func(prefix string, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix":  func(suffix string, s string) bool { return strings.HasSuffix(s, suffix) },
+		"trunc":      trunc,
+		"substr":     substr,
+		"repeat":     func(count int, s string) string { return strings.Repeat(s, count) },
+		"nospace":    func(s string) string { return strings.Join(strings.Fields(s), "") },
+		"quote":      quote,
+		"squote":     squote,
+		"cat":        cat,
+		"indent":     indent,
+		"nindent":    func(spaces int, s string) string { return "\n" + indent(spaces, s) },
+		"split":      split,
+		"splitList":  func(sep string, s string) []interface{} { return toList(strings.Split(s, sep)) },
+		"join":       join,
+		"toString":   toString,
+		"b64enc":     func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+		"b64dec":     b64dec,
+		"sha1sum":    func(s string) string { sum := sha1.Sum([]byte(s)); return hex.EncodeToString(sum[:]) },
+		"sha256sum":  func(s string) string { sum := sha256.Sum256([]byte(s)); return hex.EncodeToString(sum[:]) },
+		"regexMatch": func(regex string, s string) (bool, error) { return regexp.MatchString(regex, s) },
+		"regexFind":  regexFind,
+		"regexReplaceAll": func(regex string, s string, replacement string) (string, error) {
+			re, err := regexp.Compile(regex)
+			if err != nil {
+				return "", err
+			}
+			return re.ReplaceAllString(s, replacement), nil
+		},
+
+		// defaults and flow control
+		"default":  defaultFunc,
+		"empty":    empty,
+		"coalesce": coalesce,
+		"ternary": func(whenTrue interface{}, whenFalse interface{}, condition bool) interface{} {
+			if condition {
+				return whenTrue
+			}
+			return whenFalse
+		},
+		"required": required,
+		"fail":     func(msg string) (string, error) { return "", errors.New(msg) },
+		"lookup": func(string, string, string, string) map[string]interface{} {
+			return map[string]interface{}{}
+		},
+
+		// encoding
+		"toYaml":        toYAML,
+		"fromYaml":      fromYAML,
+		"fromYamlArray": fromYAMLArray,
+		"toJson":        toJSON,
+		"toPrettyJson":  toPrettyJSON,
+		"fromJson":      fromJSON,
+
+		// lists
+		"list":      func(items ...interface{}) []interface{} { return items },
+		"first":     first,
+		"last":      last,
+		"rest":      rest,
+		"initial":   initial,
+		"append":    push,
+		"push":      push,
+		"prepend":   prepend,
+		"concat":    concat,
+		"uniq":      uniq,
+		"has":       has,
+		"without":   without,
+		"compact":   compact,
+		"sortAlpha": sortAlpha,
+		"reverse":   reverse,
+		"until":     until,
+
+		// dictionaries
+		"dict": dict,
+		"set": func(d map[string]interface{}, key string, value interface{}) map[string]interface{} {
+			d[key] = value
+			return d
+		},
+		"unset":          func(d map[string]interface{}, key string) map[string]interface{} { delete(d, key); return d },
+		"hasKey":         func(d map[string]interface{}, key string) bool { _, ok := d[key]; return ok },
+		"get":            func(d map[string]interface{}, key string) interface{} { return d[key] },
+		"keys":           keys,
+		"values":         values,
+		"pick":           pick,
+		"omit":           omit,
+		"merge":          merge,
+		"mergeOverwrite": mergeOverwrite,
+		"deepCopy":       copyValue,
+
+		// types and conversion
+		"int":       func(v interface{}) int { return int(toInt64(v)) },
+		"int64":     toInt64,
+		"float64":   toFloat64,
+		"atoi":      func(s string) int { i, _ := strconv.Atoi(s); return i },
+		"toStrings": toStrings,
+		"kindOf":    kindOf,
+		"kindIs":    func(kind string, v interface{}) bool { return kindOf(v) == kind },
+		"typeOf":    func(v interface{}) string { return fmt.Sprintf("%T", v) },
+		"typeIs":    func(typ string, v interface{}) bool { return fmt.Sprintf("%T", v) == typ },
+
+		// maths
+		"add":  func(values ...interface{}) int64 { return reduce(values, func(a, b int64) int64 { return a + b }) },
+		"add1": func(v interface{}) int64 { return toInt64(v) + 1 },
+		"sub":  func(a interface{}, b interface{}) int64 { return toInt64(a) - toInt64(b) },
+		"mul":  func(values ...interface{}) int64 { return reduce(values, func(a, b int64) int64 { return a * b }) },
+		"div":  div,
+		"mod":  mod,
+		"max":  func(values ...interface{}) int64 { return reduce(values, maxInt64) },
+		"min":  func(values ...interface{}) int64 { return reduce(values, minInt64) },
+
+		// versions, dates and random values
+		"semverCompare": semverCompare,
+		"semver":        semver.NewVersion,
+		"now":           time.Now,
+		"date":          date,
+		"uuidv4":        func() string { return uuid.NewString() },
+		"randAlphaNum":  randAlphaNum,
+	}
+}
+
+func title(s string) string {
+	words := strings.Fields(s)
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+func trunc(length int, s string) string {
+	if length < 0 && len(s)+length > 0 {
+		return s[len(s)+length:]
+	}
+	if length >= 0 && len(s) > length {
+		return s[:length]
+	}
+	return s
+}
+
+func substr(start int, end int, s string) string {
+	if start < 0 {
+		return s[:end]
+	}
+	if end < 0 || end > len(s) {
+		return s[start:]
+	}
+	return s[start:end]
+}
+
+func quote(values ...interface{}) string {
+	var quoted []string
+	for _, value := range values {
+		if value != nil {
+			quoted = append(quoted, fmt.Sprintf("%q", toString(value)))
+		}
+	}
+	return strings.Join(quoted, " ")
+}
+
+func squote(values ...interface{}) string {
+	var quoted []string
+	for _, value := range values {
+		if value != nil {
+			quoted = append(quoted, "'"+toString(value)+"'")
+		}
+	}
+	return strings.Join(quoted, " ")
+}
+
+func cat(values ...interface{}) string {
+	var parts []string
+	for _, value := range values {
+		if value != nil {
+			parts = append(parts, toString(value))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func indent(spaces int, s string) string {
+	pad := strings.Repeat(" ", spaces)
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+}
+
+func split(sep string, s string) map[string]interface{} {
+	output := make(map[string]interface{})
+	for i, part := range strings.Split(s, sep) {
+		output["_"+strconv.Itoa(i)] = part
+	}
+	return output
+}
+
+func join(sep string, list interface{}) string {
+	return strings.Join(toStrings(list), sep)
+}
+
+func b64dec(s string) string {
+	decoded, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return err.Error()
+	}
+	return string(decoded)
+}
+
+func regexFind(regex string, s string) (string, error) {
+	re, err := regexp.Compile(regex)
+	if err != nil {
+		return "", err
+	}
+	return re.FindString(s), nil
+}
+
+func toString(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case []byte:
+		return string(typed)
+	case error:
+		return typed.Error()
+	case fmt.Stringer:
+		return typed.String()
+	default:
+		return fmt.Sprintf("%v", typed)
+	}
+}
+
+func toStrings(value interface{}) []string {
+	var output []string
+	for _, item := range toList(value) {
+		output = append(output, toString(item))
+	}
+	return output
+}
+
+// toList converts any slice or array into a list of values
+func toList(value interface{}) []interface{} {
+	if value == nil {
+		return nil
+	}
+	if list, ok := value.([]interface{}); ok {
+		return list
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		output := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			output[i] = v.Index(i).Interface()
+		}
+		return output
+	default:
+		return []interface{}{value}
+	}
+}
+
+// empty follows the sprig definition, where zero values of any type are empty
+func empty(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+func defaultFunc(defaultValue interface{}, given ...interface{}) interface{} {
+	if len(given) == 0 || empty(given[0]) {
+		return defaultValue
+	}
+	return given[0]
+}
+
+func coalesce(values ...interface{}) interface{} {
+	for _, value := range values {
+		if !empty(value) {
+			return value
+		}
+	}
+	return nil
+}
+
+func required(msg string, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, errors.New(msg)
+	}
+	if str, ok := value.(string); ok && str == "" {
+		return nil, errors.New(msg)
+	}
+	return value, nil
+}
+
+func toYAML(value interface{}) string {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(value); err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func fromYAML(s string) map[string]interface{} {
+	output := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(s), &output); err != nil {
+		output["Error"] = err.Error()
+	}
+	return output
+}
+
+func fromYAMLArray(s string) []interface{} {
+	var output []interface{}
+	if err := yaml.Unmarshal([]byte(s), &output); err != nil {
+		return []interface{}{err.Error()}
+	}
+	return output
+}
+
+func toJSON(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func toPrettyJSON(value interface{}) string {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func fromJSON(s string) map[string]interface{} {
+	output := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(s), &output); err != nil {
+		output["Error"] = err.Error()
+	}
+	return output
+}
+
+func first(list interface{}) interface{} {
+	items := toList(list)
+	if len(items) == 0 {
+		return nil
+	}
+	return items[0]
+}
+
+func last(list interface{}) interface{} {
+	items := toList(list)
+	if len(items) == 0 {
+		return nil
+	}
+	return items[len(items)-1]
+}
+
+func rest(list interface{}) []interface{} {
+	items := toList(list)
+	if len(items) == 0 {
+		return nil
+	}
+	return items[1:]
+}
+
+func initial(list interface{}) []interface{} {
+	items := toList(list)
+	if len(items) == 0 {
+		return nil
+	}
+	return items[:len(items)-1]
+}
+
+func push(list interface{}, value interface{}) []interface{} {
+	items := toList(list)
+	output := make([]interface{}, 0, len(items)+1)
+	return append(append(output, items...), value)
+}
+
+func prepend(list interface{}, value interface{}) []interface{} {
+	return append([]interface{}{value}, toList(list)...)
+}
+
+func concat(lists ...interface{}) []interface{} {
+	var output []interface{}
+	for _, list := range lists {
+		output = append(output, toList(list)...)
+	}
+	return output
+}
+
+func uniq(list interface{}) []interface{} {
+	var output []interface{}
+	for _, item := range toList(list) {
+		if !has(item, output) {
+			output = append(output, item)
+		}
+	}
+	return output
+}
+
+func has(needle interface{}, list interface{}) bool {
+	for _, item := range toList(list) {
+		if reflect.DeepEqual(item, needle) {
+			return true
+		}
+	}
+	return false
+}
+
+func without(list interface{}, omitted ...interface{}) []interface{} {
+	var output []interface{}
+	for _, item := range toList(list) {
+		if !has(item, omitted) {
+			output = append(output, item)
+		}
+	}
+	return output
+}
+
+func compact(list interface{}) []interface{} {
+	var output []interface{}
+	for _, item := range toList(list) {
+		if !empty(item) {
+			output = append(output, item)
+		}
+	}
+	return output
+}
+
+func sortAlpha(list interface{}) []string {
+	output := toStrings(list)
+	sort.Strings(output)
+	return output
+}
+
+func reverse(list interface{}) []interface{} {
+	items := toList(list)
+	output := make([]interface{}, len(items))
+	for i, item := range items {
+		output[len(items)-1-i] = item
+	}
+	return output
+}
+
+func until(count int) []int {
+	output := make([]int, 0, count)
+	for i := 0; i < count; i++ {
+		output = append(output, i)
+	}
+	return output
+}
+
+func dict(pairs ...interface{}) map[string]interface{} {
+	output := make(map[string]interface{})
+	for i := 0; i+1 < len(pairs); i += 2 {
+		output[toString(pairs[i])] = pairs[i+1]
+	}
+	return output
+}
+
+func keys(dicts ...map[string]interface{}) []string {
+	var output []string
+	for _, d := range dicts {
+		for key := range d {
+			output = append(output, key)
+		}
+	}
+	sort.Strings(output)
+	return output
+}
+
+func values(d map[string]interface{}) []interface{} {
+	output := make([]interface{}, 0, len(d))
+	for _, key := range keys(d) {
+		output = append(output, d[key])
+	}
+	return output
+}
+
+func pick(d map[string]interface{}, names ...string) map[string]interface{} {
+	output := make(map[string]interface{})
+	for _, name := range names {
+		if value, ok := d[name]; ok {
+			output[name] = value
+		}
+	}
+	return output
+}
+
+func omit(d map[string]interface{}, names ...string) map[string]interface{} {
+	output := make(map[string]interface{})
+	for key, value := range d {
+		if !has(key, toList(names)) {
+			output[key] = value
+		}
+	}
+	return output
+}
+
+// merge merges the sources into the destination, without overwriting existing values
+func merge(dst map[string]interface{}, sources ...map[string]interface{}) map[string]interface{} {
+	for _, src := range sources {
+		for key, value := range src {
+			srcMap, srcIsMap := value.(map[string]interface{})
+			dstMap, dstIsMap := dst[key].(map[string]interface{})
+			switch {
+			case srcIsMap && dstIsMap:
+				merge(dstMap, srcMap)
+			case dst[key] == nil:
+				dst[key] = copyValue(value)
+			}
+		}
+	}
+	return dst
+}
+
+func mergeOverwrite(dst map[string]interface{}, sources ...map[string]interface{}) map[string]interface{} {
+	for _, src := range sources {
+		for key, value := range src {
+			srcMap, srcIsMap := value.(map[string]interface{})
+			dstMap, dstIsMap := dst[key].(map[string]interface{})
+			if srcIsMap && dstIsMap {
+				mergeOverwrite(dstMap, srcMap)
+				continue
+			}
+			dst[key] = copyValue(value)
+		}
+	}
+	return dst
+}
+
+func toInt64(value interface{}) int64 {
+	switch typed := value.(type) {
+	case int:
+		return int64(typed)
+	case int64:
+		return typed
+	case int32:
+		return int64(typed)
+	case uint64:
+		return int64(typed)
+	case float64:
+		return int64(typed)
+	case bool:
+		if typed {
+			return 1
+		}
+		return 0
+	case string:
+		i, _ := strconv.ParseInt(typed, 10, 64)
+		return i
+	default:
+		return 0
+	}
+}
+
+func toFloat64(value interface{}) float64 {
+	switch typed := value.(type) {
+	case float64:
+		return typed
+	case string:
+		f, _ := strconv.ParseFloat(typed, 64)
+		return f
+	default:
+		return float64(toInt64(value))
+	}
+}
+
+func kindOf(value interface{}) string {
+	if value == nil {
+		return "invalid"
+	}
+	return reflect.ValueOf(value).Kind().String()
+}
+
+func reduce(values []interface{}, fn func(a, b int64) int64) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+	output := toInt64(values[0])
+	for _, value := range values[1:] {
+		output = fn(output, toInt64(value))
+	}
+	return output
+}
+
+func maxInt64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func minInt64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func div(a interface{}, b interface{}) (int64, error) {
+	divisor := toInt64(b)
+	if divisor == 0 {
+		return 0, errors.New("division by zero")
+	}
+	return toInt64(a) / divisor, nil
+}
+
+func mod(a interface{}, b interface{}) (int64, error) {
+	divisor := toInt64(b)
+	if divisor == 0 {
+		return 0, errors.New("division by zero")
+	}
+	return toInt64(a) % divisor, nil
+}
+
+func semverCompare(constraint string, version string) (bool, error) {
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return false, err
+	}
+	return c.Check(v), nil
+}
+
+func date(format string, value interface{}) string {
+	t, ok := value.(time.Time)
+	if !ok {
+		t = time.Now()
+	}
+	return t.Format(format)
+}
+
+func randAlphaNum(count int) string {
+	const chars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	output := make([]byte, count)
+	for i := range output {
+		output[i] = chars[rand.Intn(len(chars))] // nolint:gosec
+	}
+	return string(output)
+}
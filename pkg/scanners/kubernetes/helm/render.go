@@ -0,0 +1,397 @@
+package helm
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxIncludeDepth prevents templates which include themselves from rendering forever
+const maxIncludeDepth = 1000
+
+// Manifest is the output of rendering a single template
+type Manifest struct {
+	// Path is the location of the template which produced the manifest
+	Path    string
+	Content string
+}
+
+// Renderer renders Helm charts offline, in the same way as "helm template"
+type Renderer struct {
+	debugWriter io.Writer
+	valuesFiles []string
+	setValues   []string
+	releaseName string
+	namespace   string
+	kubeVersion string
+}
+
+type Option func(r *Renderer)
+
+func OptionWithDebugWriter(w io.Writer) Option {
+	return func(r *Renderer) {
+		r.debugWriter = w
+	}
+}
+
+// OptionWithValuesFiles - values files to merge over the values.yaml of each chart, in order. As with "helm -f", the
+// files are read from the OS filesystem rather than the scanned filesystem.
+func OptionWithValuesFiles(paths ...string) Option {
+	return func(r *Renderer) {
+		r.valuesFiles = append(r.valuesFiles, paths...)
+	}
+}
+
+// OptionWithValues - --set style overrides, e.g. "image.tag=latest", which take precedence over values files
+func OptionWithValues(values ...string) Option {
+	return func(r *Renderer) {
+		r.setValues = append(r.setValues, values...)
+	}
+}
+
+func OptionWithReleaseName(name string) Option {
+	return func(r *Renderer) {
+		r.releaseName = name
+	}
+}
+
+func OptionWithNamespace(namespace string) Option {
+	return func(r *Renderer) {
+		r.namespace = namespace
+	}
+}
+
+// OptionWithKubeVersion - the Kubernetes version reported by .Capabilities.KubeVersion, e.g. v1.24.0
+func OptionWithKubeVersion(version string) Option {
+	return func(r *Renderer) {
+		r.kubeVersion = version
+	}
+}
+
+// New creates a new Renderer
+func New(options ...Option) *Renderer {
+	r := &Renderer{
+		releaseName: "release-name",
+		namespace:   "default",
+		kubeVersion: "v1.24.0",
+	}
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
+
+func (r *Renderer) debug(format string, args ...interface{}) {
+	if r.debugWriter == nil {
+		return
+	}
+	prefix := "[debug:parse:helm] "
+	_, _ = r.debugWriter.Write([]byte(fmt.Sprintf(prefix+format+"\n", args...)))
+}
+
+// values returns the values for the top level chart: values.yaml, then the values files, then any overrides
+func (r *Renderer) values(chart *Chart) (map[string]interface{}, error) {
+	values := mergeValues(nil, chart.Values)
+	for _, valuesFile := range r.valuesFiles {
+		data, err := os.ReadFile(valuesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read values file: %w", err)
+		}
+		fileValues := make(map[string]interface{})
+		if err := yaml.Unmarshal(data, &fileValues); err != nil {
+			return nil, fmt.Errorf("failed to parse values file '%s': %w", valuesFile, err)
+		}
+		values = mergeValues(values, fileValues)
+	}
+	for _, setValue := range r.setValues {
+		if err := parseSetValue(values, setValue); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// renderContext is a chart along with the values and metadata it is rendered with
+type renderContext struct {
+	chart  *Chart
+	values map[string]interface{}
+}
+
+// Render renders the templates of the chart and its subcharts. Templates which fail to render are skipped.
+func (r *Renderer) Render(target fs.FS, chart *Chart) ([]Manifest, error) {
+	values, err := r.values(chart)
+	if err != nil {
+		return nil, err
+	}
+
+	var contexts []renderContext
+	r.collect(chart, values, &contexts)
+
+	root := template.New("helm").Option("missingkey=zero")
+	depth := 0
+	funcs := funcMap()
+	funcs["include"] = func(name string, data interface{}) (string, error) {
+		if depth >= maxIncludeDepth {
+			return "", fmt.Errorf("include of '%s' exceeded the maximum depth", name)
+		}
+		depth++
+		defer func() { depth-- }()
+		var buf bytes.Buffer
+		if err := root.ExecuteTemplate(&buf, name, data); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+	funcs["tpl"] = func(text string, data interface{}) (string, error) {
+		clone, err := root.Clone()
+		if err != nil {
+			return "", err
+		}
+		t, err := clone.New("tpl").Parse(text)
+		if err != nil {
+			return "", err
+		}
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, data); err != nil {
+			return "", err
+		}
+		return strings.ReplaceAll(buf.String(), "<no value>", ""), nil
+	}
+	root.Funcs(funcs)
+
+	// all templates share a namespace, so named templates from any chart can be included by any other
+	for _, ctx := range contexts {
+		for _, tpl := range ctx.chart.Templates {
+			if _, err := root.New(tpl.Path).Parse(tpl.Content); err != nil {
+				r.debug("Failed to parse template '%s': %s", tpl.Path, err)
+			}
+		}
+	}
+
+	var manifests []Manifest
+	for _, ctx := range contexts {
+		for _, tpl := range ctx.chart.Templates {
+			if !isManifest(tpl.Name) || root.Lookup(tpl.Path) == nil {
+				continue
+			}
+			var buf bytes.Buffer
+			if err := root.ExecuteTemplate(&buf, tpl.Path, r.templateData(ctx, tpl)); err != nil {
+				r.debug("Failed to render template '%s': %s", tpl.Path, err)
+				continue
+			}
+			content := strings.ReplaceAll(buf.String(), "<no value>", "")
+			if strings.TrimSpace(content) == "" {
+				continue
+			}
+			manifests = append(manifests, Manifest{
+				Path:    tpl.Path,
+				Content: content,
+			})
+		}
+	}
+	return manifests, nil
+}
+
+// collect adds the chart and its enabled subcharts to the list of charts to render. Subcharts are given the
+// values nested under their name in the parent values, along with the global values.
+func (r *Renderer) collect(chart *Chart, values map[string]interface{}, contexts *[]renderContext) {
+	*contexts = append(*contexts, renderContext{chart: chart, values: values})
+	globals, _ := values["global"].(map[string]interface{})
+	for _, dependency := range chart.Dependencies {
+		if condition := chart.dependencyCondition(dependency.Name); condition != "" {
+			if enabled, ok := lookupPath(values, condition).(bool); ok && !enabled {
+				r.debug("Skipping subchart '%s' as '%s' is false", dependency.Name, condition)
+				continue
+			}
+		}
+		subValues := mergeValues(nil, dependency.Values)
+		if overrides, ok := values[dependency.Name].(map[string]interface{}); ok {
+			subValues = mergeValues(subValues, overrides)
+		}
+		if globals != nil {
+			subGlobals, _ := subValues["global"].(map[string]interface{})
+			subValues["global"] = mergeValues(subGlobals, globals)
+		}
+		r.collect(dependency, subValues, contexts)
+	}
+}
+
+func (r *Renderer) templateData(ctx renderContext, tpl Template) map[string]interface{} {
+	return map[string]interface{}{
+		"Values": ctx.values,
+		"Release": map[string]interface{}{
+			"Name":      r.releaseName,
+			"Namespace": r.namespace,
+			"Service":   "Helm",
+			"IsInstall": true,
+			"IsUpgrade": false,
+			"Revision":  1,
+		},
+		"Chart":        chartObject(ctx.chart.Metadata),
+		"Capabilities": newCapabilities(r.kubeVersion),
+		"Template": map[string]interface{}{
+			"Name":     ctx.chart.Name + "/" + tpl.Name,
+			"BasePath": ctx.chart.Name + "/templates",
+		},
+		"Files": files(ctx.chart.Files),
+	}
+}
+
+// isManifest returns true for templates which produce Kubernetes objects, rather than partials or notes
+func isManifest(name string) bool {
+	base := path.Base(name)
+	if strings.HasPrefix(base, "_") || strings.EqualFold(base, "NOTES.txt") {
+		return false
+	}
+	switch strings.ToLower(path.Ext(base)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+// chartObject exposes Chart.yaml to templates using the field names Helm uses, e.g. .Chart.AppVersion
+func chartObject(metadata map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{}, len(metadata))
+	for key, value := range metadata {
+		if key == "" {
+			continue
+		}
+		if key == "apiVersion" {
+			output["APIVersion"] = value
+			continue
+		}
+		runes := []rune(key)
+		runes[0] = unicode.ToUpper(runes[0])
+		output[string(runes)] = value
+	}
+	return output
+}
+
+func lookupPath(values map[string]interface{}, dotted string) interface{} {
+	var current interface{} = values
+	for _, key := range strings.Split(dotted, ".") {
+		obj, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = obj[key]
+	}
+	return current
+}
+
+// files is the .Files object available to templates
+type files map[string][]byte
+
+func (f files) Get(name string) string {
+	return string(f[name])
+}
+
+func (f files) GetBytes(name string) []byte {
+	return f[name]
+}
+
+func (f files) Lines(name string) []string {
+	content := strings.TrimSuffix(string(f[name]), "\n")
+	if content == "" {
+		return nil
+	}
+	return strings.Split(content, "\n")
+}
+
+func (f files) Glob(pattern string) files {
+	output := make(files)
+	for name, data := range f {
+		if matched, _ := path.Match(pattern, name); matched {
+			output[name] = data
+		}
+	}
+	return output
+}
+
+func (f files) AsConfig() string {
+	data := make(map[string]interface{}, len(f))
+	for name, content := range f {
+		data[path.Base(name)] = string(content)
+	}
+	return toYAML(data)
+}
+
+func (f files) AsSecrets() string {
+	data := make(map[string]interface{}, len(f))
+	for name, content := range f {
+		data[path.Base(name)] = base64.StdEncoding.EncodeToString(content)
+	}
+	return toYAML(data)
+}
+
+type capabilities struct {
+	KubeVersion kubeVersion
+	APIVersions apiVersions
+}
+
+type kubeVersion struct {
+	Version    string
+	Major      string
+	Minor      string
+	GitVersion string
+}
+
+func (v kubeVersion) String() string {
+	return v.Version
+}
+
+// apiVersions are the API versions available in the (fake) cluster the chart is rendered for
+type apiVersions []string
+
+func (a apiVersions) Has(version string) bool {
+	for _, available := range a {
+		if available == version {
+			return true
+		}
+	}
+	return false
+}
+
+var defaultAPIVersions = apiVersions{
+	"v1",
+	"apps/v1",
+	"batch/v1",
+	"autoscaling/v1",
+	"autoscaling/v2",
+	"policy/v1",
+	"networking.k8s.io/v1",
+	"rbac.authorization.k8s.io/v1",
+	"storage.k8s.io/v1",
+	"apiextensions.k8s.io/v1",
+	"admissionregistration.k8s.io/v1",
+	"coordination.k8s.io/v1",
+	"scheduling.k8s.io/v1",
+}
+
+func newCapabilities(version string) capabilities {
+	trimmed := strings.TrimPrefix(version, "v")
+	parts := strings.SplitN(trimmed, ".", 3)
+	for len(parts) < 2 {
+		parts = append(parts, "0")
+	}
+	return capabilities{
+		KubeVersion: kubeVersion{
+			Version:    version,
+			Major:      parts[0],
+			Minor:      parts[1],
+			GitVersion: version,
+		},
+		APIVersions: defaultAPIVersions,
+	}
+}
@@ -0,0 +1,159 @@
+package helm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquasecurity/defsec/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var testChart = map[string]string{
+	"charts/web/Chart.yaml": `apiVersion: v2
+name: web
+version: 1.2.3
+appVersion: "2.0"
+dependencies:
+  - name: cache
+    condition: cache.enabled
+`,
+	"charts/web/values.yaml": `image:
+  repository: nginx
+  tag: ""
+replicas: 2
+securityContext:
+  readOnlyRootFilesystem: false
+cache:
+  enabled: false
+global:
+  team: platform
+`,
+	"charts/web/templates/_helpers.tpl": `{{- define "web.fullname" -}}
+{{ .Release.Name }}-{{ .Chart.Name | trunc 63 }}
+{{- end -}}
+{{- define "web.labels" -}}
+app.kubernetes.io/name: {{ .Chart.Name }}
+app.kubernetes.io/version: {{ .Chart.AppVersion | quote }}
+team: {{ .Values.global.team }}
+{{- end -}}
+`,
+	"charts/web/templates/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ include "web.fullname" . }}
+  labels:
+    {{- include "web.labels" . | nindent 4 }}
+spec:
+  replicas: {{ .Values.replicas }}
+  template:
+    spec:
+      containers:
+        - name: web
+          image: "{{ .Values.image.repository }}:{{ .Values.image.tag | default .Chart.AppVersion }}"
+          securityContext:
+            {{- toYaml .Values.securityContext | nindent 12 }}
+`,
+	"charts/web/templates/ingress.yaml": `{{- if .Values.ingress }}
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: web
+{{- end }}
+`,
+	"charts/web/templates/NOTES.txt": `Thanks for installing {{ .Chart.Name }}`,
+	"charts/web/charts/cache/Chart.yaml": `apiVersion: v2
+name: cache
+version: 0.1.0
+`,
+	"charts/web/charts/cache/templates/statefulset.yaml": `apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: cache
+  labels:
+    team: {{ .Values.global.team }}
+`,
+}
+
+func renderTestChart(t *testing.T, options ...Option) map[string]map[string]interface{} {
+	fs := testutil.CreateFS(t, testChart)
+	charts, err := FindCharts(fs, ".")
+	require.NoError(t, err)
+	require.Equal(t, []string{"charts/web"}, charts)
+
+	chart, err := LoadChart(fs, charts[0])
+	require.NoError(t, err)
+
+	manifests, err := New(options...).Render(fs, chart)
+	require.NoError(t, err)
+
+	objects := make(map[string]map[string]interface{})
+	for _, manifest := range manifests {
+		var object map[string]interface{}
+		require.NoError(t, yaml.Unmarshal([]byte(manifest.Content), &object), manifest.Content)
+		objects[manifest.Path] = object
+	}
+	return objects
+}
+
+func Test_Render(t *testing.T) {
+	objects := renderTestChart(t)
+	require.Len(t, objects, 1)
+
+	deployment := objects["charts/web/templates/deployment.yaml"]
+	require.NotNil(t, deployment)
+	assert.Equal(t, "release-name-web", lookupPath(deployment, "metadata.name"))
+	labels := lookupPath(deployment, "metadata.labels").(map[string]interface{})
+	assert.Equal(t, "2.0", labels["app.kubernetes.io/version"])
+	assert.Equal(t, "platform", labels["team"])
+	assert.Equal(t, 2, lookupPath(deployment, "spec.replicas"))
+
+	container := lookupPath(deployment, "spec.template.spec.containers").([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "nginx:2.0", container["image"])
+	assert.Equal(t, false, lookupPath(container, "securityContext.readOnlyRootFilesystem"))
+}
+
+func Test_RenderWithOverrides(t *testing.T) {
+	// values files are read from outside the scanned filesystem
+	valuesFile := filepath.Join(t.TempDir(), "prod.yaml")
+	require.NoError(t, os.WriteFile(valuesFile, []byte("replicas: 5\n"), 0o600))
+
+	objects := renderTestChart(t,
+		OptionWithReleaseName("prod"),
+		OptionWithValuesFiles(valuesFile),
+		OptionWithValues("image.tag=1.23,securityContext.readOnlyRootFilesystem=true", "cache.enabled=true", "ingress.enabled=true"),
+	)
+	require.Len(t, objects, 3)
+
+	deployment := objects["charts/web/templates/deployment.yaml"]
+	assert.Equal(t, "prod-web", lookupPath(deployment, "metadata.name"))
+	assert.Equal(t, 5, lookupPath(deployment, "spec.replicas"))
+	container := lookupPath(deployment, "spec.template.spec.containers").([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "nginx:1.23", container["image"])
+	assert.Equal(t, true, lookupPath(container, "securityContext.readOnlyRootFilesystem"))
+
+	// the subchart is enabled by its condition, and receives the global values
+	statefulSet := objects["charts/web/charts/cache/templates/statefulset.yaml"]
+	require.NotNil(t, statefulSet)
+	assert.Equal(t, "platform", lookupPath(statefulSet, "metadata.labels.team"))
+
+	assert.NotNil(t, objects["charts/web/templates/ingress.yaml"])
+}
+
+func Test_TemplateErrorsAreSkipped(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"Chart.yaml":             "name: broken\nversion: 0.1.0\n",
+		"templates/bad.yaml":     `{{ required "image is required" .Values.image }}`,
+		"templates/unknown.yaml": `{{ unknownFunction }}`,
+		"templates/good.yaml":    "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: good\n",
+	})
+	chart, err := LoadChart(fs, ".")
+	require.NoError(t, err)
+
+	manifests, err := New().Render(fs, chart)
+	require.NoError(t, err)
+	require.Len(t, manifests, 1)
+	assert.Equal(t, "templates/good.yaml", manifests[0].Path)
+}
@@ -0,0 +1,228 @@
+package helm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// mergeValues merges src into dst, with src taking precedence. As with Helm, a null value in src removes the key.
+func mergeValues(dst map[string]interface{}, src map[string]interface{}) map[string]interface{} {
+	if dst == nil {
+		dst = make(map[string]interface{})
+	}
+	for key, value := range src {
+		if value == nil {
+			delete(dst, key)
+			continue
+		}
+		srcMap, srcIsMap := value.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			dst[key] = mergeValues(dstMap, srcMap)
+			continue
+		}
+		dst[key] = copyValue(value)
+	}
+	return dst
+}
+
+// copyValue returns a deep copy of maps and lists, so that values can't be modified by templates or other charts
+func copyValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		output := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			output[key] = copyValue(item)
+		}
+		return output
+	case []interface{}:
+		output := make([]interface{}, len(typed))
+		for i, item := range typed {
+			output[i] = copyValue(item)
+		}
+		return output
+	default:
+		return value
+	}
+}
+
+// parseSetValue applies a --set style override, e.g. "image.tag=latest,ports[0]=80,hosts={a,b}" to the values
+func parseSetValue(values map[string]interface{}, input string) error {
+	for _, assignment := range splitUnescaped(input, ',', true) {
+		if strings.TrimSpace(assignment) == "" {
+			continue
+		}
+		key, value, ok := cutUnescaped(assignment, '=')
+		if !ok {
+			return fmt.Errorf("invalid value '%s', expected key=value", assignment)
+		}
+		var parsed interface{}
+		if strings.HasPrefix(value, "{") && strings.HasSuffix(value, "}") {
+			var list []interface{}
+			for _, item := range splitUnescaped(value[1:len(value)-1], ',', false) {
+				list = append(list, typedValue(item))
+			}
+			parsed = list
+		} else {
+			parsed = typedValue(value)
+		}
+		if err := setPath(values, parseKey(key), parsed); err != nil {
+			return fmt.Errorf("invalid key '%s': %w", key, err)
+		}
+	}
+	return nil
+}
+
+// splitUnescaped splits the input on the separator, ignoring escaped separators and, optionally, separators
+// inside braces
+func splitUnescaped(input string, separator rune, respectBraces bool) []string {
+	var parts []string
+	var sb strings.Builder
+	depth := 0
+	escaped := false
+	for _, r := range input {
+		switch {
+		case escaped:
+			if r != separator {
+				sb.WriteRune('\\')
+			}
+			sb.WriteRune(r)
+			escaped = false
+			continue
+		case r == '\\':
+			escaped = true
+			continue
+		case respectBraces && r == '{':
+			depth++
+		case respectBraces && r == '}':
+			depth--
+		case r == separator && depth == 0:
+			parts = append(parts, sb.String())
+			sb.Reset()
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return append(parts, sb.String())
+}
+
+func cutUnescaped(input string, separator byte) (string, string, bool) {
+	for i := 0; i < len(input); i++ {
+		if input[i] == '\\' {
+			i++
+			continue
+		}
+		if input[i] == separator {
+			return input[:i], input[i+1:], true
+		}
+	}
+	return input, "", false
+}
+
+type pathSegment struct {
+	key     string
+	indexes []int
+}
+
+// parseKey splits a key such as "a.b[0].c" into segments. Dots can be escaped, e.g. "annotations.foo\.io/bar".
+func parseKey(key string) []pathSegment {
+	var segments []pathSegment
+	for _, part := range splitUnescaped(key, '.', false) {
+		segment := pathSegment{key: part}
+		for strings.HasSuffix(segment.key, "]") {
+			open := strings.LastIndex(segment.key, "[")
+			if open < 0 {
+				break
+			}
+			index, err := strconv.Atoi(segment.key[open+1 : len(segment.key)-1])
+			if err != nil {
+				break
+			}
+			segment.indexes = append([]int{index}, segment.indexes...)
+			segment.key = segment.key[:open]
+		}
+		segments = append(segments, segment)
+	}
+	return segments
+}
+
+func setPath(values map[string]interface{}, segments []pathSegment, value interface{}) error {
+	if len(segments) == 0 {
+		return fmt.Errorf("empty key")
+	}
+	segment := segments[0]
+	if segment.key == "" {
+		return fmt.Errorf("empty key segment")
+	}
+	if len(segment.indexes) == 0 {
+		if len(segments) == 1 {
+			values[segment.key] = value
+			return nil
+		}
+		child, ok := values[segment.key].(map[string]interface{})
+		if !ok {
+			child = make(map[string]interface{})
+			values[segment.key] = child
+		}
+		return setPath(child, segments[1:], value)
+	}
+	list, _ := values[segment.key].([]interface{})
+	updated, err := setIndex(list, segment.indexes, segments[1:], value)
+	if err != nil {
+		return err
+	}
+	values[segment.key] = updated
+	return nil
+}
+
+func setIndex(list []interface{}, indexes []int, remaining []pathSegment, value interface{}) ([]interface{}, error) {
+	index := indexes[0]
+	if index < 0 || index > 10000 {
+		return nil, fmt.Errorf("invalid list index %d", index)
+	}
+	for len(list) <= index {
+		list = append(list, nil)
+	}
+	switch {
+	case len(indexes) > 1:
+		child, _ := list[index].([]interface{})
+		updated, err := setIndex(child, indexes[1:], remaining, value)
+		if err != nil {
+			return nil, err
+		}
+		list[index] = updated
+	case len(remaining) > 0:
+		child, ok := list[index].(map[string]interface{})
+		if !ok {
+			child = make(map[string]interface{})
+			list[index] = child
+		}
+		if err := setPath(child, remaining, value); err != nil {
+			return nil, err
+		}
+	default:
+		list[index] = value
+	}
+	return list, nil
+}
+
+// typedValue converts a --set value into a bool, integer or null where possible, as Helm does
+func typedValue(input string) interface{} {
+	switch strings.ToLower(input) {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null":
+		return nil
+	}
+	if len(input) > 1 && strings.HasPrefix(input, "0") {
+		// leading zeros are significant, e.g. file modes or zip codes
+		return input
+	}
+	if i, err := strconv.ParseInt(input, 10, 64); err == nil {
+		return i
+	}
+	return input
+}
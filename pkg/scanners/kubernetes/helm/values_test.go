@@ -0,0 +1,70 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseSetValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected map[string]interface{}
+	}{
+		{
+			name:  "nested keys and types",
+			input: "image.tag=latest,replicas=3,debug=true,port=080",
+			expected: map[string]interface{}{
+				"image":    map[string]interface{}{"tag": "latest"},
+				"replicas": int64(3),
+				"debug":    true,
+				"port":     "080",
+			},
+		},
+		{
+			name:  "lists",
+			input: "hosts={a.example.com,b.example.com},containers[1].name=sidecar",
+			expected: map[string]interface{}{
+				"hosts": []interface{}{"a.example.com", "b.example.com"},
+				"containers": []interface{}{
+					nil,
+					map[string]interface{}{"name": "sidecar"},
+				},
+			},
+		},
+		{
+			name:  "escaped separators",
+			input: `annotations.example\.io/name=a\,b`,
+			expected: map[string]interface{}{
+				"annotations": map[string]interface{}{"example.io/name": "a,b"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			values := make(map[string]interface{})
+			require.NoError(t, parseSetValue(values, test.input))
+			assert.Equal(t, test.expected, values)
+		})
+	}
+}
+
+func Test_ParseSetValueWithoutAssignment(t *testing.T) {
+	assert.Error(t, parseSetValue(make(map[string]interface{}), "image.tag"))
+}
+
+func Test_MergeValues(t *testing.T) {
+	merged := mergeValues(map[string]interface{}{
+		"image":  map[string]interface{}{"repository": "nginx", "tag": "1.0"},
+		"remove": "me",
+	}, map[string]interface{}{
+		"image":  map[string]interface{}{"tag": "2.0"},
+		"remove": nil,
+	})
+	assert.Equal(t, map[string]interface{}{
+		"image": map[string]interface{}{"repository": "nginx", "tag": "2.0"},
+	}, merged)
+}
@@ -0,0 +1,314 @@
+package kustomize
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Object is a Kubernetes object produced by building a kustomization
+type Object struct {
+	// Path is the resource file the object was originally defined in
+	Path    string
+	Content map[string]interface{}
+	// originalName is the name before any prefix or suffix was added, which patches may also refer to
+	originalName string
+}
+
+func (o *Object) kind() string {
+	kind, _ := o.Content["kind"].(string)
+	return kind
+}
+
+func (o *Object) metadata() map[string]interface{} {
+	metadata, ok := o.Content["metadata"].(map[string]interface{})
+	if !ok {
+		metadata = make(map[string]interface{})
+		o.Content["metadata"] = metadata
+	}
+	return metadata
+}
+
+func (o *Object) name() string {
+	name, _ := o.metadata()["name"].(string)
+	return name
+}
+
+func (o *Object) namespace() string {
+	namespace, _ := o.metadata()["namespace"].(string)
+	return namespace
+}
+
+// Result is the output of building a kustomization
+type Result struct {
+	Objects []Object
+	// Files are all of the files used to build the kustomization, including the kustomization files themselves
+	Files []string
+	// Kustomizations are the directories of other kustomizations used as resources, bases or components
+	Kustomizations []string
+}
+
+// Builder builds kustomizations offline, in the same way as "kustomize build". Remote resources are not fetched.
+type Builder struct {
+	debugWriter io.Writer
+}
+
+type Option func(b *Builder)
+
+func OptionWithDebugWriter(w io.Writer) Option {
+	return func(b *Builder) {
+		b.debugWriter = w
+	}
+}
+
+// New creates a new Builder
+func New(options ...Option) *Builder {
+	b := &Builder{}
+	for _, option := range options {
+		option(b)
+	}
+	return b
+}
+
+func (b *Builder) debug(format string, args ...interface{}) {
+	if b.debugWriter == nil {
+		return
+	}
+	prefix := "[debug:parse:kustomize] "
+	_, _ = b.debugWriter.Write([]byte(fmt.Sprintf(prefix+format+"\n", args...)))
+}
+
+// Build builds the kustomization in the given directory
+func (b *Builder) Build(target fs.FS, dir string) (*Result, error) {
+	return b.build(target, path.Clean(filepath.ToSlash(dir)), nil, nil)
+}
+
+// build builds the kustomization in the directory. Components are applied to the objects of the kustomization
+// which uses them, so these are passed in as the inherited objects.
+func (b *Builder) build(target fs.FS, dir string, visiting []string, inherited []Object) (*Result, error) {
+	for _, visited := range visiting {
+		if visited == dir {
+			return nil, fmt.Errorf("cycle detected: '%s' includes itself", dir)
+		}
+	}
+	visiting = append(visiting, dir)
+
+	filename := kustomizationFile(target, dir)
+	if filename == "" {
+		return nil, fmt.Errorf("no kustomization file found in '%s'", dir)
+	}
+	k, err := loadKustomization(target, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Objects: inherited, Files: []string{filename}}
+	for _, resource := range append(append([]string{}, k.Resources...), k.Bases...) {
+		if err := b.addResource(target, dir, resource, visiting, result); err != nil {
+			return nil, err
+		}
+	}
+	for _, component := range k.Components {
+		if isRemote(component) {
+			b.debug("Skipping remote component '%s' in '%s'", component, dir)
+			continue
+		}
+		componentPath := path.Join(dir, component)
+		sub, err := b.build(target, componentPath, visiting, result.Objects)
+		if err != nil {
+			return nil, err
+		}
+		result.Objects = sub.Objects
+		result.Files = append(result.Files, sub.Files...)
+		result.Kustomizations = append(append(result.Kustomizations, componentPath), sub.Kustomizations...)
+	}
+
+	for _, patchRef := range k.PatchesStrategicMerge {
+		patches, err := b.readPatch(target, dir, patch{Path: patchRef}, result)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range patches {
+			b.applyPatch(result, nil, p)
+		}
+	}
+	for _, patchRef := range append(append([]patch{}, k.PatchesJSON6902...), k.Patches...) {
+		patches, err := b.readPatch(target, dir, patchRef, result)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range patches {
+			b.applyPatch(result, patchRef.Target, p)
+		}
+	}
+
+	for i := range result.Objects {
+		object := &result.Objects[i]
+		applyNamePrefixAndSuffix(object, k.NamePrefix, k.NameSuffix)
+		applyNamespace(object, k.Namespace)
+		applyLabels(object, k.CommonLabels)
+		applyAnnotations(object, k.CommonAnnotations)
+		applyImages(object.Content, k.Images)
+	}
+
+	return result, nil
+}
+
+func (b *Builder) addResource(target fs.FS, dir string, resource string, visiting []string, result *Result) error {
+	if isRemote(resource) {
+		b.debug("Skipping remote resource '%s' in '%s'", resource, dir)
+		return nil
+	}
+	resourcePath := path.Join(dir, resource)
+	info, err := fs.Stat(target, resourcePath)
+	if err != nil {
+		return fmt.Errorf("resource '%s' in '%s' not found: %w", resource, dir, err)
+	}
+	if info.IsDir() {
+		sub, err := b.build(target, resourcePath, visiting, nil)
+		if err != nil {
+			return err
+		}
+		result.Objects = append(result.Objects, sub.Objects...)
+		result.Files = append(result.Files, sub.Files...)
+		result.Kustomizations = append(append(result.Kustomizations, resourcePath), sub.Kustomizations...)
+		return nil
+	}
+	data, err := fs.ReadFile(target, resourcePath)
+	if err != nil {
+		return err
+	}
+	documents, err := decodeDocuments(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse '%s': %w", resourcePath, err)
+	}
+	for _, document := range documents {
+		content, ok := document.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		object := Object{Path: resourcePath, Content: content}
+		object.originalName = object.name()
+		result.Objects = append(result.Objects, object)
+	}
+	result.Files = append(result.Files, resourcePath)
+	return nil
+}
+
+// readPatch returns the documents of an inline patch, or of the patch file it refers to
+func (b *Builder) readPatch(target fs.FS, dir string, ref patch, result *Result) ([]interface{}, error) {
+	data := []byte(ref.Patch)
+	if ref.Path != "" {
+		patchPath := path.Join(dir, ref.Path)
+		fileData, err := fs.ReadFile(target, patchPath)
+		switch {
+		case err == nil:
+			data = fileData
+			result.Files = append(result.Files, patchPath)
+		case strings.Contains(ref.Path, "\n"):
+			// patchesStrategicMerge entries may also be inline patches
+			data = []byte(ref.Path)
+		default:
+			return nil, fmt.Errorf("patch '%s' in '%s' not found: %w", ref.Path, dir, err)
+		}
+	}
+	documents, err := decodeDocuments(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse patch in '%s': %w", dir, err)
+	}
+	return documents, nil
+}
+
+// applyPatch applies a JSON 6902 patch (a list of operations) or a strategic merge patch to the matching objects.
+// Strategic merge patches without a target are applied to the object with the same kind and name.
+func (b *Builder) applyPatch(result *Result, target *selector, document interface{}) {
+	var kept []Object
+	for _, object := range result.Objects {
+		switch typed := document.(type) {
+		case []interface{}:
+			if target == nil || !target.matches(&object) {
+				break
+			}
+			patched, err := applyJSONPatch(object.Content, typed)
+			if err != nil {
+				b.debug("Failed to apply patch to %s '%s': %s", object.kind(), object.name(), err)
+				break
+			}
+			object.Content = patched
+		case map[string]interface{}:
+			if target != nil && !target.matches(&object) {
+				break
+			}
+			if target == nil && !patchMatches(typed, &object) {
+				break
+			}
+			if typed["$patch"] == "delete" {
+				continue
+			}
+			object.Content = strategicMerge(object.Content, typed)
+		}
+		kept = append(kept, object)
+	}
+	result.Objects = kept
+}
+
+func patchMatches(p map[string]interface{}, object *Object) bool {
+	kind, _ := p["kind"].(string)
+	metadata, _ := p["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	namespace, _ := metadata["namespace"].(string)
+	if kind != object.kind() || (name != object.name() && name != object.originalName) {
+		return false
+	}
+	return namespace == "" || namespace == object.namespace()
+}
+
+func (s *selector) matches(object *Object) bool {
+	apiVersion, _ := object.Content["apiVersion"].(string)
+	group, version := "", apiVersion
+	if strings.Contains(apiVersion, "/") {
+		group, version, _ = strings.Cut(apiVersion, "/")
+	}
+	return matchesPattern(s.Group, group) &&
+		matchesPattern(s.Version, version) &&
+		matchesPattern(s.Kind, object.kind()) &&
+		(matchesPattern(s.Name, object.name()) || matchesPattern(s.Name, object.originalName)) &&
+		matchesPattern(s.Namespace, object.namespace())
+}
+
+// matchesPattern matches a selector field, which kustomize treats as an anchored regular expression
+func matchesPattern(pattern string, value string) bool {
+	if pattern == "" || pattern == value {
+		return true
+	}
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return false
+	}
+	return re.MatchString(value)
+}
+
+func decodeDocuments(data []byte) ([]interface{}, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	var documents []interface{}
+	for {
+		var document interface{}
+		if err := decoder.Decode(&document); err != nil {
+			if errors.Is(err, io.EOF) {
+				return documents, nil
+			}
+			return nil, err
+		}
+		if document != nil {
+			documents = append(documents, document)
+		}
+	}
+}
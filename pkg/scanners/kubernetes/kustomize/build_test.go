@@ -0,0 +1,224 @@
+package kustomize
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/defsec/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testFiles = map[string]string{
+	"base/kustomization.yaml": `resources:
+  - deployment.yaml
+  - service.yaml
+commonLabels:
+  app: web
+`,
+	"base/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  selector:
+    matchLabels:
+      tier: frontend
+  template:
+    metadata:
+      labels:
+        tier: frontend
+    spec:
+      containers:
+        - name: web
+          image: nginx:1.0
+          securityContext:
+            privileged: true
+        - name: sidecar
+          image: registry.example.com:5000/proxy
+`,
+	"base/service.yaml": `apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  ports:
+    - port: 80
+`,
+	"overlays/prod/kustomization.yaml": `bases:
+  - ../../base
+namePrefix: prod-
+namespace: production
+commonAnnotations:
+  owner: platform
+images:
+  - name: nginx
+    newTag: "1.23"
+  - name: registry.example.com:5000/proxy
+    newName: proxy
+    digest: sha256:abc
+patchesStrategicMerge:
+  - security.yaml
+patchesJson6902:
+  - target:
+      group: apps
+      version: v1
+      kind: Deployment
+      name: web
+    path: replicas.yaml
+patches:
+  - target:
+      kind: Service
+    patch: |-
+      - op: replace
+        path: /spec/ports/0/port
+        value: 8080
+  - patch: |-
+      apiVersion: v1
+      kind: Service
+      metadata:
+        name: web
+        labels:
+          exposed: "true"
+`,
+	"overlays/prod/security.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      containers:
+        - name: web
+          securityContext:
+            privileged: false
+            readOnlyRootFilesystem: true
+`,
+	"overlays/prod/replicas.yaml": `- op: add
+  path: /spec/replicas
+  value: 3
+`,
+}
+
+func Test_BuildOverlay(t *testing.T) {
+	fs := testutil.CreateFS(t, testFiles)
+
+	dirs, err := FindKustomizations(fs, ".")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"base", "overlays/prod"}, dirs)
+
+	result, err := New().Build(fs, "overlays/prod")
+	require.NoError(t, err)
+	require.Len(t, result.Objects, 2)
+	assert.Equal(t, []string{"base"}, result.Kustomizations)
+	assert.ElementsMatch(t, []string{
+		"overlays/prod/kustomization.yaml",
+		"base/kustomization.yaml",
+		"base/deployment.yaml",
+		"base/service.yaml",
+		"overlays/prod/security.yaml",
+		"overlays/prod/replicas.yaml",
+	}, result.Files)
+
+	deployment := result.Objects[0]
+	assert.Equal(t, "base/deployment.yaml", deployment.Path)
+	assert.Equal(t, map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":        "prod-web",
+			"namespace":   "production",
+			"labels":      map[string]interface{}{"app": "web"},
+			"annotations": map[string]interface{}{"owner": "platform"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": 3,
+			"selector": map[string]interface{}{
+				"matchLabels": map[string]interface{}{"tier": "frontend", "app": "web"},
+			},
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels":      map[string]interface{}{"tier": "frontend", "app": "web"},
+					"annotations": map[string]interface{}{"owner": "platform"},
+				},
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{
+							"name":  "web",
+							"image": "nginx:1.23",
+							"securityContext": map[string]interface{}{
+								"privileged":             false,
+								"readOnlyRootFilesystem": true,
+							},
+						},
+						map[string]interface{}{
+							"name":  "sidecar",
+							"image": "proxy@sha256:abc",
+						},
+					},
+				},
+			},
+		},
+	}, deployment.Content)
+
+	service := result.Objects[1]
+	assert.Equal(t, "base/service.yaml", service.Path)
+	assert.Equal(t, "prod-web", service.name())
+	assert.Equal(t, 8080, service.Content["spec"].(map[string]interface{})["ports"].([]interface{})[0].(map[string]interface{})["port"])
+	assert.Equal(t, map[string]interface{}{"app": "web"}, service.Content["spec"].(map[string]interface{})["selector"])
+	assert.Equal(t, map[string]interface{}{"app": "web", "exposed": "true"}, service.metadata()["labels"])
+}
+
+func Test_BuildComponent(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"app/kustomization.yaml": `resources:
+  - pod.yaml
+components:
+  - ../hardening
+`,
+		"app/pod.yaml": `apiVersion: v1
+kind: Pod
+metadata:
+  name: app
+spec:
+  containers:
+    - name: app
+      image: app
+`,
+		"hardening/kustomization.yaml": `apiVersion: kustomize.config.k8s.io/v1alpha1
+kind: Component
+patches:
+  - target:
+      kind: Pod
+    patch: |-
+      - op: add
+        path: /spec/securityContext
+        value:
+          runAsNonRoot: true
+`,
+	})
+
+	result, err := New().Build(fs, "app")
+	require.NoError(t, err)
+	require.Len(t, result.Objects, 1)
+	assert.Equal(t, map[string]interface{}{"runAsNonRoot": true}, result.Objects[0].Content["spec"].(map[string]interface{})["securityContext"])
+	assert.Equal(t, []string{"hardening"}, result.Kustomizations)
+}
+
+func Test_BuildErrors(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"a/kustomization.yaml":       "resources:\n  - ../b\n",
+		"b/kustomization.yaml":       "resources:\n  - ../a\n",
+		"missing/kustomization.yaml": "resources:\n  - nothing.yaml\n",
+		"remote/kustomization.yaml":  "resources:\n  - https://github.com/example/repo//base?ref=v1\n",
+	})
+
+	_, err := New().Build(fs, "a")
+	assert.Error(t, err)
+
+	_, err = New().Build(fs, "missing")
+	assert.Error(t, err)
+
+	result, err := New().Build(fs, "remote")
+	require.NoError(t, err)
+	assert.Empty(t, result.Objects)
+}
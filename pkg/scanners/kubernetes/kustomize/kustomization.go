@@ -0,0 +1,106 @@
+package kustomize
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// kustomizationFilenames are the names kustomize accepts for a kustomization file, in order of precedence
+var kustomizationFilenames = []string{"kustomization.yaml", "kustomization.yml", "Kustomization"}
+
+type kustomization struct {
+	Resources             []string          `yaml:"resources"`
+	Bases                 []string          `yaml:"bases"`
+	Components            []string          `yaml:"components"`
+	Namespace             string            `yaml:"namespace"`
+	NamePrefix            string            `yaml:"namePrefix"`
+	NameSuffix            string            `yaml:"nameSuffix"`
+	CommonLabels          map[string]string `yaml:"commonLabels"`
+	CommonAnnotations     map[string]string `yaml:"commonAnnotations"`
+	PatchesStrategicMerge []string          `yaml:"patchesStrategicMerge"`
+	PatchesJSON6902       []patch           `yaml:"patchesJson6902"`
+	Patches               []patch           `yaml:"patches"`
+	Images                []image           `yaml:"images"`
+}
+
+// patch is a JSON 6902 or strategic merge patch, either inline or in a file
+type patch struct {
+	Path   string    `yaml:"path"`
+	Patch  string    `yaml:"patch"`
+	Target *selector `yaml:"target"`
+}
+
+type selector struct {
+	Group     string `yaml:"group"`
+	Version   string `yaml:"version"`
+	Kind      string `yaml:"kind"`
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace"`
+}
+
+type image struct {
+	Name    string `yaml:"name"`
+	NewName string `yaml:"newName"`
+	NewTag  string `yaml:"newTag"`
+	Digest  string `yaml:"digest"`
+}
+
+// kustomizationFile returns the path of the kustomization file in the directory, or an empty string if there is none
+func kustomizationFile(target fs.FS, dir string) string {
+	for _, name := range kustomizationFilenames {
+		p := path.Join(dir, name)
+		if info, err := fs.Stat(target, p); err == nil && !info.IsDir() {
+			return p
+		}
+	}
+	return ""
+}
+
+// IsKustomization returns true if the directory contains a kustomization file
+func IsKustomization(target fs.FS, dir string) bool {
+	return kustomizationFile(target, filepath.ToSlash(dir)) != ""
+}
+
+// FindKustomizations returns all directories in the given directory which contain a kustomization file
+func FindKustomizations(target fs.FS, dir string) ([]string, error) {
+	var dirs []string
+	if err := fs.WalkDir(target, filepath.ToSlash(dir), func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() && IsKustomization(target, p) {
+			dirs = append(dirs, p)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Strings(dirs)
+	return dirs, nil
+}
+
+func loadKustomization(target fs.FS, filename string) (*kustomization, error) {
+	data, err := fs.ReadFile(target, filename)
+	if err != nil {
+		return nil, err
+	}
+	var k kustomization
+	if err := yaml.Unmarshal(data, &k); err != nil {
+		return nil, fmt.Errorf("failed to parse '%s': %w", filename, err)
+	}
+	return &k, nil
+}
+
+// isRemote returns true for resources which kustomize would fetch, e.g. git repositories or URLs
+func isRemote(resource string) bool {
+	return strings.Contains(resource, "://") ||
+		strings.HasPrefix(resource, "github.com/") ||
+		strings.HasPrefix(resource, "git@") ||
+		strings.Contains(resource, "?ref=")
+}
@@ -0,0 +1,304 @@
+package kustomize
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// mergeKeys are the fields used to identify list items when applying a strategic merge patch. Where more than
+// one field is listed, the first one present on the list items is used.
+var mergeKeys = map[string][]string{
+	"containers":          {"name"},
+	"initContainers":      {"name"},
+	"ephemeralContainers": {"name"},
+	"volumes":             {"name"},
+	"env":                 {"name"},
+	"imagePullSecrets":    {"name"},
+	"volumeMounts":        {"mountPath"},
+	"volumeDevices":       {"devicePath"},
+	"ports":               {"containerPort", "port"},
+	"hostAliases":         {"ip"},
+}
+
+// strategicMerge applies a strategic merge patch to the object, in place. Lists of known types (containers,
+// volumes etc.) are merged by key, other lists are replaced. The $patch: replace and $patch: delete directives
+// are supported.
+func strategicMerge(original map[string]interface{}, patch map[string]interface{}) map[string]interface{} {
+	for key, patchValue := range patch {
+		if strings.HasPrefix(key, "$") {
+			continue
+		}
+		switch typed := patchValue.(type) {
+		case nil:
+			delete(original, key)
+		case map[string]interface{}:
+			switch typed["$patch"] {
+			case "delete":
+				delete(original, key)
+			case "replace":
+				original[key] = withoutDirectives(typed)
+			default:
+				if existing, ok := original[key].(map[string]interface{}); ok {
+					original[key] = strategicMerge(existing, typed)
+				} else {
+					original[key] = withoutDirectives(typed)
+				}
+			}
+		case []interface{}:
+			existing, ok := original[key].([]interface{})
+			if !ok {
+				original[key] = withoutDirectives(typed)
+				continue
+			}
+			original[key] = mergeList(key, existing, typed)
+		default:
+			original[key] = patchValue
+		}
+	}
+	return original
+}
+
+func mergeList(key string, original []interface{}, patch []interface{}) []interface{} {
+	for _, item := range patch {
+		if directive, ok := item.(map[string]interface{}); ok && directive["$patch"] == "replace" && len(directive) == 1 {
+			// the whole list is replaced with the other items in the patch
+			var replaced []interface{}
+			for _, other := range patch {
+				if !reflect.DeepEqual(other, item) {
+					replaced = append(replaced, withoutDirectives(other))
+				}
+			}
+			return replaced
+		}
+	}
+
+	mergeKey := mergeKeyFor(key, original, patch)
+	if mergeKey == "" {
+		return withoutDirectives(patch).([]interface{})
+	}
+
+	output := append([]interface{}{}, original...)
+	for _, item := range patch {
+		patchItem := item.(map[string]interface{})
+		index := -1
+		for i, existing := range output {
+			if existingItem, ok := existing.(map[string]interface{}); ok && reflect.DeepEqual(existingItem[mergeKey], patchItem[mergeKey]) {
+				index = i
+				break
+			}
+		}
+		switch {
+		case patchItem["$patch"] == "delete":
+			if index >= 0 {
+				output = append(output[:index], output[index+1:]...)
+			}
+		case index >= 0:
+			output[index] = strategicMerge(output[index].(map[string]interface{}), patchItem)
+		default:
+			output = append(output, withoutDirectives(patchItem))
+		}
+	}
+	return output
+}
+
+// mergeKeyFor returns the field used to merge the list items, or an empty string if the list should be replaced
+func mergeKeyFor(key string, lists ...[]interface{}) string {
+	candidates, ok := mergeKeys[key]
+	if !ok {
+		return ""
+	}
+	for _, candidate := range candidates {
+		found := true
+		for _, list := range lists {
+			for _, item := range list {
+				obj, ok := item.(map[string]interface{})
+				if !ok {
+					return ""
+				}
+				if _, ok := obj[candidate]; !ok {
+					if _, isDirective := obj["$patch"]; !isDirective {
+						found = false
+					}
+				}
+			}
+		}
+		if found {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// withoutDirectives returns a copy of the value with all patch directives removed
+func withoutDirectives(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		output := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			if strings.HasPrefix(key, "$") {
+				continue
+			}
+			output[key] = withoutDirectives(item)
+		}
+		return output
+	case []interface{}:
+		output := make([]interface{}, 0, len(typed))
+		for _, item := range typed {
+			output = append(output, withoutDirectives(item))
+		}
+		return output
+	default:
+		return value
+	}
+}
+
+// applyJSONPatch applies the RFC 6902 operations to the object
+func applyJSONPatch(object map[string]interface{}, operations []interface{}) (map[string]interface{}, error) {
+	var doc interface{} = object
+	for _, raw := range operations {
+		operation, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid patch operation: %v", raw)
+		}
+		op, _ := operation["op"].(string)
+		path, _ := operation["path"].(string)
+		from, _ := operation["from"].(string)
+		value := operation["value"]
+
+		var err error
+		switch op {
+		case "add", "replace", "remove":
+			doc, err = modify(doc, op, splitPointer(path), value)
+		case "move", "copy":
+			var source interface{}
+			if source, err = get(doc, splitPointer(from)); err != nil {
+				break
+			}
+			if op == "move" {
+				if doc, err = modify(doc, "remove", splitPointer(from), nil); err != nil {
+					break
+				}
+			} else {
+				source = withoutDirectives(source)
+			}
+			doc, err = modify(doc, "add", splitPointer(path), source)
+		case "test":
+			var actual interface{}
+			if actual, err = get(doc, splitPointer(path)); err == nil && !reflect.DeepEqual(actual, value) {
+				err = fmt.Errorf("test failed for '%s'", path)
+			}
+		default:
+			err = fmt.Errorf("unsupported operation '%s'", op)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	result, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("patch did not produce an object")
+	}
+	return result, nil
+}
+
+// splitPointer splits a JSON pointer, e.g. /metadata/labels/app.kubernetes.io~1name, into unescaped tokens
+func splitPointer(pointer string) []string {
+	if pointer == "" || pointer == "/" {
+		return nil
+	}
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens
+}
+
+func get(node interface{}, tokens []string) (interface{}, error) {
+	for _, token := range tokens {
+		switch typed := node.(type) {
+		case map[string]interface{}:
+			value, ok := typed[token]
+			if !ok {
+				return nil, fmt.Errorf("path '%s' does not exist", token)
+			}
+			node = value
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(typed) {
+				return nil, fmt.Errorf("invalid index '%s'", token)
+			}
+			node = typed[index]
+		default:
+			return nil, fmt.Errorf("cannot traverse into '%s'", token)
+		}
+	}
+	return node, nil
+}
+
+// modify applies an add, replace or remove operation at the path, returning the updated node
+func modify(node interface{}, op string, tokens []string, value interface{}) (interface{}, error) {
+	if len(tokens) == 0 {
+		if op == "remove" {
+			return nil, nil
+		}
+		return value, nil
+	}
+	token := tokens[0]
+	switch typed := node.(type) {
+	case map[string]interface{}:
+		existing, exists := typed[token]
+		if len(tokens) > 1 {
+			if !exists {
+				return nil, fmt.Errorf("path '%s' does not exist", token)
+			}
+			updated, err := modify(existing, op, tokens[1:], value)
+			if err != nil {
+				return nil, err
+			}
+			typed[token] = updated
+			return typed, nil
+		}
+		switch {
+		case op == "add":
+			typed[token] = value
+		case !exists:
+			return nil, fmt.Errorf("path '%s' does not exist", token)
+		case op == "replace":
+			typed[token] = value
+		default:
+			delete(typed, token)
+		}
+		return typed, nil
+	case []interface{}:
+		if token == "-" && op == "add" && len(tokens) == 1 {
+			return append(typed, value), nil
+		}
+		index, err := strconv.Atoi(token)
+		if err != nil || index < 0 || index > len(typed) || (index == len(typed) && (op != "add" || len(tokens) > 1)) {
+			return nil, fmt.Errorf("invalid index '%s'", token)
+		}
+		if len(tokens) > 1 {
+			updated, err := modify(typed[index], op, tokens[1:], value)
+			if err != nil {
+				return nil, err
+			}
+			typed[index] = updated
+			return typed, nil
+		}
+		switch op {
+		case "add":
+			output := append([]interface{}{}, typed[:index]...)
+			output = append(output, value)
+			return append(output, typed[index:]...), nil
+		case "replace":
+			typed[index] = value
+			return typed, nil
+		default:
+			return append(typed[:index], typed[index+1:]...), nil
+		}
+	default:
+		return nil, fmt.Errorf("cannot traverse into '%s'", token)
+	}
+}
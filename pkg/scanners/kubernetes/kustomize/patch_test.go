@@ -0,0 +1,135 @@
+package kustomize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_StrategicMerge(t *testing.T) {
+	tests := []struct {
+		name     string
+		original map[string]interface{}
+		patch    map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name:     "merge maps",
+			original: map[string]interface{}{"a": map[string]interface{}{"b": 1, "c": 2}},
+			patch:    map[string]interface{}{"a": map[string]interface{}{"c": 3, "d": 4}},
+			expected: map[string]interface{}{"a": map[string]interface{}{"b": 1, "c": 3, "d": 4}},
+		},
+		{
+			name:     "null deletes key",
+			original: map[string]interface{}{"a": 1, "b": 2},
+			patch:    map[string]interface{}{"a": nil},
+			expected: map[string]interface{}{"b": 2},
+		},
+		{
+			name:     "replace map",
+			original: map[string]interface{}{"a": map[string]interface{}{"b": 1}},
+			patch:    map[string]interface{}{"a": map[string]interface{}{"$patch": "replace", "c": 2}},
+			expected: map[string]interface{}{"a": map[string]interface{}{"c": 2}},
+		},
+		{
+			name: "merge containers by name",
+			original: map[string]interface{}{"containers": []interface{}{
+				map[string]interface{}{"name": "a", "image": "a"},
+				map[string]interface{}{"name": "b", "image": "b"},
+			}},
+			patch: map[string]interface{}{"containers": []interface{}{
+				map[string]interface{}{"name": "b", "image": "b2"},
+				map[string]interface{}{"name": "c", "image": "c"},
+			}},
+			expected: map[string]interface{}{"containers": []interface{}{
+				map[string]interface{}{"name": "a", "image": "a"},
+				map[string]interface{}{"name": "b", "image": "b2"},
+				map[string]interface{}{"name": "c", "image": "c"},
+			}},
+		},
+		{
+			name: "delete container",
+			original: map[string]interface{}{"containers": []interface{}{
+				map[string]interface{}{"name": "a"},
+				map[string]interface{}{"name": "b"},
+			}},
+			patch: map[string]interface{}{"containers": []interface{}{
+				map[string]interface{}{"name": "a", "$patch": "delete"},
+			}},
+			expected: map[string]interface{}{"containers": []interface{}{
+				map[string]interface{}{"name": "b"},
+			}},
+		},
+		{
+			name:     "replace unknown list",
+			original: map[string]interface{}{"args": []interface{}{"a", "b"}},
+			patch:    map[string]interface{}{"args": []interface{}{"c"}},
+			expected: map[string]interface{}{"args": []interface{}{"c"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, strategicMerge(test.original, test.patch))
+		})
+	}
+}
+
+func Test_JSONPatch(t *testing.T) {
+	object := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "a"},
+		"list":     []interface{}{"x", "y"},
+	}
+	patched, err := applyJSONPatch(object, []interface{}{
+		map[string]interface{}{"op": "add", "path": "/metadata/labels", "value": map[string]interface{}{}},
+		map[string]interface{}{"op": "add", "path": "/metadata/labels/app.kubernetes.io~1name", "value": "web"},
+		map[string]interface{}{"op": "add", "path": "/list/1", "value": "z"},
+		map[string]interface{}{"op": "add", "path": "/list/-", "value": "end"},
+		map[string]interface{}{"op": "remove", "path": "/list/0"},
+		map[string]interface{}{"op": "copy", "from": "/metadata/name", "path": "/copied"},
+		map[string]interface{}{"op": "move", "from": "/copied", "path": "/moved"},
+		map[string]interface{}{"op": "test", "path": "/moved", "value": "a"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":   "a",
+			"labels": map[string]interface{}{"app.kubernetes.io/name": "web"},
+		},
+		"list":  []interface{}{"z", "y", "end"},
+		"moved": "a",
+	}, patched)
+
+	_, err = applyJSONPatch(patched, []interface{}{
+		map[string]interface{}{"op": "replace", "path": "/missing", "value": 1},
+	})
+	assert.Error(t, err)
+
+	_, err = applyJSONPatch(patched, []interface{}{
+		map[string]interface{}{"op": "test", "path": "/moved", "value": "b"},
+	})
+	assert.Error(t, err)
+}
+
+func Test_SplitImage(t *testing.T) {
+	tests := []struct {
+		ref    string
+		name   string
+		tag    string
+		digest string
+	}{
+		{ref: "nginx", name: "nginx"},
+		{ref: "nginx:1.0", name: "nginx", tag: "1.0"},
+		{ref: "registry:5000/app", name: "registry:5000/app"},
+		{ref: "registry:5000/app:2@sha256:abc", name: "registry:5000/app", tag: "2", digest: "sha256:abc"},
+	}
+	for _, test := range tests {
+		t.Run(test.ref, func(t *testing.T) {
+			name, tag, digest := splitImage(test.ref)
+			assert.Equal(t, test.name, name)
+			assert.Equal(t, test.tag, tag)
+			assert.Equal(t, test.digest, digest)
+		})
+	}
+}
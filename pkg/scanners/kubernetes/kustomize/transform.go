@@ -0,0 +1,151 @@
+package kustomize
+
+import "strings"
+
+// clusterScopedKinds are not given the namespace of the kustomization
+var clusterScopedKinds = map[string]bool{
+	"Namespace":                      true,
+	"ClusterRole":                    true,
+	"ClusterRoleBinding":             true,
+	"CustomResourceDefinition":       true,
+	"PersistentVolume":               true,
+	"StorageClass":                   true,
+	"PriorityClass":                  true,
+	"PodSecurityPolicy":              true,
+	"APIService":                     true,
+	"ValidatingWebhookConfiguration": true,
+	"MutatingWebhookConfiguration":   true,
+}
+
+// selectorPaths are the label selectors (and the pod template labels they select) which common labels are added to
+var selectorPaths = map[string][][]string{
+	"Deployment":  {{"spec", "selector", "matchLabels"}, {"spec", "template", "metadata", "labels"}},
+	"ReplicaSet":  {{"spec", "selector", "matchLabels"}, {"spec", "template", "metadata", "labels"}},
+	"StatefulSet": {{"spec", "selector", "matchLabels"}, {"spec", "template", "metadata", "labels"}},
+	"DaemonSet":   {{"spec", "selector", "matchLabels"}, {"spec", "template", "metadata", "labels"}},
+	"Job":         {{"spec", "template", "metadata", "labels"}},
+	"CronJob":     {{"spec", "jobTemplate", "spec", "template", "metadata", "labels"}},
+	"Service":     {{"spec", "selector"}},
+}
+
+func applyNamePrefixAndSuffix(object *Object, prefix string, suffix string) {
+	if (prefix == "" && suffix == "") || object.kind() == "CustomResourceDefinition" {
+		return
+	}
+	object.metadata()["name"] = prefix + object.name() + suffix
+}
+
+func applyNamespace(object *Object, namespace string) {
+	if namespace == "" || clusterScopedKinds[object.kind()] {
+		return
+	}
+	object.metadata()["namespace"] = namespace
+}
+
+func applyLabels(object *Object, labels map[string]string) {
+	if len(labels) == 0 {
+		return
+	}
+	addStrings(object.Content, []string{"metadata", "labels"}, labels)
+	for _, p := range selectorPaths[object.kind()] {
+		addStrings(object.Content, p, labels)
+	}
+}
+
+func applyAnnotations(object *Object, annotations map[string]string) {
+	if len(annotations) == 0 {
+		return
+	}
+	addStrings(object.Content, []string{"metadata", "annotations"}, annotations)
+	for _, p := range selectorPaths[object.kind()] {
+		if p[len(p)-1] == "labels" {
+			templateAnnotations := append(append([]string{}, p[:len(p)-1]...), "annotations")
+			addStrings(object.Content, templateAnnotations, annotations)
+		}
+	}
+}
+
+// addStrings adds the values to the map at the given path, creating any missing maps along the way
+func addStrings(object map[string]interface{}, path []string, values map[string]string) {
+	current := object
+	for _, key := range path {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			current[key] = next
+		}
+		current = next
+	}
+	for key, value := range values {
+		current[key] = value
+	}
+}
+
+// applyImages updates the image of any container or init container which uses one of the named images
+func applyImages(node interface{}, images []image) {
+	if len(images) == 0 {
+		return
+	}
+	switch typed := node.(type) {
+	case map[string]interface{}:
+		for key, value := range typed {
+			if key == "containers" || key == "initContainers" {
+				for _, item := range asList(value) {
+					if container, ok := item.(map[string]interface{}); ok {
+						if current, ok := container["image"].(string); ok {
+							container["image"] = updateImage(current, images)
+						}
+					}
+				}
+				continue
+			}
+			applyImages(value, images)
+		}
+	case []interface{}:
+		for _, item := range typed {
+			applyImages(item, images)
+		}
+	}
+}
+
+func asList(value interface{}) []interface{} {
+	list, _ := value.([]interface{})
+	return list
+}
+
+func updateImage(current string, images []image) string {
+	name, tag, digest := splitImage(current)
+	for _, img := range images {
+		if img.Name != name {
+			continue
+		}
+		if img.NewName != "" {
+			name = img.NewName
+		}
+		if img.NewTag != "" {
+			tag, digest = img.NewTag, ""
+		}
+		if img.Digest != "" {
+			tag, digest = "", img.Digest
+		}
+		break
+	}
+	output := name
+	if tag != "" {
+		output += ":" + tag
+	}
+	if digest != "" {
+		output += "@" + digest
+	}
+	return output
+}
+
+// splitImage splits an image reference such as registry:5000/app:1.0@sha256:abc into its name, tag and digest
+func splitImage(ref string) (string, string, string) {
+	name, digest, _ := strings.Cut(ref, "@")
+	var tag string
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, tag = name[:i], name[i+1:]
+	}
+	return name, tag, digest
+}
@@ -3,6 +3,9 @@ package kubernetes
 import (
 	"io"
 
+	"github.com/aquasecurity/defsec/pkg/ignore"
+	"github.com/aquasecurity/defsec/pkg/scanners/kubernetes/helm"
+	"github.com/aquasecurity/defsec/pkg/scanners/kubernetes/kustomize"
 	"github.com/aquasecurity/defsec/pkg/scanners/kubernetes/parser"
 )
 
@@ -12,6 +15,8 @@ type Option func(s *Scanner)
 func OptionWithDebug(debugWriter io.Writer) func(s *Scanner) {
 	return func(s *Scanner) {
 		s.debugWriter = debugWriter
+		s.helmOptions = append(s.helmOptions, helm.OptionWithDebugWriter(debugWriter))
+		s.kustomizeOptions = append(s.kustomizeOptions, kustomize.OptionWithDebugWriter(debugWriter))
 	}
 }
 
@@ -57,3 +62,41 @@ func OptionWithPerResultTracing() Option {
 		s.traceWriter = io.Discard
 	}
 }
+
+// OptionWithHelmValuesFiles - values files to use when rendering Helm charts, in addition to the values.yaml of the chart.
+// The paths are on the OS filesystem, not the scanned filesystem. Results for rendered charts name the template file
+// but not a line, so inline ignores in templates do not apply; use a suppression file instead.
+func OptionWithHelmValuesFiles(paths ...string) Option {
+	return func(s *Scanner) {
+		s.helmOptions = append(s.helmOptions, helm.OptionWithValuesFiles(paths...))
+	}
+}
+
+// OptionWithHelmValues - --set style overrides to use when rendering Helm charts, e.g. "securityContext.runAsUser=0"
+func OptionWithHelmValues(values ...string) Option {
+	return func(s *Scanner) {
+		s.helmOptions = append(s.helmOptions, helm.OptionWithValues(values...))
+	}
+}
+
+// OptionWithHelmOptions - options to pass to the Helm chart renderer, e.g. to set the release name
+func OptionWithHelmOptions(options ...helm.Option) Option {
+	return func(s *Scanner) {
+		s.helmOptions = append(s.helmOptions, options...)
+	}
+}
+
+// OptionWithSuppressions - ignore results matching entries of a suppression file, e.g. .defsecignore
+func OptionWithSuppressions(suppressions ignore.Suppressions) Option {
+	return func(s *Scanner) {
+		s.suppressions = suppressions
+	}
+}
+
+// OptionReportStaleIgnores - add a result for each inline ignore directive which is expired, refers to an unknown rule
+// or did not ignore anything
+func OptionReportStaleIgnores(report bool) Option {
+	return func(s *Scanner) {
+		s.reportStale = report
+	}
+}
@@ -0,0 +1,7 @@
+package parser
+
+// Manifest is a single Kubernetes object and the file it was defined in
+type Manifest struct {
+	Path    string
+	Content map[string]interface{}
+}
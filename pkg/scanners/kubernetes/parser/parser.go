@@ -1,12 +1,11 @@
 package parser
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
-	"io/ioutil"
 	"path/filepath"
 	"strings"
 
@@ -66,6 +65,11 @@ func (p *Parser) ParseFile(_ context.Context, fs fs.FS, path string) ([]interfac
 	return p.parse(f)
 }
 
+// ParseReader parses the Kubernetes manifests read from the reader, e.g. the output of a rendered Helm template.
+func (p *Parser) ParseReader(r io.Reader) ([]interface{}, error) {
+	return p.parse(r)
+}
+
 func (p *Parser) required(ctx context.Context, fs fs.FS, path string) bool {
 	if p.skipRequired {
 		return true
@@ -100,28 +104,53 @@ func (p *Parser) required(ctx context.Context, fs fs.FS, path string) bool {
 	return false
 }
 
+// parse decodes each document in the stream. The lines each document spans are recorded in the
+// __defsec_metadata of the document, so results can be reported against the correct lines of multi-document files.
 func (p *Parser) parse(r io.Reader) ([]interface{}, error) {
 
-	contents, err := ioutil.ReadAll(r)
-	if err != nil {
-		return nil, err
-	}
-
 	var results []interface{}
 
-	marker := "\n---\n"
-	altMarker := "\r\n---\r\n"
-	if bytes.Contains(contents, []byte(altMarker)) {
-		marker = altMarker
-	}
-
-	for _, partial := range strings.Split(string(contents), marker) {
+	decoder := yaml.NewDecoder(r)
+	for {
+		var node yaml.Node
+		if err := decoder.Decode(&node); err != nil {
+			if errors.Is(err, io.EOF) {
+				return results, nil
+			}
+			return nil, fmt.Errorf("unmarshal yaml: %w", err)
+		}
+		if len(node.Content) == 0 {
+			// empty document, e.g. between two separators
+			continue
+		}
 		var result interface{}
-		if err := yaml.Unmarshal([]byte(partial), &result); err != nil {
+		if err := node.Decode(&result); err != nil {
 			return nil, fmt.Errorf("unmarshal yaml: %w", err)
 		}
+		if result == nil {
+			continue
+		}
+		if object, ok := result.(map[string]interface{}); ok {
+			object["__defsec_metadata"] = map[string]interface{}{
+				"startline": node.Content[0].Line,
+				"endline":   endLine(node.Content[0]),
+			}
+		}
 		results = append(results, result)
 	}
+}
 
-	return results, nil
+// endLine returns the last line of the node and its children
+func endLine(node *yaml.Node) int {
+	end := node.Line
+	if node.Kind == yaml.ScalarNode && node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		// block scalars start on the line after the indicator
+		end += strings.Count(strings.TrimSuffix(node.Value, "\n"), "\n") + 1
+	}
+	for _, child := range node.Content {
+		if childEnd := endLine(child); childEnd > end {
+			end = childEnd
+		}
+	}
+	return end
 }
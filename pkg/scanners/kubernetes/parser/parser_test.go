@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseMultipleDocuments(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected [][2]int
+	}{
+		{
+			name: "separators",
+			source: `apiVersion: v1
+kind: Pod
+---
+apiVersion: v1
+kind: Service
+`,
+			expected: [][2]int{{1, 2}, {4, 5}},
+		},
+		{
+			name: "leading separator and comments",
+			source: `---
+# first
+apiVersion: v1
+kind: Pod
+--- # second
+apiVersion: v1
+kind: Service
+`,
+			expected: [][2]int{{3, 4}, {6, 7}},
+		},
+		{
+			name: "document end markers",
+			source: `apiVersion: v1
+kind: Pod
+...
+---
+apiVersion: v1
+kind: Service
+...
+`,
+			expected: [][2]int{{1, 2}, {5, 6}},
+		},
+		{
+			name: "empty documents",
+			source: `---
+---
+apiVersion: v1
+kind: Pod
+---
+`,
+			expected: [][2]int{{3, 4}},
+		},
+		{
+			name: "block scalars",
+			source: `apiVersion: v1
+kind: ConfigMap
+data:
+  script: |
+    echo hello
+    echo world
+---
+apiVersion: v1
+kind: Pod
+`,
+			expected: [][2]int{{1, 6}, {8, 9}},
+		},
+		{
+			name:     "windows line endings",
+			source:   "apiVersion: v1\r\nkind: Pod\r\n---\r\napiVersion: v1\r\nkind: Service\r\n",
+			expected: [][2]int{{1, 2}, {4, 5}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parsed, err := New().ParseReader(strings.NewReader(test.source))
+			require.NoError(t, err)
+			require.Len(t, parsed, len(test.expected))
+			for i, document := range parsed {
+				metadata := document.(map[string]interface{})["__defsec_metadata"].(map[string]interface{})
+				assert.Equal(t, test.expected[i][0], metadata["startline"], "start line of document %d", i)
+				assert.Equal(t, test.expected[i][1], metadata["endline"], "end line of document %d", i)
+			}
+		})
+	}
+}
+
+func Test_ParseInvalidDocument(t *testing.T) {
+	_, err := New().ParseReader(strings.NewReader("apiVersion: v1\n---\nkind: [Pod\n"))
+	assert.Error(t, err)
+}
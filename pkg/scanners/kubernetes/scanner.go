@@ -7,18 +7,26 @@ import (
 	"io/fs"
 	"io/ioutil"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/liamg/memoryfs"
 
+	adapter "github.com/aquasecurity/defsec/internal/adapters/kubernetes"
+	"github.com/aquasecurity/defsec/internal/rules"
 	"github.com/aquasecurity/defsec/internal/types"
 
+	"github.com/aquasecurity/defsec/pkg/ignore"
 	"github.com/aquasecurity/defsec/pkg/rego"
+	_ "github.com/aquasecurity/defsec/pkg/rules"
+	"github.com/aquasecurity/defsec/pkg/scanners/kubernetes/helm"
+	"github.com/aquasecurity/defsec/pkg/scanners/kubernetes/kustomize"
 	"github.com/aquasecurity/defsec/pkg/scanners/kubernetes/parser"
 
 	"github.com/aquasecurity/defsec/pkg/scan"
 
 	"github.com/aquasecurity/defsec/pkg/scanners"
+	"github.com/aquasecurity/defsec/pkg/state"
 )
 
 var _ scanners.Scanner = (*Scanner)(nil)
@@ -32,6 +40,10 @@ type Scanner struct {
 	policyNamespaces []string
 	regoScanner      *rego.Scanner
 	parser           *parser.Parser
+	helmOptions      []helm.Option
+	kustomizeOptions []kustomize.Option
+	suppressions     ignore.Suppressions
+	reportStale      bool
 	sync.Mutex
 }
 
@@ -63,6 +75,8 @@ func (s *Scanner) initRegoScanner(srcFS fs.FS) (*rego.Scanner, error) {
 	regoOpts := []rego.Option{
 		rego.OptionWithPolicyNamespaces(true, s.policyNamespaces...),
 		rego.OptionWithDataDirs(s.dataDirs...),
+		// the defsec state is scanned alongside the manifests, but only for the policies which select it
+		rego.OptionWithNativeInputsOnly(),
 	}
 	if s.traceWriter != nil {
 		regoOpts = append(regoOpts, rego.OptionWithTrace(s.traceWriter))
@@ -92,17 +106,36 @@ func (s *Scanner) ScanReader(ctx context.Context, filename string, reader io.Rea
 
 func (s *Scanner) ScanFS(ctx context.Context, target fs.FS, dir string) (scan.Results, error) {
 
-	k8sFilesets, err := s.parser.ParseFS(ctx, target, dir)
+	charts, err := helm.FindCharts(target, dir)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(k8sFilesets) == 0 {
-		return nil, nil
+	inputs, err := s.renderCharts(target, charts)
+	if err != nil {
+		return nil, err
+	}
+
+	kustomizeInputs, kustomizeFiles, err := s.buildKustomizations(target, dir, charts)
+	if err != nil {
+		return nil, err
+	}
+	inputs = append(inputs, kustomizeInputs...)
+
+	k8sFilesets, err := s.parser.ParseFS(ctx, target, dir)
+	if err != nil {
+		return nil, err
 	}
 
-	var inputs []rego.Input
 	for path, k8sFiles := range k8sFilesets {
+		if isInChart(path, charts) {
+			// chart templates are only scanned once rendered
+			continue
+		}
+		if kustomizeFiles[path] {
+			// files used by a kustomization are only scanned once built
+			continue
+		}
 		for _, content := range k8sFiles {
 			inputs = append(inputs, rego.Input{
 				Path:     path,
@@ -112,16 +145,173 @@ func (s *Scanner) ScanFS(ctx context.Context, target fs.FS, dir string) (scan.Re
 		}
 	}
 
+	if len(inputs) == 0 {
+		return nil, nil
+	}
+
 	regoScanner, err := s.initRegoScanner(target)
 	if err != nil {
 		return nil, err
 	}
 
+	state := adapter.Adapt(ctx, manifests(inputs))
+	results, err := s.scanState(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+
 	s.debug("Scanning %d files...", len(inputs))
-	results, err := regoScanner.ScanInput(ctx, inputs...)
+	regoResults, err := regoScanner.ScanInput(ctx, append(inputs, rego.Input{
+		Path:     dir,
+		Contents: state.ToRego(),
+		Type:     types.SourceDefsec,
+	})...)
 	if err != nil {
 		return nil, err
 	}
+	results = append(results, regoResults...)
+
+	var paths []string
+	for _, input := range inputs {
+		paths = append(paths, input.Path)
+	}
+	directives := ignore.ParseFS(target, paths...)
+	used := results.ApplyIgnores(directives)
+	results.ApplySuppressions(s.suppressions)
+	if s.reportStale {
+		results.AddStaleIgnores(directives, used)
+	}
 	results.SetSourceAndFilesystem("", target)
 	return results, nil
 }
+
+// scanState runs the registered Go rules against the typed model of the scanned objects
+func (s *Scanner) scanState(ctx context.Context, state *state.State) (scan.Results, error) {
+	var results scan.Results
+	for _, rule := range rules.GetRegistered() {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		evalResult := rule.Evaluate(state)
+		if len(evalResult) > 0 {
+			s.debug("Found %d results for %s", len(evalResult), rule.Rule().AVDID)
+			results = append(results, evalResult...)
+		}
+	}
+	return results, nil
+}
+
+func manifests(inputs []rego.Input) []parser.Manifest {
+	var output []parser.Manifest
+	for _, input := range inputs {
+		if content, ok := input.Contents.(map[string]interface{}); ok {
+			output = append(output, parser.Manifest{Path: input.Path, Content: content})
+		}
+	}
+	return output
+}
+
+// renderCharts renders each Helm chart offline. Each rendered object is mapped back to the template which
+// produced it, so results point at the template file. The lines of the rendered output do not correspond to the
+// template, so they are dropped: results are reported at line 0, and inline ignores in templates do not apply.
+func (s *Scanner) renderCharts(target fs.FS, charts []string) ([]rego.Input, error) {
+	renderer := helm.New(s.helmOptions...)
+	var inputs []rego.Input
+	for _, dir := range charts {
+		chart, err := helm.LoadChart(target, dir)
+		if err != nil {
+			s.debug("Failed to load Helm chart in '%s': %s", dir, err)
+			continue
+		}
+		manifests, err := renderer.Render(target, chart)
+		if err != nil {
+			return nil, err
+		}
+		s.debug("Rendered %d templates from Helm chart '%s'", len(manifests), chart.Name)
+		for _, manifest := range manifests {
+			objects, err := s.parser.ParseReader(strings.NewReader(manifest.Content))
+			if err != nil {
+				s.debug("Failed to parse rendered template '%s': %s", manifest.Path, err)
+				continue
+			}
+			for _, object := range objects {
+				if object == nil {
+					continue
+				}
+				if content, ok := object.(map[string]interface{}); ok {
+					// the lines are those of the rendered output rather than the template
+					delete(content, "__defsec_metadata")
+				}
+				inputs = append(inputs, rego.Input{
+					Path:     manifest.Path,
+					Contents: object,
+					Type:     types.SourceKubernetes,
+				})
+			}
+		}
+	}
+	return inputs, nil
+}
+
+// buildKustomizations builds each kustomization which is not itself used by another kustomization, e.g. the
+// overlays rather than their bases. Each built object is mapped back to the resource file which defined it. The
+// files used by successful builds are returned so they are not also scanned in isolation.
+func (s *Scanner) buildKustomizations(target fs.FS, dir string, charts []string) ([]rego.Input, map[string]bool, error) {
+	dirs, err := kustomize.FindKustomizations(target, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	builder := kustomize.New(s.kustomizeOptions...)
+	built := make(map[string]*kustomize.Result)
+	used := make(map[string]bool)
+	for _, kustomizationDir := range dirs {
+		if isInChart(kustomizationDir+"/", charts) {
+			continue
+		}
+		result, err := builder.Build(target, kustomizationDir)
+		if err != nil {
+			s.debug("Failed to build kustomization in '%s': %s", kustomizationDir, err)
+			continue
+		}
+		built[kustomizationDir] = result
+		for _, other := range result.Kustomizations {
+			used[other] = true
+		}
+	}
+
+	var inputs []rego.Input
+	files := make(map[string]bool)
+	for _, kustomizationDir := range dirs {
+		result, ok := built[kustomizationDir]
+		if !ok {
+			continue
+		}
+		for _, file := range result.Files {
+			files[file] = true
+		}
+		if used[kustomizationDir] {
+			continue
+		}
+		s.debug("Built %d objects from kustomization '%s'", len(result.Objects), kustomizationDir)
+		for _, object := range result.Objects {
+			inputs = append(inputs, rego.Input{
+				Path:     object.Path,
+				Contents: object.Content,
+				Type:     types.SourceKubernetes,
+			})
+		}
+	}
+	return inputs, files, nil
+}
+
+func isInChart(path string, charts []string) bool {
+	for _, chart := range charts {
+		if chart == "." || strings.HasPrefix(path, chart+"/") {
+			return true
+		}
+	}
+	return false
+}
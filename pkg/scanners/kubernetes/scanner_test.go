@@ -352,3 +352,275 @@ spec:
 
 	assert.Equal(t, 1, len(results.GetFailed()))
 }
+
+func Test_HelmChartScan(t *testing.T) {
+
+	fs := testutil.CreateFS(t, map[string]string{
+		"/chart/Chart.yaml": `apiVersion: v2
+name: web
+version: 0.1.0
+`,
+		"/chart/values.yaml": `securityContext:
+  readOnlyRootFilesystem: false
+`,
+		"/chart/templates/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Release.Name }}-{{ .Chart.Name }}
+spec:
+  template:
+    spec:
+      containers:
+        - name: web
+          image: nginx
+          securityContext:
+            {{- toYaml .Values.securityContext | nindent 12 }}
+`,
+	})
+
+	failedPaths := func(results scan.Results) []string {
+		var paths []string
+		for _, result := range results.GetFailed() {
+			if result.Rule().AVDID == "AVD-KSV-0014" {
+				paths = append(paths, result.Range().GetFilename())
+			}
+		}
+		return paths
+	}
+
+	results, err := NewScanner().ScanFS(context.TODO(), fs, ".")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"chart/templates/deployment.yaml"}, failedPaths(results))
+
+	results, err = NewScanner(
+		OptionWithHelmValues("securityContext.readOnlyRootFilesystem=true"),
+	).ScanFS(context.TODO(), fs, ".")
+	require.NoError(t, err)
+	assert.Empty(t, failedPaths(results))
+}
+
+func Test_KustomizeOverlayScan(t *testing.T) {
+
+	fs := testutil.CreateFS(t, map[string]string{
+		"/base/kustomization.yaml": `resources:
+  - deployment.yaml
+`,
+		"/base/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      containers:
+        - name: web
+          image: nginx
+          securityContext:
+            readOnlyRootFilesystem: false
+`,
+		"/overlays/prod/kustomization.yaml": `resources:
+  - ../../base
+namePrefix: prod-
+patchesStrategicMerge:
+  - security.yaml
+`,
+		"/overlays/prod/security.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      containers:
+        - name: web
+          securityContext:
+            readOnlyRootFilesystem: true
+`,
+		"/overlays/debug/kustomization.yaml": `resources:
+  - ../../base
+patches:
+  - target:
+      kind: Deployment
+    patch: |-
+      - op: add
+        path: /spec/template/spec/containers/0/securityContext/privileged
+        value: true
+`,
+	})
+
+	failedPaths := func(results scan.Results, id string) []string {
+		var paths []string
+		for _, result := range results.GetFailed() {
+			if result.Rule().AVDID == id {
+				paths = append(paths, result.Range().GetFilename())
+			}
+		}
+		return paths
+	}
+
+	results, err := NewScanner().ScanFS(context.TODO(), fs, "overlays/prod")
+	require.NoError(t, err)
+	assert.Empty(t, failedPaths(results, "AVD-KSV-0014"))
+
+	results, err = NewScanner().ScanFS(context.TODO(), fs, ".")
+	require.NoError(t, err)
+	// the base is only scanned as part of each overlay, so only the debug overlay fails
+	assert.Equal(t, []string{"base/deployment.yaml"}, failedPaths(results, "AVD-KSV-0014"))
+	assert.Equal(t, []string{"base/deployment.yaml"}, failedPaths(results, "AVD-KSV-0017"))
+}
+
+func Test_TypedModelScan(t *testing.T) {
+
+	fs := testutil.CreateFS(t, map[string]string{
+		"/code/policy.yaml": `apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: egress
+spec:
+  podSelector: {}
+  egress:
+    - to:
+        - ipBlock:
+            cidr: 0.0.0.0/0
+`,
+		"/code/pod.yaml": `apiVersion: v1
+kind: Pod
+metadata:
+  name: debug
+spec:
+  hostNetwork: true
+  containers:
+    - name: debug
+      image: busybox
+`,
+		"/rules/host_network.rego": `package defsec.test
+
+__rego_metadata__ := {
+	"id": "TEST001",
+	"avd_id": "AVD-TEST-0001",
+	"title": "Host network",
+	"severity": "HIGH",
+}
+
+__rego_input__ := {
+	"selector": [{"type": "defsec"}],
+}
+
+deny[res] {
+	workload := input.kubernetes.workloads[_]
+	workload.template.spec.hostnetwork.value
+	res := {
+		"msg": "Workload uses the host network.",
+		"filepath": workload.template.spec.hostnetwork.filepath,
+	}
+}
+`,
+	})
+
+	results, err := NewScanner(
+		OptionWithPolicyDirs("rules"),
+		OptionWithPolicyNamespaces("defsec"),
+	).ScanFS(context.TODO(), fs, "code")
+	require.NoError(t, err)
+
+	failed := make(map[string]string)
+	for _, result := range results.GetFailed() {
+		failed[result.Rule().AVDID] = result.Range().GetFilename()
+	}
+	assert.Equal(t, map[string]string{
+		"AVD-KUBE-0002": "code/policy.yaml",
+		"AVD-TEST-0001": "code/pod.yaml",
+	}, failed)
+}
+
+func Test_MultiDocumentLines(t *testing.T) {
+
+	results, err := NewScanner().ScanReader(context.TODO(), "k8s.yaml", strings.NewReader(`---
+# secure
+apiVersion: v1
+kind: Pod
+metadata:
+  name: secure
+spec:
+  containers:
+    - name: app
+      image: busybox
+      securityContext:
+        privileged: false
+--- # privileged
+apiVersion: v1
+kind: Pod
+metadata:
+  name: privileged
+spec:
+  containers:
+    - name: app
+      image: busybox
+      securityContext:
+        privileged: true
+...
+`))
+	require.NoError(t, err)
+
+	var found bool
+	for _, result := range results.GetFailed() {
+		if result.Rule().AVDID != "AVD-KSV-0017" {
+			continue
+		}
+		found = true
+		assert.Equal(t, 14, result.Range().GetStartLine())
+		assert.Equal(t, 23, result.Range().GetEndLine())
+	}
+	assert.True(t, found)
+
+	for _, result := range results.GetPassed() {
+		if result.Rule().AVDID == "AVD-KSV-0017" {
+			assert.Equal(t, 3, result.Range().GetStartLine())
+			assert.Equal(t, 12, result.Range().GetEndLine())
+		}
+	}
+}
+
+func Test_InlineIgnores(t *testing.T) {
+
+	results, err := NewScanner().ScanReader(context.TODO(), "k8s.yaml", strings.NewReader(`---
+# defsec:ignore:KSV017 the node exporter needs access to the host
+apiVersion: v1
+kind: Pod
+metadata:
+  name: exporter
+spec:
+  containers:
+    - name: app
+      image: busybox
+      securityContext:
+        privileged: true
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: privileged
+spec:
+  containers:
+    - name: app
+      image: busybox
+      securityContext:
+        privileged: true
+`))
+	require.NoError(t, err)
+
+	var failed, ignored []int
+	for _, result := range results {
+		if result.Rule().AVDID != "AVD-KSV-0017" {
+			continue
+		}
+		switch result.Status() {
+		case scan.StatusFailed:
+			failed = append(failed, result.Range().GetStartLine())
+		case scan.StatusIgnored:
+			ignored = append(ignored, result.Range().GetStartLine())
+		}
+	}
+	assert.Equal(t, []int{3}, ignored)
+	assert.Equal(t, []int{14}, failed)
+}
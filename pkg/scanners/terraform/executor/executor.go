@@ -8,6 +8,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/aquasecurity/defsec/pkg/ignore"
 	"github.com/aquasecurity/defsec/pkg/terraform"
 
 	"github.com/aquasecurity/defsec/pkg/severity"
@@ -26,6 +27,8 @@ import (
 // Executor scans HCL blocks by running all registered rules against them
 type Executor struct {
 	enableIgnores             bool
+	suppressions              ignore.Suppressions
+	reportStaleIgnores        bool
 	excludedRuleIDs           []string
 	includedRuleIDs           []string
 	ignoreCheckErrors         bool
@@ -127,10 +130,15 @@ func (e *Executor) Execute(modules terraform.Modules) (scan.Results, Metrics, er
 	metrics.Timings.RunningChecks = time.Since(checksTime)
 	e.debug("Finished applying rules.")
 
+	var ignores, usedIgnores ignore.Directives
 	if e.enableIgnores {
-		var ignores terraform.Ignores
 		for _, module := range modules {
-			ignores = append(ignores, module.Ignores()...)
+			for _, moduleIgnore := range module.Ignores() {
+				// ignores scoped to other workspaces are neither applied nor reported as stale
+				if moduleIgnore.Workspace == "" || moduleIgnore.Workspace == e.workspaceName {
+					ignores = append(ignores, moduleIgnore)
+				}
+			}
 		}
 
 		for i, result := range results {
@@ -141,20 +149,33 @@ func (e *Executor) Execute(modules terraform.Modules) (scan.Results, Metrics, er
 			if e.alternativeIDProviderFunc != nil {
 				allIDs = append(allIDs, e.alternativeIDProviderFunc(result.Rule().LongID())...)
 			}
-			if ignores.Covering(
+			if covering := terraform.Ignores(ignores).Covering(
 				modules,
 				result.Metadata(),
 				e.workspaceName,
 				allIDs...,
-			) != nil {
+			); covering != nil {
 				e.debug("Ignored '%s' at '%s'.", result.Rule().LongID(), result.Range())
 				results[i].OverrideStatus(scan.StatusIgnored)
+				usedIgnores = append(usedIgnores, *covering)
 			}
 		}
 	}
 
+	results.ApplySuppressions(e.suppressions)
+
 	results = e.updateSeverity(results)
 	results = e.filterResults(results)
+	if e.reportStaleIgnores {
+		var known []string
+		for _, rule := range registeredRules {
+			known = append(known, rule.Rule().IDs()...)
+			if e.alternativeIDProviderFunc != nil {
+				known = append(known, e.alternativeIDProviderFunc(rule.Rule().LongID())...)
+			}
+		}
+		results.AddStaleIgnores(ignores, usedIgnores, known...)
+	}
 	metrics.Counts.Ignored = len(results.GetIgnored())
 	metrics.Counts.Passed = len(results.GetPassed())
 	metrics.Counts.Failed = len(results.GetFailed())
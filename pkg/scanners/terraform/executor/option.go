@@ -3,6 +3,7 @@ package executor
 import (
 	"io"
 
+	"github.com/aquasecurity/defsec/pkg/ignore"
 	"github.com/aquasecurity/defsec/pkg/state"
 
 	"github.com/aquasecurity/defsec/pkg/scan"
@@ -89,3 +90,18 @@ func OptionWithRegoOnly(regoOnly bool) Option {
 		e.regoOnly = regoOnly
 	}
 }
+
+// OptionWithSuppressions ignores results matching entries of a suppression file
+func OptionWithSuppressions(suppressions ignore.Suppressions) Option {
+	return func(s *Executor) {
+		s.suppressions = suppressions
+	}
+}
+
+// OptionReportStaleIgnores adds a result for each ignore which is expired, refers to an unknown rule or did not ignore
+// anything
+func OptionReportStaleIgnores(report bool) Option {
+	return func(s *Executor) {
+		s.reportStaleIgnores = report
+	}
+}
@@ -0,0 +1,219 @@
+// Package fixer applies the structured fixes declared by rules (see scan.Fix) to Terraform source files.
+package fixer
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+)
+
+// Patch is the fixed content of a single file
+type Patch struct {
+	Filename string
+	Original []byte
+	Fixed    []byte
+	// Results are the findings resolved by the patch
+	Results scan.Results
+}
+
+// Diff returns the patch as a unified diff
+func (p Patch) Diff() (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(p.Original),
+		B:        splitLines(p.Fixed),
+		FromFile: "a/" + p.Filename,
+		ToFile:   "b/" + p.Filename,
+		Context:  3,
+	})
+}
+
+// splitLines splits content after each newline. Unlike difflib.SplitLines, no empty line is added after a trailing
+// newline.
+func splitLines(content []byte) []string {
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// Fix returns a patch for each file in the filesystem containing failed results whose rules declare a Terraform fix
+// for the resource at fault. Files of downloaded modules are not modified.
+func Fix(fsys fs.FS, results scan.Results) ([]Patch, error) {
+	byFile := make(map[string]scan.Results)
+	var filenames []string
+	for _, result := range results {
+		if result.Status() != scan.StatusFailed || result.Range() == nil {
+			continue
+		}
+		if engine := result.Rule().Terraform; engine == nil || len(engine.Fixes) == 0 {
+			continue
+		}
+		filename := result.Range().GetLocalFilename()
+		if filepath.Ext(filename) != ".tf" || strings.Contains(filename, ".terraform/") {
+			continue
+		}
+		if _, ok := byFile[filename]; !ok {
+			filenames = append(filenames, filename)
+		}
+		byFile[filename] = append(byFile[filename], result)
+	}
+	sort.Strings(filenames)
+
+	var patches []Patch
+	for _, filename := range filenames {
+		original, err := fs.ReadFile(fsys, filename)
+		if err != nil {
+			continue
+		}
+		patch, err := fixFile(filename, original, byFile[filename])
+		if err != nil {
+			return nil, err
+		}
+		if patch != nil {
+			patches = append(patches, *patch)
+		}
+	}
+	return patches, nil
+}
+
+// Write rewrites the patched files, which are relative to the given directory
+func Write(dir string, patches []Patch) error {
+	for _, patch := range patches {
+		path := filepath.Join(dir, filepath.FromSlash(patch.Filename))
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, patch.Fixed, info.Mode()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type fixedBlock struct {
+	syntax *hclsyntax.Block
+	write  *hclwrite.Block
+	// applied records the rules whose fix has been applied, as several results may share a resource, e.g. with count
+	applied map[string]struct{}
+}
+
+func fixFile(filename string, original []byte, results scan.Results) (*Patch, error) {
+	syntaxFile, diags := hclsyntax.ParseConfig(original, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, diags)
+	}
+	writeFile, diags := hclwrite.ParseConfig(original, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, diags)
+	}
+	syntaxBlocks := syntaxFile.Body.(*hclsyntax.Body).Blocks
+	writeBlocks := writeFile.Body().Blocks()
+	if len(syntaxBlocks) != len(writeBlocks) {
+		return nil, fmt.Errorf("failed to match the blocks of %s", filename)
+	}
+
+	patch := Patch{
+		Filename: filename,
+		Original: original,
+	}
+	fixed := make(map[int]*fixedBlock)
+	for _, result := range results {
+		index := blockAt(syntaxBlocks, result.Range().GetStartLine())
+		if index < 0 {
+			continue
+		}
+		fix := fixFor(result.Rule().Terraform.Fixes, writeBlocks[index])
+		if fix == nil {
+			continue
+		}
+		block, ok := fixed[index]
+		if !ok {
+			block = &fixedBlock{
+				syntax:  syntaxBlocks[index],
+				write:   writeBlocks[index],
+				applied: make(map[string]struct{}),
+			}
+			fixed[index] = block
+		}
+		if _, ok := block.applied[result.Rule().LongID()]; !ok {
+			for _, operation := range fix.Operations {
+				if err := apply(block.write.Body(), operation); err != nil {
+					return nil, fmt.Errorf("failed to fix %s in %s: %w", result.Rule().LongID(), filename, err)
+				}
+			}
+			block.applied[result.Rule().LongID()] = struct{}{}
+		}
+		patch.Results = append(patch.Results, result)
+	}
+	if len(fixed) == 0 {
+		return nil, nil
+	}
+
+	patch.Fixed = splice(original, fixed)
+	return &patch, nil
+}
+
+// blockAt returns the index of the top level block containing the given line
+func blockAt(blocks hclsyntax.Blocks, line int) int {
+	for i, block := range blocks {
+		if block.Range().Start.Line <= line && line <= block.Range().End.Line {
+			return i
+		}
+	}
+	return -1
+}
+
+func fixFor(fixes []scan.Fix, block *hclwrite.Block) *scan.Fix {
+	var resourceType string
+	if block.Type() == "resource" && len(block.Labels()) > 0 {
+		resourceType = block.Labels()[0]
+	}
+	for i, fix := range fixes {
+		if fix.ResourceType == "" || fix.ResourceType == resourceType {
+			return &fixes[i]
+		}
+	}
+	return nil
+}
+
+// splice replaces the source of each fixed block with its new content, formatted as by terraform fmt, leaving the
+// rest of the file untouched
+func splice(original []byte, fixed map[int]*fixedBlock) []byte {
+	var indexes []int
+	for index := range fixed {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	var output []byte
+	var offset int
+	for _, index := range indexes {
+		block := fixed[index]
+		rng := block.syntax.Range()
+		output = append(output, original[offset:rng.Start.Byte]...)
+		content := hclwrite.Format(withoutLeadComments(block.write.BuildTokens(nil)).Bytes())
+		output = append(output, strings.TrimRight(string(content), "\n")...)
+		offset = rng.End.Byte
+	}
+	return append(output, original[offset:]...)
+}
+
+// withoutLeadComments drops the comments preceding a block, which are not part of its hclsyntax range
+func withoutLeadComments(tokens hclwrite.Tokens) hclwrite.Tokens {
+	for len(tokens) > 0 && tokens[0].Type == hclsyntax.TokenComment {
+		tokens = tokens[1:]
+	}
+	return tokens
+}
@@ -0,0 +1,134 @@
+package fixer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/terraform"
+	"github.com/aquasecurity/defsec/test/testutil"
+)
+
+func fixSource(t *testing.T, source string) []Patch {
+	fsys := testutil.CreateFS(t, map[string]string{
+		"project/main.tf": source,
+	})
+	results, err := terraform.New().ScanFS(context.TODO(), fsys, "project")
+	require.NoError(t, err)
+
+	patches, err := Fix(fsys, results)
+	require.NoError(t, err)
+	return patches
+}
+
+func Test_FixSetsNestedAttributes(t *testing.T) {
+	patches := fixSource(t, `
+# the bucket
+resource "aws_instance" "example" {
+  ami = "ami-12345"
+}
+`)
+	require.Len(t, patches, 1)
+	assert.Equal(t, "project/main.tf", patches[0].Filename)
+	assert.Equal(t, `
+# the bucket
+resource "aws_instance" "example" {
+  ami = "ami-12345"
+  metadata_options {
+    http_tokens = "required"
+  }
+}
+`, string(patches[0].Fixed))
+}
+
+func Test_FixRemovesAttributesAndLeavesOtherBlocksUntouched(t *testing.T) {
+	patches := fixSource(t, `
+variable "ami"    {
+    default = "ami-12345"
+}
+
+resource "aws_launch_configuration" "example" {
+  image_id      = var.ami
+  associate_public_ip_address = true
+}
+`)
+	require.Len(t, patches, 1)
+	assert.Equal(t, `
+variable "ami"    {
+    default = "ami-12345"
+}
+
+resource "aws_launch_configuration" "example" {
+  image_id = var.ami
+}
+`, string(patches[0].Fixed))
+}
+
+func Test_FixAppliesEachRuleOncePerBlock(t *testing.T) {
+	patches := fixSource(t, `
+resource "aws_s3_bucket" "example" {
+  bucket = "example"
+}
+
+resource "aws_s3_bucket_public_access_block" "example" {
+  count  = 2
+  bucket = aws_s3_bucket.example.id
+}
+`)
+	require.Len(t, patches, 1)
+	assert.Contains(t, string(patches[0].Fixed), `
+resource "aws_s3_bucket_public_access_block" "example" {
+  count                   = 2
+  bucket                  = aws_s3_bucket.example.id
+  block_public_acls       = true
+  block_public_policy     = true
+  ignore_public_acls      = true
+  restrict_public_buckets = true
+}
+`)
+}
+
+func Test_FixIgnoresPassedAndUnfixableResults(t *testing.T) {
+	patches := fixSource(t, `
+resource "aws_instance" "example" {
+  metadata_options {
+    http_tokens = "required"
+  }
+}
+`)
+	assert.Empty(t, patches)
+}
+
+func Test_PatchDiff(t *testing.T) {
+	patch := Patch{
+		Filename: "main.tf",
+		Original: []byte("a\nb\n"),
+		Fixed:    []byte("a\nc\n"),
+	}
+	diff, err := patch.Diff()
+	require.NoError(t, err)
+	assert.Equal(t, `--- a/main.tf
++++ b/main.tf
+@@ -1,2 +1,2 @@
+ a
+-b
++c
+`, diff)
+}
+
+func Test_Write(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "modules"), 0o755))
+	path := filepath.Join(dir, "modules", "main.tf")
+	require.NoError(t, os.WriteFile(path, []byte("original\n"), 0o600))
+
+	require.NoError(t, Write(dir, []Patch{{Filename: "modules/main.tf", Fixed: []byte("fixed\n")}}))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "fixed\n", string(content))
+}
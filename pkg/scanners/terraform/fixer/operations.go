@@ -0,0 +1,86 @@
+package fixer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+)
+
+func apply(body *hclwrite.Body, operation scan.FixOperation) error {
+	parts := strings.Split(operation.Path, ".")
+	name := parts[len(parts)-1]
+	if name == "" {
+		return fmt.Errorf("invalid path '%s'", operation.Path)
+	}
+	parents := parts[:len(parts)-1]
+
+	switch operation.Action {
+	case scan.FixSetAttribute:
+		tokens, err := expressionTokens(operation.Value)
+		if err != nil {
+			return err
+		}
+		getOrAddBody(body, parents).SetAttributeRaw(name, tokens)
+	case scan.FixAddBlock:
+		parent := getOrAddBody(body, parents)
+		if parent.FirstMatchingBlock(name, nil) != nil {
+			return nil
+		}
+		block, err := parseBlock(name, operation.Body)
+		if err != nil {
+			return err
+		}
+		parent.AppendBlock(block)
+	case scan.FixRemoveAttribute:
+		if parent := getBody(body, parents); parent != nil {
+			parent.RemoveAttribute(name)
+		}
+	default:
+		return fmt.Errorf("unsupported fix action '%s'", operation.Action)
+	}
+	return nil
+}
+
+// getBody returns the body of the nested block at the given path, or nil if it does not exist
+func getBody(body *hclwrite.Body, path []string) *hclwrite.Body {
+	for _, name := range path {
+		block := body.FirstMatchingBlock(name, nil)
+		if block == nil {
+			return nil
+		}
+		body = block.Body()
+	}
+	return body
+}
+
+// getOrAddBody returns the body of the nested block at the given path, adding any blocks which do not exist
+func getOrAddBody(body *hclwrite.Body, path []string) *hclwrite.Body {
+	for _, name := range path {
+		block := body.FirstMatchingBlock(name, nil)
+		if block == nil {
+			block = body.AppendNewBlock(name, nil)
+		}
+		body = block.Body()
+	}
+	return body
+}
+
+func expressionTokens(value string) (hclwrite.Tokens, error) {
+	file, diags := hclwrite.ParseConfig([]byte("value = "+value+"\n"), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("invalid expression '%s': %w", value, diags)
+	}
+	return file.Body().GetAttribute("value").Expr().BuildTokens(nil), nil
+}
+
+func parseBlock(name string, content string) (*hclwrite.Block, error) {
+	file, diags := hclwrite.ParseConfig([]byte(name+" {\n"+content+"\n}\n"), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("invalid content for block '%s': %w", name, diags)
+	}
+	return file.Body().Blocks()[0], nil
+}
@@ -0,0 +1,86 @@
+package fixer
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+)
+
+func Test_Apply(t *testing.T) {
+	tests := []struct {
+		name      string
+		source    string
+		operation scan.FixOperation
+		expected  string
+		wantErr   bool
+	}{
+		{
+			name:      "set attribute",
+			source:    "enabled = false\n",
+			operation: scan.FixOperation{Action: scan.FixSetAttribute, Path: "enabled", Value: "true"},
+			expected:  "enabled = true\n",
+		},
+		{
+			name:      "set attribute in existing block",
+			source:    "logging {\n  level = \"info\"\n}\n",
+			operation: scan.FixOperation{Action: scan.FixSetAttribute, Path: "logging.enabled", Value: "true"},
+			expected:  "logging {\n  level   = \"info\"\n  enabled = true\n}\n",
+		},
+		{
+			name:      "add block",
+			source:    "name = \"example\"\n",
+			operation: scan.FixOperation{Action: scan.FixAddBlock, Path: "logging", Body: "enabled = true"},
+			expected:  "name = \"example\"\nlogging {\n  enabled = true\n}\n",
+		},
+		{
+			name:      "add block which already exists",
+			source:    "logging {\n  enabled = false\n}\n",
+			operation: scan.FixOperation{Action: scan.FixAddBlock, Path: "logging", Body: "enabled = true"},
+			expected:  "logging {\n  enabled = false\n}\n",
+		},
+		{
+			name:      "remove attribute",
+			source:    "name   = \"example\"\npublic = true\n",
+			operation: scan.FixOperation{Action: scan.FixRemoveAttribute, Path: "public"},
+			expected:  "name = \"example\"\n",
+		},
+		{
+			name:      "remove attribute from missing block",
+			source:    "name = \"example\"\n",
+			operation: scan.FixOperation{Action: scan.FixRemoveAttribute, Path: "logging.enabled"},
+			expected:  "name = \"example\"\n",
+		},
+		{
+			name:      "invalid expression",
+			source:    "name = \"example\"\n",
+			operation: scan.FixOperation{Action: scan.FixSetAttribute, Path: "name", Value: "\"unterminated"},
+			wantErr:   true,
+		},
+		{
+			name:      "unsupported action",
+			source:    "name = \"example\"\n",
+			operation: scan.FixOperation{Action: "rename", Path: "name"},
+			wantErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file, diags := hclwrite.ParseConfig([]byte(test.source), "test.tf", hcl.InitialPos)
+			require.False(t, diags.HasErrors())
+
+			err := apply(file.Body(), test.operation)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, string(hclwrite.Format(file.Bytes())))
+		})
+	}
+}
@@ -2,8 +2,10 @@ package terraform
 
 import (
 	"io"
+	"io/fs"
 	"strings"
 
+	"github.com/aquasecurity/defsec/pkg/ignore"
 	"github.com/aquasecurity/defsec/pkg/scanners/terraform/parser"
 
 	"github.com/aquasecurity/defsec/pkg/severity"
@@ -128,11 +130,11 @@ func OptionWithResultsFilter(f func(scan.Results) scan.Results) Option {
 }
 
 func OptionWithMinimumSeverity(minimum severity.Severity) Option {
-	min := severityAsOrdinal(minimum)
+	min := minimum.Ordinal()
 	return func(s *Scanner) {
 		s.executorOpt = append(s.executorOpt, executor.OptionWithResultsFilter(func(results scan.Results) scan.Results {
 			for i, result := range results {
-				if severityAsOrdinal(result.Severity()) < min {
+				if result.Severity().Ordinal() < min {
 					results[i].OverrideStatus(scan.StatusIgnored)
 				}
 			}
@@ -148,6 +150,13 @@ func OptionWithPolicyDirs(dirs ...string) func(s *Scanner) {
 	}
 }
 
+// OptionWithPolicyFilesystem - filesystem to load policy directories from, if it differs from the scanned filesystem
+func OptionWithPolicyFilesystem(policyFS fs.FS) func(s *Scanner) {
+	return func(s *Scanner) {
+		s.policyFS = policyFS
+	}
+}
+
 // OptionWithDataDirs - location of rego data directories
 func OptionWithDataDirs(dirs ...string) func(s *Scanner) {
 	return func(s *Scanner) {
@@ -162,21 +171,6 @@ func OptionWithPolicyNamespaces(namespaces ...string) func(s *Scanner) {
 	}
 }
 
-func severityAsOrdinal(sev severity.Severity) int {
-	switch sev {
-	case severity.Critical:
-		return 4
-	case severity.High:
-		return 3
-	case severity.Medium:
-		return 2
-	case severity.Low:
-		return 1
-	default:
-		return 0
-	}
-}
-
 func OptionWithStateFunc(f ...func(*state.State)) Option {
 	return func(s *Scanner) {
 		s.executorOpt = append(s.executorOpt, executor.OptionWithStateFunc(f...))
@@ -200,3 +194,18 @@ func OptionWithPerResultTracing() Option {
 		s.traceWriter = io.Discard
 	}
 }
+
+// OptionWithSuppressions - ignore results matching entries of a suppression file, e.g. .defsecignore
+func OptionWithSuppressions(suppressions ignore.Suppressions) Option {
+	return func(s *Scanner) {
+		s.executorOpt = append(s.executorOpt, executor.OptionWithSuppressions(suppressions))
+	}
+}
+
+// OptionReportStaleIgnores - add a result for each ignore which is expired, refers to an unknown rule or did not ignore
+// anything
+func OptionReportStaleIgnores(report bool) Option {
+	return func(s *Scanner) {
+		s.executorOpt = append(s.executorOpt, executor.OptionReportStaleIgnores(report))
+	}
+}
@@ -36,6 +36,7 @@ type Scanner struct {
 	policyDirs       []string
 	dataDirs         []string
 	policyNamespaces []string
+	policyFS         fs.FS
 	regoScanner      *rego.Scanner
 	execLock         sync.RWMutex
 	sync.Mutex
@@ -87,6 +88,9 @@ func (s *Scanner) initRegoScanner(srcFS fs.FS) (*rego.Scanner, error) {
 	if s.traceWriter != nil {
 		regoOpts = append(regoOpts, rego.OptionWithTrace(s.traceWriter))
 	}
+	if s.policyFS != nil {
+		srcFS = s.policyFS
+	}
 	regoScanner := rego.NewScanner(regoOpts...)
 	if err := regoScanner.LoadPolicies(true, srcFS, s.policyDirs, nil); err != nil {
 		return nil, err
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/aquasecurity/defsec/pkg/ignore"
 	"github.com/aquasecurity/defsec/pkg/terraform"
 
 	"github.com/aquasecurity/defsec/pkg/severity"
@@ -118,6 +119,68 @@ resource "something" "else" {}
 
 }
 
+func Test_OptionWithSuppressions(t *testing.T) {
+	reg := rules.Register(alwaysFailRule, nil)
+	defer rules.Deregister(reg)
+
+	suppressions, err := ignore.ParseSuppressions([]byte(`
+suppressions:
+  - id: aws-service-*
+    path: "project/**/*.tf"
+    resource: something.legacy
+    reason: replaced next quarter
+    owner: platform-team
+    expires: 2999-01-01
+`), ".defsecignore")
+	require.NoError(t, err)
+
+	results := scanWithOptions(t, `
+resource "something" "legacy" {}
+resource "something" "else" {}
+`, OptionWithSuppressions(suppressions))
+	require.Len(t, results.GetFailed(), 1)
+	require.Len(t, results.GetIgnored(), 1)
+
+	ignored := results.GetIgnored()[0]
+	require.NotNil(t, ignored.Suppression())
+	assert.Equal(t, ".defsecignore:3", ignored.Suppression().Source)
+	assert.Equal(t, "platform-team", ignored.Flatten().Suppression.Owner)
+}
+
+func Test_OptionReportStaleIgnores(t *testing.T) {
+	reg := rules.Register(alwaysFailRule, nil)
+	defer rules.Deregister(reg)
+
+	results := scanWithOptions(t, `
+#tfsec:ignore:aws-service-abc
+resource "something" "used" {}
+
+#tfsec:ignore:aws-service-abc:exp:2000-01-01
+resource "something" "expired" {}
+
+#tfsec:ignore:aws-service-xyz
+resource "something" "unknown" {}
+
+#tfsec:ignore:aws-service-abc:ws:other
+resource "something" "workspace" {}
+
+#tfsec:ignore:aws-service-abc
+`, OptionReportStaleIgnores(true))
+	require.Len(t, results.GetIgnored(), 1)
+	require.Len(t, results.GetFailed(), 3)
+
+	reasons := make(map[int]ignore.StaleReason)
+	for _, result := range results.GetStaleIgnores() {
+		assert.Equal(t, scan.StaleIgnoreRule, result.Rule())
+		reasons[result.Range().GetStartLine()] = result.StaleIgnore().Reason
+	}
+	assert.Equal(t, map[int]ignore.StaleReason{
+		5:  ignore.StaleExpired,
+		8:  ignore.StaleUnknownRule,
+		14: ignore.StaleUnused,
+	}, reasons)
+}
+
 func Test_OptionExcludeRules(t *testing.T) {
 	reg := rules.Register(alwaysFailRule, nil)
 	defer rules.Deregister(reg)
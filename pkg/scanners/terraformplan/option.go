@@ -0,0 +1,34 @@
+package terraformplan
+
+import (
+	"io"
+
+	"github.com/aquasecurity/defsec/pkg/ignore"
+	"github.com/aquasecurity/defsec/pkg/scanners/terraform"
+	"github.com/aquasecurity/defsec/pkg/scanners/terraformplan/parser"
+)
+
+type Option func(s *Scanner)
+
+// OptionWithDebug - pass the scanner an io.Writer to log debug messages to
+func OptionWithDebug(debugWriter io.Writer) Option {
+	return func(s *Scanner) {
+		s.debugWriter = debugWriter
+		s.parserOpts = append(s.parserOpts, parser.OptionWithDebugWriter(debugWriter))
+		s.terraformOpts = append(s.terraformOpts, terraform.OptionWithDebug(debugWriter))
+	}
+}
+
+// OptionWithTerraformOptions - options for the terraform scanner which is used to scan the reconstructed plan
+func OptionWithTerraformOptions(options ...terraform.Option) Option {
+	return func(s *Scanner) {
+		s.terraformOpts = append(s.terraformOpts, options...)
+	}
+}
+
+// OptionWithSuppressions - ignore results matching entries of a suppression file, e.g. .defsecignore
+func OptionWithSuppressions(suppressions ignore.Suppressions) Option {
+	return func(s *Scanner) {
+		s.suppressions = suppressions
+	}
+}
@@ -0,0 +1,200 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type hclFile struct {
+	file *hclwrite.File
+}
+
+func newHCLFile() *hclFile {
+	return &hclFile{
+		file: hclwrite.NewEmptyFile(),
+	}
+}
+
+func (f *hclFile) Bytes() []byte {
+	return f.file.Bytes()
+}
+
+func (f *hclFile) addModule(label string, source string) {
+	body := f.file.Body()
+	if len(body.Blocks()) > 0 {
+		body.AppendNewline()
+	}
+	block := body.AppendNewBlock("module", []string{label})
+	block.Body().SetAttributeValue("source", cty.StringVal(source))
+}
+
+func (f *hclFile) addResource(resource PlanResource, config *ConfigResource) {
+	blockType := "resource"
+	if resource.Mode == "data" {
+		blockType = "data"
+	}
+	body := f.file.Body()
+	if len(body.Blocks()) > 0 {
+		body.AppendNewline()
+	}
+	block := body.AppendNewBlock(blockType, []string{resource.Type, resourceLabel(resource)})
+	var expressions map[string]interface{}
+	if config != nil {
+		expressions = config.Expressions
+	}
+	writeBody(block.Body(), resource.Values, expressions)
+}
+
+// resourceLabel returns the name label for a resource instance, including the count/for_each key in the same
+// format used for expanded blocks when parsing HCL directly
+func resourceLabel(resource PlanResource) string {
+	switch index := resource.Index.(type) {
+	case nil:
+		return resource.Name
+	case json.Number:
+		return fmt.Sprintf("%s[%s]", resource.Name, index.String())
+	case string:
+		return fmt.Sprintf("%s[%q]", resource.Name, index)
+	default:
+		return fmt.Sprintf("%s[%v]", resource.Name, index)
+	}
+}
+
+func writeBody(body *hclwrite.Body, values map[string]interface{}, expressions map[string]interface{}) {
+
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	for key := range expressions {
+		if _, ok := values[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := values[key]
+		expression := expressions[key]
+
+		// values which are only known after apply are missing from the plan, but references to other resources
+		// are retained in the configuration, so we write the reference out instead
+		if value == nil {
+			if traversal := findReference(expression); traversal != nil {
+				body.SetAttributeRaw(key, hclwrite.TokensForTraversal(traversal))
+				continue
+			}
+		}
+
+		if blocks, ok := asBlocks(value); ok {
+			blockExpressions, _ := expression.([]interface{})
+			for i, blockValues := range blocks {
+				var nested map[string]interface{}
+				if i < len(blockExpressions) {
+					nested, _ = blockExpressions[i].(map[string]interface{})
+				}
+				writeBody(body.AppendNewBlock(key, nil).Body(), blockValues, nested)
+			}
+			continue
+		}
+
+		// empty lists cannot be told apart from absent nested blocks, so treat them as unset
+		if list, ok := value.([]interface{}); value == nil || (ok && len(list) == 0) {
+			continue
+		}
+
+		body.SetAttributeValue(key, toCty(value))
+	}
+}
+
+// asBlocks returns the input as a list of nested blocks if it is a non-empty list of objects
+func asBlocks(value interface{}) ([]map[string]interface{}, bool) {
+	list, ok := value.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil, false
+	}
+	var blocks []map[string]interface{}
+	for _, item := range list {
+		block, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, true
+}
+
+// findReference returns the first reference in a configuration expression which points at a resource or data source
+func findReference(expression interface{}) hcl.Traversal {
+	msi, ok := expression.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	references, ok := msi["references"].([]interface{})
+	if !ok {
+		return nil
+	}
+	for _, raw := range references {
+		reference, ok := raw.(string)
+		if !ok {
+			continue
+		}
+		switch strings.Split(reference, ".")[0] {
+		case "var", "local", "module", "each", "count", "path", "terraform", "self":
+			continue
+		}
+		traversal, diags := hclsyntax.ParseTraversalAbs([]byte(reference), "", hcl.InitialPos)
+		if diags.HasErrors() || len(traversal) < 2 {
+			continue
+		}
+		return traversal
+	}
+	return nil
+}
+
+func toCty(value interface{}) cty.Value {
+	switch typed := value.(type) {
+	case nil:
+		return cty.NullVal(cty.DynamicPseudoType)
+	case string:
+		return cty.StringVal(typed)
+	case bool:
+		return cty.BoolVal(typed)
+	case json.Number:
+		if number, err := cty.ParseNumberVal(typed.String()); err == nil {
+			return number
+		}
+		return cty.StringVal(typed.String())
+	case int64:
+		return cty.NumberIntVal(typed)
+	case float64:
+		return cty.NumberFloatVal(typed)
+	case []interface{}:
+		if len(typed) == 0 {
+			return cty.EmptyTupleVal
+		}
+		var items []cty.Value
+		for _, item := range typed {
+			items = append(items, toCty(item))
+		}
+		return cty.TupleVal(items)
+	case map[string]interface{}:
+		if len(typed) == 0 {
+			return cty.EmptyObjectVal
+		}
+		items := make(map[string]cty.Value)
+		for key, item := range typed {
+			items[key] = toCty(item)
+		}
+		return cty.ObjectVal(items)
+	default:
+		return cty.StringVal(fmt.Sprintf("%v", typed))
+	}
+}
@@ -0,0 +1,133 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/liamg/memoryfs"
+)
+
+type Parser struct {
+	debugWriter io.Writer
+}
+
+type Option func(p *Parser)
+
+func OptionWithDebugWriter(w io.Writer) Option {
+	return func(p *Parser) {
+		p.debugWriter = w
+	}
+}
+
+// New creates a new parser
+func New(options ...Option) *Parser {
+	p := &Parser{}
+	for _, option := range options {
+		option(p)
+	}
+	return p
+}
+
+func (p *Parser) debug(format string, args ...interface{}) {
+	if p.debugWriter == nil {
+		return
+	}
+	prefix := "[debug:parse:terraformplan] "
+	_, _ = p.debugWriter.Write([]byte(fmt.Sprintf(prefix+format+"\n", args...)))
+}
+
+// ParseFile parses the plan JSON (as produced by `terraform show -json`) at the given path
+func (p *Parser) ParseFile(filesystem fs.FS, path string) (*PlanFile, error) {
+	f, err := filesystem.Open(filepath.ToSlash(path))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return p.Parse(f)
+}
+
+// Parse parses plan JSON from the provided reader
+func (p *Parser) Parse(reader io.Reader) (*PlanFile, error) {
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+	var planFile PlanFile
+	if err := decoder.Decode(&planFile); err != nil {
+		return nil, err
+	}
+	if planFile.FormatVersion == "" || planFile.PlannedValues == nil {
+		return nil, fmt.Errorf("input is not a terraform plan")
+	}
+	p.debug("Parsed plan (format version %s, terraform version %s).", planFile.FormatVersion, planFile.TerraformVersion)
+	return &planFile, nil
+}
+
+// Required returns true if the file at the given path could contain plan JSON
+func (p *Parser) Required(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".json")
+}
+
+// ToFS reconstructs the planned resources as HCL in an in-memory filesystem, with the module hierarchy preserved.
+// The root module is written to main.tf at the root of the filesystem.
+func (p *PlanFile) ToFS() (*memoryfs.FS, error) {
+	memfs := memoryfs.New()
+	var config *ConfigModule
+	if p.Configuration != nil {
+		config = &p.Configuration.RootModule
+	}
+	if err := writeModule(memfs, ".", p.PlannedValues.RootModule, config); err != nil {
+		return nil, err
+	}
+	return memfs, nil
+}
+
+func writeModule(memfs *memoryfs.FS, dir string, module PlanModule, config *ConfigModule) error {
+
+	file := newHCLFile()
+
+	for _, resource := range module.Resources {
+		file.addResource(resource, config.findResource(resource.Mode, resource.Type, resource.Name))
+	}
+
+	for _, child := range module.ChildModules {
+		label, callName := moduleLabel(child.Address)
+		childDir := path.Join(dir, "modules", sanitiseDirName(label))
+		file.addModule(label, "./"+path.Join("modules", sanitiseDirName(label)))
+		if err := writeModule(memfs, childDir, child, config.findModule(callName)); err != nil {
+			return err
+		}
+	}
+
+	if err := memfs.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	return memfs.WriteFile(path.Join(dir, "main.tf"), file.Bytes(), 0o600)
+}
+
+// moduleLabel returns the label for a module instance (e.g. "x[0]") and the name of the module call (e.g. "x")
+func moduleLabel(address string) (string, string) {
+	label := address
+	if index := strings.LastIndex(address, "module."); index >= 0 {
+		label = address[index+len("module."):]
+	}
+	callName := label
+	if index := strings.Index(label, "["); index >= 0 {
+		callName = label[:index]
+	}
+	return label, callName
+}
+
+func sanitiseDirName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
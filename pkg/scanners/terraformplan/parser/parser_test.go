@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PlanToFS(t *testing.T) {
+
+	input := `{
+  "format_version": "1.0",
+  "planned_values": {
+    "root_module": {
+      "resources": [
+        {
+          "address": "aws_instance.web[\"a\"]",
+          "mode": "managed",
+          "type": "aws_instance",
+          "name": "web",
+          "index": "a",
+          "values": {
+            "ami": "ami-123456",
+            "key_name": "deployer",
+            "metadata_options": [{"http_tokens": "required"}],
+            "ebs_block_device": [],
+            "user_data": null
+          }
+        }
+      ]
+    }
+  },
+  "configuration": {
+    "root_module": {
+      "resources": [
+        {
+          "address": "aws_instance.web",
+          "mode": "managed",
+          "type": "aws_instance",
+          "name": "web",
+          "expressions": {
+            "ami": {"constant_value": "ami-123456"},
+            "key_name": {"references": ["aws_key_pair.deployer.key_name", "aws_key_pair.deployer"]},
+            "subnet_id": {"references": ["var.subnet", "aws_subnet.main.id", "aws_subnet.main"]}
+          }
+        }
+      ]
+    }
+  }
+}`
+
+	plan, err := New().Parse(strings.NewReader(input))
+	require.NoError(t, err)
+
+	memfs, err := plan.ToFS()
+	require.NoError(t, err)
+
+	data, err := memfs.ReadFile("main.tf")
+	require.NoError(t, err)
+
+	assert.Equal(t, `resource "aws_instance" "web[\"a\"]" {
+  ami      = "ami-123456"
+  key_name = "deployer"
+  metadata_options {
+    http_tokens = "required"
+  }
+  subnet_id = aws_subnet.main.id
+}
+`, string(data))
+}
+
+func Test_ParseRejectsNonPlan(t *testing.T) {
+	_, err := New().Parse(strings.NewReader(`{"resources": []}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,72 @@
+package parser
+
+// PlanFile is the subset of the `terraform show -json` output which is required to reconstruct the planned resources
+type PlanFile struct {
+	FormatVersion    string         `json:"format_version"`
+	TerraformVersion string         `json:"terraform_version"`
+	PlannedValues    *PlannedValues `json:"planned_values"`
+	Configuration    *Configuration `json:"configuration"`
+}
+
+type PlannedValues struct {
+	RootModule PlanModule `json:"root_module"`
+}
+
+type PlanModule struct {
+	Address      string         `json:"address"`
+	Resources    []PlanResource `json:"resources"`
+	ChildModules []PlanModule   `json:"child_modules"`
+}
+
+type PlanResource struct {
+	Address string                 `json:"address"`
+	Mode    string                 `json:"mode"`
+	Type    string                 `json:"type"`
+	Name    string                 `json:"name"`
+	Index   interface{}            `json:"index"`
+	Values  map[string]interface{} `json:"values"`
+}
+
+type Configuration struct {
+	RootModule ConfigModule `json:"root_module"`
+}
+
+type ConfigModule struct {
+	Resources   []ConfigResource      `json:"resources"`
+	ModuleCalls map[string]ModuleCall `json:"module_calls"`
+}
+
+type ConfigResource struct {
+	Address     string                 `json:"address"`
+	Mode        string                 `json:"mode"`
+	Type        string                 `json:"type"`
+	Name        string                 `json:"name"`
+	Expressions map[string]interface{} `json:"expressions"`
+}
+
+type ModuleCall struct {
+	Source string       `json:"source"`
+	Module ConfigModule `json:"module"`
+}
+
+func (c *ConfigModule) findResource(mode string, resourceType string, name string) *ConfigResource {
+	if c == nil {
+		return nil
+	}
+	for i, resource := range c.Resources {
+		if resource.Mode == mode && resource.Type == resourceType && resource.Name == name {
+			return &c.Resources[i]
+		}
+	}
+	return nil
+}
+
+func (c *ConfigModule) findModule(name string) *ConfigModule {
+	if c == nil {
+		return nil
+	}
+	if call, ok := c.ModuleCalls[name]; ok {
+		return &call.Module
+	}
+	return nil
+}
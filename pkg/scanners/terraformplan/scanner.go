@@ -0,0 +1,101 @@
+package terraformplan
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/aquasecurity/defsec/pkg/ignore"
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/scanners"
+	"github.com/aquasecurity/defsec/pkg/scanners/terraform"
+	"github.com/aquasecurity/defsec/pkg/scanners/terraformplan/parser"
+)
+
+var _ scanners.Scanner = (*Scanner)(nil)
+
+// Scanner scans the JSON output of `terraform show -json <planfile>`. The planned values are reconstructed as
+// HCL and scanned with the terraform scanner, so checks see values which are only resolved during planning.
+type Scanner struct {
+	debugWriter   io.Writer
+	parserOpts    []parser.Option
+	terraformOpts []terraform.Option
+	suppressions  ignore.Suppressions
+}
+
+func New(options ...Option) *Scanner {
+	s := &Scanner{}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+func (s *Scanner) debug(format string, args ...interface{}) {
+	if s.debugWriter == nil {
+		return
+	}
+	prefix := "[debug:scan:terraformplan] "
+	_, _ = s.debugWriter.Write([]byte(fmt.Sprintf(prefix+format+"\n", args...)))
+}
+
+func (s *Scanner) ScanFS(ctx context.Context, target fs.FS, dir string) (scan.Results, error) {
+	p := parser.New(s.parserOpts...)
+	var results scan.Results
+	if err := fs.WalkDir(target, filepath.ToSlash(dir), func(path string, entry fs.DirEntry, err error) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !p.Required(path) {
+			return nil
+		}
+		planFile, err := p.ParseFile(target, path)
+		if err != nil {
+			// not every JSON file is a plan
+			return nil
+		}
+		planResults, err := s.scanPlan(ctx, target, path, planFile)
+		if err != nil {
+			return err
+		}
+		results = append(results, planResults...)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ScanFile scans a single plan file
+func (s *Scanner) ScanFile(ctx context.Context, target fs.FS, path string) (scan.Results, error) {
+	planFile, err := parser.New(s.parserOpts...).ParseFile(target, path)
+	if err != nil {
+		return nil, err
+	}
+	return s.scanPlan(ctx, target, path, planFile)
+}
+
+func (s *Scanner) scanPlan(ctx context.Context, target fs.FS, path string, planFile *parser.PlanFile) (scan.Results, error) {
+	s.debug("Scanning plan %s...", path)
+	planFS, err := planFile.ToFS()
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstruct plan %s: %w", path, err)
+	}
+	options := append([]terraform.Option{terraform.OptionWithPolicyFilesystem(target)}, s.terraformOpts...)
+	// suppressions are matched against the plan file rather than the reconstructed HCL
+	options = append(options, terraform.OptionWithSuppressions(nil))
+	results, err := terraform.New(options...).ScanFS(ctx, planFS, ".")
+	if err != nil {
+		return nil, err
+	}
+	results.SetSourceAndFilesystem(filepath.ToSlash(path), planFS)
+	results.ApplySuppressions(s.suppressions)
+	return results, nil
+}
@@ -0,0 +1,51 @@
+package terraformplan
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ScanPlanFile(t *testing.T) {
+
+	results, err := New().ScanFS(context.TODO(), os.DirFS("test/testdata"), ".")
+	require.NoError(t, err)
+
+	failed := make(map[string][]string)
+	for _, result := range results.GetFailed() {
+		failed[result.Rule().AVDID] = append(failed[result.Rule().AVDID], result.Flatten().Resource)
+	}
+
+	// the public access block refers to the bucket by an id which is only known after apply
+	assert.NotContains(t, failed["AVD-AWS-0086"], "aws_s3_bucket.example")
+	assert.Contains(t, failed["AVD-AWS-0086"], "aws_s3_bucket.this[0]")
+
+	// versioning is only enabled for the root bucket
+	assert.NotContains(t, failed["AVD-AWS-0090"], "aws_s3_bucket.example")
+	assert.Contains(t, failed["AVD-AWS-0090"], "aws_s3_bucket.this[0]")
+
+	for _, result := range results {
+		if result.Status() != scan.StatusFailed {
+			continue
+		}
+		assert.Contains(t, []string{"plan.json/main.tf", "plan.json/modules/logs/main.tf"}, result.Range().GetFilename())
+	}
+}
+
+func Test_NonPlanJSONIsSkipped(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"code/data.json": `{"format_version": "1.0", "x": 123}`,
+	})
+
+	_, err := New().ScanFile(context.TODO(), fs, "code/data.json")
+	assert.Error(t, err)
+
+	results, err := New().ScanFS(context.TODO(), fs, "code")
+	require.NoError(t, err)
+	assert.Len(t, results, 0)
+}
@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/liamg/jfather"
+)
+
+type Parser struct {
+	debugWriter io.Writer
+}
+
+type Option func(p *Parser)
+
+func OptionWithDebugWriter(w io.Writer) Option {
+	return func(p *Parser) {
+		p.debugWriter = w
+	}
+}
+
+// New creates a new parser
+func New(options ...Option) *Parser {
+	p := &Parser{}
+	for _, option := range options {
+		option(p)
+	}
+	return p
+}
+
+func (p *Parser) debug(format string, args ...interface{}) {
+	if p.debugWriter == nil {
+		return
+	}
+	prefix := "[debug:parse:terraformstate] "
+	_, _ = p.debugWriter.Write([]byte(fmt.Sprintf(prefix+format+"\n", args...)))
+}
+
+// ParseFile parses the terraform state file at the given path
+func (p *Parser) ParseFile(filesystem fs.FS, path string) (*State, error) {
+	f, err := filesystem.Open(filepath.ToSlash(path))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return p.Parse(f)
+}
+
+// Parse parses terraform state from the provided reader. Only version 4 state files are supported.
+func (p *Parser) Parse(reader io.Reader) (*State, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	var state State
+	if err := jfather.Unmarshal(content, &state); err != nil {
+		return nil, err
+	}
+	if state.Version != 4 {
+		return nil, fmt.Errorf("unsupported terraform state version: %d", state.Version)
+	}
+	p.debug("Parsed state with %d resources (terraform version %s).", len(state.Resources), state.TerraformVersion)
+	return &state, nil
+}
+
+// Required returns true if the file at the given path is a terraform state file
+func (p *Parser) Required(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".tfstate")
+}
@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_StateToFS(t *testing.T) {
+
+	input := `{
+  "version": 4,
+  "resources": [
+    {
+      "module": "module.a.module.b[\"x\"]",
+      "mode": "data",
+      "type": "aws_iam_policy_document",
+      "name": "policy",
+      "instances": [
+        {
+          "attributes": {
+            "json": "{}"
+          }
+        }
+      ]
+    }
+  ]
+}`
+
+	state, err := New().Parse(strings.NewReader(input))
+	require.NoError(t, err)
+
+	require.Len(t, state.Resources, 1)
+	resource := state.Resources[0]
+	require.Len(t, resource.Instances, 1)
+	assert.Equal(t, `module.a.module.b["x"].data.aws_iam_policy_document.policy`, resource.Address(resource.Instances[0]))
+	assert.Equal(t, 10, resource.Instances[0].StartLine)
+	assert.Equal(t, 14, resource.Instances[0].EndLine)
+
+	memfs, err := state.ToFS()
+	require.NoError(t, err)
+
+	root, err := memfs.ReadFile("main.tf")
+	require.NoError(t, err)
+	assert.Equal(t, `module "a" {
+  source = "./modules/a"
+}
+`, string(root))
+
+	a, err := memfs.ReadFile("modules/a/main.tf")
+	require.NoError(t, err)
+	assert.Equal(t, `module "b[\"x\"]" {
+  source = "./modules/b__x__"
+}
+`, string(a))
+
+	b, err := memfs.ReadFile("modules/a/modules/b__x__/main.tf")
+	require.NoError(t, err)
+	assert.Equal(t, `data "aws_iam_policy_document" "policy" {
+  json = "{}"
+}
+`, string(b))
+}
@@ -0,0 +1,137 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	planparser "github.com/aquasecurity/defsec/pkg/scanners/terraformplan/parser"
+	"github.com/liamg/jfather"
+	"github.com/liamg/memoryfs"
+)
+
+// State is the subset of a version 4 terraform state file which is required to reconstruct deployed resources
+type State struct {
+	Version          int        `json:"version"`
+	TerraformVersion string     `json:"terraform_version"`
+	Resources        []Resource `json:"resources"`
+}
+
+type Resource struct {
+	Module    string     `json:"module"`
+	Mode      string     `json:"mode"`
+	Type      string     `json:"type"`
+	Name      string     `json:"name"`
+	Instances []Instance `json:"instances"`
+}
+
+type Instance struct {
+	IndexKey   interface{}            `json:"index_key"`
+	Attributes map[string]interface{} `json:"attributes"`
+	StartLine  int                    `json:"-"`
+	EndLine    int                    `json:"-"`
+}
+
+type instanceInner struct {
+	IndexKey   interface{}            `json:"index_key"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+func (i *Instance) UnmarshalJSONWithMetadata(node jfather.Node) error {
+	var inner instanceInner
+	if err := node.Decode(&inner); err != nil {
+		return err
+	}
+	i.IndexKey = inner.IndexKey
+	i.Attributes = inner.Attributes
+	i.StartLine = node.Range().Start.Line
+	i.EndLine = node.Range().End.Line
+	return nil
+}
+
+// Address returns the address of the given instance of the resource, including the module path
+func (r *Resource) Address(instance Instance) string {
+	address := fmt.Sprintf("%s.%s", r.Type, r.Name)
+	if r.Mode == "data" {
+		address = "data." + address
+	}
+	switch key := instance.IndexKey.(type) {
+	case nil:
+	case string:
+		address += fmt.Sprintf("[%q]", key)
+	default:
+		address += fmt.Sprintf("[%v]", key)
+	}
+	if r.Module != "" {
+		address = r.Module + "." + address
+	}
+	return address
+}
+
+// ToFS reconstructs the resources in the state as HCL in an in-memory filesystem, with the module hierarchy preserved
+func (s *State) ToFS() (*memoryfs.FS, error) {
+	return s.toPlan().ToFS()
+}
+
+func (s *State) toPlan() *planparser.PlanFile {
+	modules := make(map[string]*planparser.PlanModule)
+	root := &planparser.PlanModule{}
+	modules[""] = root
+
+	for _, resource := range s.Resources {
+		module := findOrCreateModule(modules, resource.Module)
+		for _, instance := range resource.Instances {
+			module.Resources = append(module.Resources, planparser.PlanResource{
+				Address: resource.Address(instance),
+				Mode:    resource.Mode,
+				Type:    resource.Type,
+				Name:    resource.Name,
+				Index:   instance.IndexKey,
+				Values:  instance.Attributes,
+			})
+		}
+	}
+
+	return &planparser.PlanFile{
+		FormatVersion: "state",
+		PlannedValues: &planparser.PlannedValues{
+			RootModule: buildModuleTree(modules, ""),
+		},
+	}
+}
+
+func findOrCreateModule(modules map[string]*planparser.PlanModule, address string) *planparser.PlanModule {
+	if module, ok := modules[address]; ok {
+		return module
+	}
+	module := &planparser.PlanModule{
+		Address: address,
+	}
+	modules[address] = module
+	findOrCreateModule(modules, parentModule(address))
+	return module
+}
+
+// parentModule returns the address of the module containing the module with the given address
+func parentModule(address string) string {
+	index := strings.LastIndex(address, ".module.")
+	if index < 0 {
+		return ""
+	}
+	return address[:index]
+}
+
+func buildModuleTree(modules map[string]*planparser.PlanModule, address string) planparser.PlanModule {
+	module := *modules[address]
+	var children []string
+	for childAddress := range modules {
+		if childAddress != "" && childAddress != address && parentModule(childAddress) == address {
+			children = append(children, childAddress)
+		}
+	}
+	sort.Strings(children)
+	for _, childAddress := range children {
+		module.ChildModules = append(module.ChildModules, buildModuleTree(modules, childAddress))
+	}
+	return module
+}
@@ -0,0 +1,134 @@
+package terraformstate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/ignore"
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/scanners"
+	"github.com/aquasecurity/defsec/pkg/scanners/terraform"
+	"github.com/aquasecurity/defsec/pkg/scanners/terraformstate/parser"
+	tf "github.com/aquasecurity/defsec/pkg/terraform"
+)
+
+var _ scanners.Scanner = (*Scanner)(nil)
+
+// Scanner scans version 4 terraform state files. Resource instances are reconstructed as HCL and scanned with the
+// terraform scanner, and results are mapped back to the instance in the state file.
+type Scanner struct {
+	debugWriter   io.Writer
+	parserOpts    []parser.Option
+	terraformOpts []terraform.Option
+	suppressions  ignore.Suppressions
+}
+
+func New(options ...Option) *Scanner {
+	s := &Scanner{}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+func (s *Scanner) debug(format string, args ...interface{}) {
+	if s.debugWriter == nil {
+		return
+	}
+	prefix := "[debug:scan:terraformstate] "
+	_, _ = s.debugWriter.Write([]byte(fmt.Sprintf(prefix+format+"\n", args...)))
+}
+
+func (s *Scanner) ScanFS(ctx context.Context, target fs.FS, dir string) (scan.Results, error) {
+	p := parser.New(s.parserOpts...)
+	var results scan.Results
+	if err := fs.WalkDir(target, filepath.ToSlash(dir), func(path string, entry fs.DirEntry, err error) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !p.Required(path) {
+			return nil
+		}
+		state, err := p.ParseFile(target, path)
+		if err != nil {
+			s.debug("Failed to parse state %s: %s", path, err)
+			return nil
+		}
+		stateResults, err := s.scanState(ctx, target, path, state)
+		if err != nil {
+			return err
+		}
+		results = append(results, stateResults...)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ScanFile scans a single state file
+func (s *Scanner) ScanFile(ctx context.Context, target fs.FS, path string) (scan.Results, error) {
+	state, err := parser.New(s.parserOpts...).ParseFile(target, path)
+	if err != nil {
+		return nil, err
+	}
+	return s.scanState(ctx, target, path, state)
+}
+
+func (s *Scanner) scanState(ctx context.Context, target fs.FS, path string, state *parser.State) (scan.Results, error) {
+	s.debug("Scanning state %s...", path)
+	stateFS, err := state.ToFS()
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstruct state %s: %w", path, err)
+	}
+	options := append([]terraform.Option{terraform.OptionWithPolicyFilesystem(target)}, s.terraformOpts...)
+	// suppressions are matched against the state file rather than the reconstructed HCL
+	options = append(options, terraform.OptionWithSuppressions(nil))
+	results, err := terraform.New(options...).ScanFS(ctx, stateFS, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := make(map[string]types.Range)
+	for _, resource := range state.Resources {
+		for _, instance := range resource.Instances {
+			ranges[resource.Address(instance)] = types.NewRange(filepath.ToSlash(path), instance.StartLine, instance.EndLine, "", target)
+		}
+	}
+
+	// there are no meaningful HCL ranges for state, so point results at the resource instance in the state file instead
+	for i, result := range results {
+		address := resourceAddress(result.Metadata())
+		rng, ok := ranges[address]
+		if !ok {
+			rng = types.NewRange(filepath.ToSlash(path), 0, 0, "", target)
+		}
+		results[i].OverrideMetadata(types.NewMetadata(rng, types.NewNamedReference(address)))
+	}
+	results.ApplySuppressions(s.suppressions)
+
+	return results, nil
+}
+
+// resourceAddress finds the top-level block for the given metadata, stopping at the module boundary
+func resourceAddress(metadata types.Metadata) string {
+	for metadata.Parent() != nil {
+		if ref, ok := metadata.Parent().Reference().(*tf.Reference); ok && ref.BlockType() == tf.TypeModule {
+			break
+		}
+		metadata = *metadata.Parent()
+	}
+	if ref, ok := metadata.Reference().(*tf.Reference); ok {
+		return ref.FullAddress()
+	}
+	return metadata.Reference().LogicalID()
+}
@@ -0,0 +1,55 @@
+package terraformstate
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/aquasecurity/defsec/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ScanStateFile(t *testing.T) {
+
+	results, err := New().ScanFS(context.TODO(), os.DirFS("test/testdata"), ".")
+	require.NoError(t, err)
+
+	failed := make(map[string][]string)
+	for _, result := range results.GetFailed() {
+		failed[result.Rule().AVDID] = append(failed[result.Rule().AVDID], result.Flatten().Resource)
+		assert.Equal(t, "terraform.tfstate", result.Range().GetFilename())
+	}
+
+	// encryption is configured inline on the root bucket only
+	assert.Equal(t, []string{"module.logs.aws_s3_bucket.this[0]"}, failed["AVD-AWS-0088"])
+
+	// the public access block refers to the root bucket by name
+	assert.Equal(t, []string{"module.logs.aws_s3_bucket.this[0]"}, failed["AVD-AWS-0086"])
+
+	for _, result := range results.GetFailed() {
+		switch result.Flatten().Resource {
+		case "aws_s3_bucket.example":
+			assert.Equal(t, 14, result.Range().GetStartLine())
+			assert.Equal(t, 46, result.Range().GetEndLine())
+		case "module.logs.aws_s3_bucket.this[0]":
+			assert.Equal(t, 79, result.Range().GetStartLine())
+			assert.Equal(t, 89, result.Range().GetEndLine())
+		default:
+			t.Errorf("unexpected resource: %s", result.Flatten().Resource)
+		}
+	}
+}
+
+func Test_UnsupportedStateVersion(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"code/terraform.tfstate": `{"version": 3, "modules": []}`,
+	})
+
+	_, err := New().ScanFile(context.TODO(), fs, "code/terraform.tfstate")
+	assert.Error(t, err)
+
+	results, err := New().ScanFS(context.TODO(), fs, "code")
+	require.NoError(t, err)
+	assert.Len(t, results, 0)
+}
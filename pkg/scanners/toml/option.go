@@ -2,6 +2,8 @@ package toml
 
 import (
 	"io"
+
+	"github.com/aquasecurity/defsec/pkg/ignore"
 )
 
 type Option func(s *Scanner)
@@ -43,3 +45,18 @@ func OptionWithPerResultTracing() Option {
 		s.traceWriter = io.Discard
 	}
 }
+
+// OptionWithSuppressions - ignore results matching entries of a suppression file, e.g. .defsecignore
+func OptionWithSuppressions(suppressions ignore.Suppressions) Option {
+	return func(s *Scanner) {
+		s.suppressions = suppressions
+	}
+}
+
+// OptionReportStaleIgnores - add a result for each inline ignore directive which is expired, refers to an unknown rule
+// or did not ignore anything
+func OptionReportStaleIgnores(report bool) Option {
+	return func(s *Scanner) {
+		s.reportStale = report
+	}
+}
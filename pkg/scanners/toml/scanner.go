@@ -9,6 +9,7 @@ import (
 	"sync"
 
 	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/ignore"
 	"github.com/aquasecurity/defsec/pkg/rego"
 	"github.com/aquasecurity/defsec/pkg/scan"
 	"github.com/aquasecurity/defsec/pkg/scanners"
@@ -25,6 +26,8 @@ type Scanner struct {
 	policyNamespaces []string
 	parser           *parser.Parser
 	regoScanner      *rego.Scanner
+	suppressions     ignore.Suppressions
+	reportStale      bool
 	sync.Mutex
 }
 
@@ -117,6 +120,16 @@ func (s *Scanner) scanRego(ctx context.Context, srcFS fs.FS, inputs ...rego.Inpu
 	if err != nil {
 		return nil, err
 	}
+	var paths []string
+	for _, input := range inputs {
+		paths = append(paths, input.Path)
+	}
+	directives := ignore.ParseFS(srcFS, paths...)
+	used := results.ApplyIgnores(directives)
+	results.ApplySuppressions(s.suppressions)
+	if s.reportStale {
+		results.AddStaleIgnores(directives, used)
+	}
 	results.SetSourceAndFilesystem("", srcFS)
 	return results, nil
 }
@@ -3,15 +3,20 @@ package universal
 import (
 	"io"
 
+	"github.com/aquasecurity/defsec/pkg/ignore"
 	"github.com/aquasecurity/defsec/pkg/scanners/json"
 	"github.com/aquasecurity/defsec/pkg/scanners/yaml"
 
 	"github.com/aquasecurity/defsec/pkg/scanners/toml"
 
+	"github.com/aquasecurity/defsec/pkg/scanners/azure/arm"
+	"github.com/aquasecurity/defsec/pkg/scanners/azure/bicep"
 	"github.com/aquasecurity/defsec/pkg/scanners/cloudformation"
 	"github.com/aquasecurity/defsec/pkg/scanners/dockerfile"
 	"github.com/aquasecurity/defsec/pkg/scanners/kubernetes"
 	"github.com/aquasecurity/defsec/pkg/scanners/terraform"
+	"github.com/aquasecurity/defsec/pkg/scanners/terraformplan"
+	"github.com/aquasecurity/defsec/pkg/scanners/terraformstate"
 )
 
 type Option func(*Scanner)
@@ -21,7 +26,11 @@ func OptionWithDebug(w io.Writer) Option {
 	return func(s *Scanner) {
 		s.debugWriter = w
 		s.terraformOpts = append(s.terraformOpts, terraform.OptionWithDebug(w))
+		s.terraformPlanOpts = append(s.terraformPlanOpts, terraformplan.OptionWithDebug(w))
+		s.terraformStateOpts = append(s.terraformStateOpts, terraformstate.OptionWithDebug(w))
 		s.cloudformationOpts = append(s.cloudformationOpts, cloudformation.OptionWithDebug(w))
+		s.armOpts = append(s.armOpts, arm.OptionWithDebug(w))
+		s.bicepOpts = append(s.bicepOpts, bicep.OptionWithDebug(w))
 		s.dockerfileOpts = append(s.dockerfileOpts, dockerfile.OptionWithDebug(w))
 		s.kubernetesOpts = append(s.kubernetesOpts, kubernetes.OptionWithDebug(w))
 		s.tomlOpts = append(s.tomlOpts, toml.OptionWithDebug(w))
@@ -36,6 +45,8 @@ func OptionWithTrace(w io.Writer) Option {
 		s.debugWriter = w
 		s.terraformOpts = append(s.terraformOpts, terraform.OptionWithTrace(w))
 		s.cloudformationOpts = append(s.cloudformationOpts, cloudformation.OptionWithTrace(w))
+		s.armOpts = append(s.armOpts, arm.OptionWithTrace(w))
+		s.bicepOpts = append(s.bicepOpts, bicep.OptionWithTrace(w))
 		s.dockerfileOpts = append(s.dockerfileOpts, dockerfile.OptionWithTrace(w))
 		s.kubernetesOpts = append(s.kubernetesOpts, kubernetes.OptionWithTrace(w))
 		s.tomlOpts = append(s.tomlOpts, toml.OptionWithTrace(w))
@@ -53,14 +64,28 @@ func OptionWithPerResultTracing() Option {
 // OptionWithTerraformWorkspace specify Terraform workspace
 func OptionWithTerraformWorkspace(ws string) Option {
 	return func(s *Scanner) {
-		s.terraformOpts = append(s.terraformOpts, terraform.OptionWithWorkspaceName(ws))
+		s.terraformInputOpts = append(s.terraformInputOpts, terraform.OptionWithWorkspaceName(ws))
 	}
 }
 
 // OptionWithTerraformVarsPaths paths to tfvars files for Terraform
 func OptionWithTerraformVarsPaths(paths []string) Option {
 	return func(s *Scanner) {
-		s.terraformOpts = append(s.terraformOpts, terraform.OptionWithTFVarsPaths(paths))
+		s.terraformInputOpts = append(s.terraformInputOpts, terraform.OptionWithTFVarsPaths(paths))
+	}
+}
+
+// OptionWithHelmValuesFiles paths to values files used when rendering Helm charts
+func OptionWithHelmValuesFiles(paths []string) Option {
+	return func(s *Scanner) {
+		s.kubernetesOpts = append(s.kubernetesOpts, kubernetes.OptionWithHelmValuesFiles(paths...))
+	}
+}
+
+// OptionWithHelmValues --set style overrides used when rendering Helm charts
+func OptionWithHelmValues(values []string) Option {
+	return func(s *Scanner) {
+		s.kubernetesOpts = append(s.kubernetesOpts, kubernetes.OptionWithHelmValues(values...))
 	}
 }
 
@@ -69,6 +94,8 @@ func OptionWithPolicyDirs(dirs []string) func(s *Scanner) {
 	return func(s *Scanner) {
 		s.terraformOpts = append(s.terraformOpts, terraform.OptionWithPolicyDirs(dirs...))
 		s.cloudformationOpts = append(s.cloudformationOpts, cloudformation.OptionWithPolicyDirs(dirs...))
+		s.armOpts = append(s.armOpts, arm.OptionWithPolicyDirs(dirs...))
+		s.bicepOpts = append(s.bicepOpts, bicep.OptionWithPolicyDirs(dirs...))
 		s.dockerfileOpts = append(s.dockerfileOpts, dockerfile.OptionWithPolicyDirs(dirs...))
 		s.kubernetesOpts = append(s.kubernetesOpts, kubernetes.OptionWithPolicyDirs(dirs...))
 		s.tomlOpts = append(s.tomlOpts, toml.OptionWithPolicyDirs(dirs...))
@@ -82,6 +109,8 @@ func OptionWithDataDirs(dirs []string) func(s *Scanner) {
 	return func(s *Scanner) {
 		s.terraformOpts = append(s.terraformOpts, terraform.OptionWithDataDirs(dirs...))
 		s.cloudformationOpts = append(s.cloudformationOpts, cloudformation.OptionWithDataDirs(dirs...))
+		s.armOpts = append(s.armOpts, arm.OptionWithDataDirs(dirs...))
+		s.bicepOpts = append(s.bicepOpts, bicep.OptionWithDataDirs(dirs...))
 		s.dockerfileOpts = append(s.dockerfileOpts, dockerfile.OptionWithDataDirs(dirs...))
 		s.kubernetesOpts = append(s.kubernetesOpts, kubernetes.OptionWithDataDirs(dirs...))
 		s.tomlOpts = append(s.tomlOpts, toml.OptionWithDataDirs(dirs...))
@@ -95,6 +124,8 @@ func OptionWithPolicyNamespaces(namespaces ...string) func(s *Scanner) {
 	return func(s *Scanner) {
 		s.terraformOpts = append(s.terraformOpts, terraform.OptionWithPolicyNamespaces(namespaces...))
 		s.cloudformationOpts = append(s.cloudformationOpts, cloudformation.OptionWithPolicyNamespaces(namespaces...))
+		s.armOpts = append(s.armOpts, arm.OptionWithPolicyNamespaces(namespaces...))
+		s.bicepOpts = append(s.bicepOpts, bicep.OptionWithPolicyNamespaces(namespaces...))
 		s.dockerfileOpts = append(s.dockerfileOpts, dockerfile.OptionWithPolicyNamespaces(namespaces...))
 		s.kubernetesOpts = append(s.kubernetesOpts, kubernetes.OptionWithPolicyNamespaces(namespaces...))
 		s.tomlOpts = append(s.tomlOpts, toml.OptionWithPolicyNamespaces(namespaces...))
@@ -102,3 +133,37 @@ func OptionWithPolicyNamespaces(namespaces ...string) func(s *Scanner) {
 		s.yamlOpts = append(s.yamlOpts, yaml.OptionWithPolicyNamespaces(namespaces...))
 	}
 }
+
+// OptionWithSuppressions - ignore results matching entries of a suppression file, e.g. .defsecignore
+func OptionWithSuppressions(suppressions ignore.Suppressions) Option {
+	return func(s *Scanner) {
+		s.terraformOpts = append(s.terraformOpts, terraform.OptionWithSuppressions(suppressions))
+		s.terraformPlanOpts = append(s.terraformPlanOpts, terraformplan.OptionWithSuppressions(suppressions))
+		s.terraformStateOpts = append(s.terraformStateOpts, terraformstate.OptionWithSuppressions(suppressions))
+		s.cloudformationOpts = append(s.cloudformationOpts, cloudformation.OptionWithSuppressions(suppressions))
+		s.armOpts = append(s.armOpts, arm.OptionWithSuppressions(suppressions))
+		s.bicepOpts = append(s.bicepOpts, bicep.OptionWithSuppressions(suppressions))
+		s.dockerfileOpts = append(s.dockerfileOpts, dockerfile.OptionWithSuppressions(suppressions))
+		s.kubernetesOpts = append(s.kubernetesOpts, kubernetes.OptionWithSuppressions(suppressions))
+		s.tomlOpts = append(s.tomlOpts, toml.OptionWithSuppressions(suppressions))
+		s.jsonOpts = append(s.jsonOpts, json.OptionWithSuppressions(suppressions))
+		s.yamlOpts = append(s.yamlOpts, yaml.OptionWithSuppressions(suppressions))
+	}
+}
+
+// OptionReportStaleIgnores - add a result for each inline ignore directive which is expired, refers to an unknown rule
+// or did not ignore anything in any of the scanners which read its file
+func OptionReportStaleIgnores(report bool) Option {
+	return func(s *Scanner) {
+		s.reportStale = report
+		s.terraformOpts = append(s.terraformOpts, terraform.OptionReportStaleIgnores(report))
+		s.cloudformationOpts = append(s.cloudformationOpts, cloudformation.OptionReportStaleIgnores(report))
+		s.armOpts = append(s.armOpts, arm.OptionReportStaleIgnores(report))
+		s.bicepOpts = append(s.bicepOpts, bicep.OptionReportStaleIgnores(report))
+		s.dockerfileOpts = append(s.dockerfileOpts, dockerfile.OptionReportStaleIgnores(report))
+		s.kubernetesOpts = append(s.kubernetesOpts, kubernetes.OptionReportStaleIgnores(report))
+		s.tomlOpts = append(s.tomlOpts, toml.OptionReportStaleIgnores(report))
+		s.jsonOpts = append(s.jsonOpts, json.OptionReportStaleIgnores(report))
+		s.yamlOpts = append(s.yamlOpts, yaml.OptionReportStaleIgnores(report))
+	}
+}
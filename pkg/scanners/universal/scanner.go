@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"path"
+	"reflect"
+	"time"
 
 	"github.com/aquasecurity/defsec/pkg/scanners/json"
 	"github.com/aquasecurity/defsec/pkg/scanners/toml"
@@ -13,10 +16,14 @@ import (
 	"github.com/aquasecurity/defsec/pkg/scan"
 
 	"github.com/aquasecurity/defsec/pkg/scanners"
+	"github.com/aquasecurity/defsec/pkg/scanners/azure/arm"
+	"github.com/aquasecurity/defsec/pkg/scanners/azure/bicep"
 	"github.com/aquasecurity/defsec/pkg/scanners/cloudformation"
 	"github.com/aquasecurity/defsec/pkg/scanners/dockerfile"
 	"github.com/aquasecurity/defsec/pkg/scanners/kubernetes"
 	"github.com/aquasecurity/defsec/pkg/scanners/terraform"
+	"github.com/aquasecurity/defsec/pkg/scanners/terraformplan"
+	"github.com/aquasecurity/defsec/pkg/scanners/terraformstate"
 )
 
 var _ scanners.Scanner = (*Scanner)(nil)
@@ -25,12 +32,18 @@ type Scanner struct {
 	debugWriter        io.Writer
 	scanners           []scanners.Scanner
 	terraformOpts      []terraform.Option
+	terraformInputOpts []terraform.Option
+	terraformPlanOpts  []terraformplan.Option
+	terraformStateOpts []terraformstate.Option
 	cloudformationOpts []cloudformation.Option
+	armOpts            []arm.Option
+	bicepOpts          []bicep.Option
 	dockerfileOpts     []dockerfile.Option
 	kubernetesOpts     []kubernetes.Option
 	tomlOpts           []toml.Option
 	jsonOpts           []json.Option
 	yamlOpts           []yaml.Option
+	reportStale        bool
 }
 
 func (s *Scanner) debug(format string, args ...interface{}) {
@@ -47,8 +60,14 @@ func New(opts ...Option) *Scanner {
 		opt(s)
 	}
 	s.scanners = []scanners.Scanner{
-		terraform.New(s.terraformOpts...),
+		// input options such as tfvars are not passed on to the modules reconstructed from plans and state, as their
+		// values are already resolved
+		terraform.New(append(append([]terraform.Option{}, s.terraformOpts...), s.terraformInputOpts...)...),
+		terraformplan.New(append(s.terraformPlanOpts, terraformplan.OptionWithTerraformOptions(s.terraformOpts...))...),
+		terraformstate.New(append(s.terraformStateOpts, terraformstate.OptionWithTerraformOptions(s.terraformOpts...))...),
 		cloudformation.New(s.cloudformationOpts...),
+		arm.New(s.armOpts...),
+		bicep.New(s.bicepOpts...),
 		dockerfile.NewScanner(s.dockerfileOpts...),
 		kubernetes.NewScanner(s.kubernetesOpts...),
 		json.NewScanner(s.jsonOpts...),
@@ -58,15 +77,56 @@ func New(opts ...Option) *Scanner {
 	return s
 }
 
+// Metrics records the time taken by the scan as a whole and by each scanner
+type Metrics struct {
+	Scanners []ScannerMetrics
+	Timings  struct {
+		Total time.Duration
+	}
+}
+
+type ScannerMetrics struct {
+	// Name is the name of the scanner package, e.g. "terraform"
+	Name     string
+	Results  int
+	Duration time.Duration
+}
+
 func (s *Scanner) ScanFS(ctx context.Context, fs fs.FS, dir string) (scan.Results, error) {
+	results, _, err := s.ScanFSWithMetrics(ctx, fs, dir)
+	return results, err
+}
+
+func (s *Scanner) ScanFSWithMetrics(ctx context.Context, fs fs.FS, dir string) (scan.Results, Metrics, error) {
+	var metrics Metrics
 	var results scan.Results
+	start := time.Now()
 	for _, inner := range s.scanners {
 		s.debug("Scanning with %T...\n", inner)
+		innerStart := time.Now()
 		innerResults, err := inner.ScanFS(ctx, fs, dir)
 		if err != nil {
-			return nil, err
+			return nil, metrics, err
 		}
+		metrics.Scanners = append(metrics.Scanners, ScannerMetrics{
+			Name:     scannerName(inner),
+			Results:  len(innerResults),
+			Duration: time.Since(innerStart),
+		})
 		results = append(results, innerResults...)
 	}
-	return results, nil
+	if s.reportStale {
+		// several scanners may read the same file, so a directive used by one is not stale for the others
+		results = results.MergeStaleIgnores()
+	}
+	metrics.Timings.Total = time.Since(start)
+	return results, metrics, nil
+}
+
+func scannerName(scanner scanners.Scanner) string {
+	t := reflect.TypeOf(scanner)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return path.Base(t.PkgPath())
 }
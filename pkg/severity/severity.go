@@ -31,6 +31,22 @@ func (s *Severity) Valid() []Severity {
 	return ValidSeverity
 }
 
+// Ordinal returns the rank of a severity, from 0 for None to 4 for Critical, so severities can be compared
+func (s Severity) Ordinal() int {
+	switch s {
+	case Critical:
+		return 4
+	case High:
+		return 3
+	case Medium:
+		return 2
+	case Low:
+		return 1
+	default:
+		return 0
+	}
+}
+
 func StringToSeverity(sev string) Severity {
 	s := strings.ToUpper(sev)
 	switch s {
@@ -0,0 +1,15 @@
+package severity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Ordinal(t *testing.T) {
+	assert.Equal(t, 0, None.Ordinal())
+	assert.Less(t, Low.Ordinal(), Medium.Ordinal())
+	assert.Less(t, Medium.Ordinal(), High.Ordinal())
+	assert.Less(t, High.Ordinal(), Critical.Ordinal())
+	assert.Equal(t, 0, Severity("UNKNOWN").Ordinal())
+}
@@ -2,48 +2,35 @@ package terraform
 
 import (
 	"fmt"
-	"time"
 
 	"github.com/aquasecurity/defsec/internal/types"
+	"github.com/aquasecurity/defsec/pkg/ignore"
 
 	"github.com/zclconf/go-cty/cty"
 )
 
-type Ignore struct {
-	Range     types.Range
-	RuleID    string
-	Expiry    *time.Time
-	Workspace string
-	Block     bool
-	Params    map[string]string
-}
+// Ignore is an inline ignore directive, e.g. #tfsec:ignore:aws-s3-enable-bucket-encryption
+type Ignore = ignore.Directive
 
 type Ignores []Ignore
 
 func (ignores Ignores) Covering(modules Modules, m types.Metadata, workspace string, ids ...string) *Ignore {
-	for _, ignore := range ignores {
-		if ignore.Covering(modules, m, workspace, ids...) {
-			return &ignore
+	for i, ignore := range ignores {
+		if ignoreCovering(ignore, modules, m, workspace, ids...) {
+			return &ignores[i]
 		}
 	}
 	return nil
 }
 
-func (ignore Ignore) Covering(modules Modules, m types.Metadata, workspace string, ids ...string) bool {
-	if ignore.Expiry != nil && time.Now().After(*ignore.Expiry) {
+func ignoreCovering(ignore Ignore, modules Modules, m types.Metadata, workspace string, ids ...string) bool {
+	if ignore.Expired() {
 		return false
 	}
 	if ignore.Workspace != "" && ignore.Workspace != workspace {
 		return false
 	}
-	idMatch := ignore.RuleID == "*" || len(ids) == 0
-	for _, id := range ids {
-		if id == ignore.RuleID {
-			idMatch = true
-			break
-		}
-	}
-	if !idMatch {
+	if !ignore.MatchesID(ids...) {
 		return false
 	}
 
@@ -52,12 +39,8 @@ func (ignore Ignore) Covering(modules Modules, m types.Metadata, workspace strin
 		if metaHierarchy.Range() == nil {
 			break
 		}
-		if ignore.Range.GetFilename() != metaHierarchy.Range().GetFilename() {
-			metaHierarchy = metaHierarchy.Parent()
-			continue
-		}
-		if metaHierarchy.Range().GetStartLine() == ignore.Range.GetStartLine()+1 || metaHierarchy.Range().GetStartLine() == ignore.Range.GetStartLine() {
-			return ignore.MatchParams(modules)
+		if ignoreCoversRange(ignore, metaHierarchy.Range()) {
+			return matchIgnoreParams(ignore, modules)
 		}
 		metaHierarchy = metaHierarchy.Parent()
 	}
@@ -65,11 +48,22 @@ func (ignore Ignore) Covering(modules Modules, m types.Metadata, workspace strin
 
 }
 
-func (ignore Ignore) MatchParams(modules Modules) bool {
+// ignoreCoversRange returns true if the ignore applies to a range. Terraform ignores have always applied to a range
+// starting on the line of the comment or the line below, wherever the comment is placed, so they are matched more
+// loosely than the directives of other scanners.
+func ignoreCoversRange(ignore Ignore, r types.Range) bool {
+	anchor := ignore.AnchorRange()
+	if anchor == nil || anchor.GetFilename() != r.GetFilename() {
+		return false
+	}
+	return r.GetStartLine() == anchor.GetStartLine() || r.GetStartLine() == anchor.GetStartLine()+1
+}
+
+func matchIgnoreParams(ignore Ignore, modules Modules) bool {
 	if len(ignore.Params) == 0 {
 		return true
 	}
-	block := modules.GetBlockByIgnoreRange(ignore.Range)
+	block := modules.GetBlockByIgnoreRange(ignore.AnchorRange())
 	if block == nil {
 		return true
 	}
@@ -103,6 +103,15 @@ func (r *Reference) HumanReadable() string {
 	return fmt.Sprintf("%s:%s", r.parent, r.String())
 }
 
+// FullAddress returns the address of the referenced block including the path of the module containing it,
+// e.g. module.a.aws_s3_bucket.b
+func (r *Reference) FullAddress() string {
+	if r.parent == "" {
+		return r.String()
+	}
+	return fmt.Sprintf("%s.%s", r.parent, r.String())
+}
+
 func (r *Reference) LogicalID() string {
 	return r.String()
 }
@@ -50,3 +50,14 @@ func Test_ReferenceParsing(t *testing.T) {
 		})
 	}
 }
+
+func Test_ReferenceFullAddress(t *testing.T) {
+	ref, err := newReference([]string{"resource", "aws_s3_bucket", "test[0]"}, "module.a.module.b")
+	require.NoError(t, err)
+	assert.Equal(t, "aws_s3_bucket.test[0]", ref.String())
+	assert.Equal(t, "module.a.module.b.aws_s3_bucket.test[0]", ref.FullAddress())
+
+	root, err := newReference([]string{"data", "aws_caller_identity", "current"}, "root")
+	require.NoError(t, err)
+	assert.Equal(t, "data.aws_caller_identity.current", root.FullAddress())
+}
@@ -326,3 +326,27 @@ resource "bad" "my-rule" {
 `, "testworkspace")
 	assert.Len(t, results.GetFailed(), 1)
 }
+
+func Test_IgnoreInlineAboveTheLine(t *testing.T) {
+	reg := rules.Register(exampleRule, nil)
+	defer rules.Deregister(reg)
+
+	results := scanHCL(t, `
+resource "bad" "my-rule" {
+    other = true // tfsec:ignore:*
+    secure = false
+}
+`)
+	assert.Len(t, results.GetFailed(), 0)
+}
+
+func Test_IgnoreBlockCommentOnTheSameLine(t *testing.T) {
+	reg := rules.Register(exampleRule, nil)
+	defer rules.Deregister(reg)
+
+	results := scanHCL(t, `
+/* tfsec:ignore:* */ resource "bad" "my-rule" {
+}
+`)
+	assert.Len(t, results.GetFailed(), 0)
+}